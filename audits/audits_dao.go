@@ -0,0 +1,340 @@
+package audits
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Dao struct{}
+
+func NewDao() *Dao {
+	return &Dao{}
+}
+
+const auditColumns = `id, name, location_id, department_id, status, created_at, created_by, closed_at, closed_by`
+
+func scanAudit(row pgx.Row, audit *utils.Audit) error {
+	return row.Scan(&audit.ID, &audit.Name, &audit.LocationID, &audit.DepartmentID, &audit.Status,
+		&audit.CreatedAt, &audit.CreatedBy, &audit.ClosedAt, &audit.ClosedBy)
+}
+
+func (d *Dao) GetAudit(ctx context.Context, tx pgx.Tx, id string) (*utils.Audit, error) {
+	utils.Log(ctx).Printf("Fetching audit with ID: %s", id)
+	query := `SELECT ` + auditColumns + ` FROM audits WHERE id = $1`
+	var audit utils.Audit
+	if err := scanAudit(tx.QueryRow(ctx, query, id), &audit); err != nil {
+		utils.Log(ctx).Errorf("Error fetching audit with ID %s: %v", id, err)
+		return nil, err
+	}
+	return &audit, nil
+}
+
+// LockAudit fetches an audit and locks it until the transaction ends.
+func (d *Dao) LockAudit(ctx context.Context, tx pgx.Tx, id string) (*utils.Audit, error) {
+	utils.Log(ctx).Printf("Locking audit with ID: %s", id)
+	query := `SELECT ` + auditColumns + ` FROM audits WHERE id = $1 FOR UPDATE`
+	var audit utils.Audit
+	if err := scanAudit(tx.QueryRow(ctx, query, id), &audit); err != nil {
+		utils.Log(ctx).Errorf("Error locking audit with ID %s: %v", id, err)
+		return nil, err
+	}
+	return &audit, nil
+}
+
+// GetAudits returns audits, newest first, optionally only those in a status.
+func (d *Dao) GetAudits(ctx context.Context, tx pgx.Tx, status string) ([]*utils.Audit, error) {
+	utils.Log(ctx).Printf("Fetching audits (status: %q)", status)
+	query := `SELECT ` + auditColumns + ` FROM audits WHERE ($1 = '' OR status = $1) ORDER BY created_at DESC`
+	rows, err := tx.Query(ctx, query, status)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching audits: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var audits []*utils.Audit
+	for rows.Next() {
+		var audit utils.Audit
+		if err := scanAudit(rows, &audit); err != nil {
+			utils.Log(ctx).Errorf("Error scanning audit row: %v", err)
+			return nil, err
+		}
+		audits = append(audits, &audit)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over audit rows: %v", err)
+		return nil, err
+	}
+	return audits, nil
+}
+
+func (d *Dao) CreateAudit(ctx context.Context, tx pgx.Tx, audit *utils.Audit) error {
+	utils.Log(ctx).Printf("Creating audit: %+v", audit)
+	if audit.ID == "" {
+		id, err := gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Error generating ID for new audit: %v", err)
+			return err
+		}
+		audit.ID = id
+	}
+	query := `INSERT INTO audits (` + auditColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := tx.Exec(ctx, query, audit.ID, audit.Name, audit.LocationID, audit.DepartmentID, audit.Status,
+		audit.CreatedAt, audit.CreatedBy, audit.ClosedAt, audit.ClosedBy)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error creating audit: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (d *Dao) CloseAudit(ctx context.Context, tx pgx.Tx, id string, closedAt time.Time, closedBy string) error {
+	utils.Log(ctx).Printf("Closing audit with ID: %s", id)
+	query := `UPDATE audits SET status = $2, closed_at = $3, closed_by = $4 WHERE id = $1`
+	tag, err := tx.Exec(ctx, query, id, utils.AuditClosed, closedAt, closedBy)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error closing audit with ID %s: %v", id, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// ExpectLocation records every device in a location or its sub-locations,
+// except lost ones, as expected by an audit. It returns how many there are.
+func (d *Dao) ExpectLocation(ctx context.Context, tx pgx.Tx, auditID string, locationID string) (int64, error) {
+	utils.Log(ctx).Printf("Expecting devices in location %s for audit %s", locationID, auditID)
+	query := `WITH RECURSIVE tree AS (
+		SELECT id FROM locations WHERE id = $2
+		UNION
+		SELECT l.id
+		FROM locations l
+		JOIN tree t ON l.parent_id = t.id
+	)
+	INSERT INTO audit_items (audit_id, device_id)
+	SELECT $1, id FROM devices
+	WHERE location_id IN (SELECT id FROM tree) AND status <> $3`
+	tag, err := tx.Exec(ctx, query, auditID, locationID, utils.DeviceStatusLost)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error expecting devices for audit %s: %v", auditID, err)
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ExpectDepartment records every device of a department or its
+// sub-departments, except lost ones, as expected by an audit. As in the
+// department report, a device belongs to the department of its cost center,
+// or of its owner when it has none.
+func (d *Dao) ExpectDepartment(ctx context.Context, tx pgx.Tx, auditID string, departmentID string) (int64, error) {
+	utils.Log(ctx).Printf("Expecting devices in department %s for audit %s", departmentID, auditID)
+	query := `WITH RECURSIVE tree AS (
+		SELECT id FROM departments WHERE id = $2
+		UNION
+		SELECT d.id
+		FROM departments d
+		JOIN tree t ON d.parent_id = t.id
+	)
+	INSERT INTO audit_items (audit_id, device_id)
+	SELECT $1, v.id
+	FROM devices v
+	JOIN owners o ON o.id = v.owner_id
+	LEFT JOIN departments c ON c.cost_center = v.cost_center
+	WHERE COALESCE(c.id, o.department_id) IN (SELECT id FROM tree) AND v.status <> $3`
+	tag, err := tx.Exec(ctx, query, auditID, departmentID, utils.DeviceStatusLost)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error expecting devices for audit %s: %v", auditID, err)
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// IsExpected reports whether a device is on the expected list of an audit.
+func (d *Dao) IsExpected(ctx context.Context, tx pgx.Tx, auditID string, deviceID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM audit_items WHERE audit_id = $1 AND device_id = $2)`
+	var expected bool
+	if err := tx.QueryRow(ctx, query, auditID, deviceID).Scan(&expected); err != nil {
+		utils.Log(ctx).Errorf("Error checking audit %s for device %s: %v", auditID, deviceID, err)
+		return false, err
+	}
+	return expected, nil
+}
+
+// FindDevices returns the IDs and current locations of the devices a scanned
+// code can stand for: an asset tag, a serial number or a device ID.
+func (d *Dao) FindDevices(ctx context.Context, tx pgx.Tx, code string) (map[string]*string, error) {
+	utils.Log(ctx).Printf("Finding devices for code: %s", code)
+	query := `SELECT id, location_id FROM devices WHERE asset_tag = upper($1) OR serial_number = $1 OR id = $1`
+	rows, err := tx.Query(ctx, query, code)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error finding devices for code %s: %v", code, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	devices := make(map[string]*string)
+	for rows.Next() {
+		var id string
+		var locationID *string
+		if err := rows.Scan(&id, &locationID); err != nil {
+			utils.Log(ctx).Errorf("Error scanning device row: %v", err)
+			return nil, err
+		}
+		devices[id] = locationID
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over device rows: %v", err)
+		return nil, err
+	}
+	return devices, nil
+}
+
+func (d *Dao) CreateScan(ctx context.Context, tx pgx.Tx, scan *utils.AuditScan) error {
+	utils.Log(ctx).Printf("Recording scan of %s in audit %s", scan.Code, scan.AuditID)
+	if scan.ID == "" {
+		id, err := gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Error generating ID for new audit scan: %v", err)
+			return err
+		}
+		scan.ID = id
+	}
+	query := `INSERT INTO audit_scans (id, audit_id, code, device_id, location_id, scanned_at, scanned_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := tx.Exec(ctx, query, scan.ID, scan.AuditID, scan.Code, scan.DeviceID, scan.LocationID, scan.ScannedAt, scan.ScannedBy)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error creating audit scan: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetScans returns every scan of an audit in the order they were made.
+func (d *Dao) GetScans(ctx context.Context, tx pgx.Tx, auditID string) ([]*utils.AuditScan, error) {
+	utils.Log(ctx).Printf("Fetching scans of audit with ID: %s", auditID)
+	query := `SELECT id, audit_id, code, device_id, location_id, scanned_at, scanned_by
+	FROM audit_scans
+	WHERE audit_id = $1
+	ORDER BY scanned_at, id`
+	rows, err := tx.Query(ctx, query, auditID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching audit scans: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scans []*utils.AuditScan
+	for rows.Next() {
+		var scan utils.AuditScan
+		if err := rows.Scan(&scan.ID, &scan.AuditID, &scan.Code, &scan.DeviceID, &scan.LocationID,
+			&scan.ScannedAt, &scan.ScannedBy); err != nil {
+			utils.Log(ctx).Errorf("Error scanning audit scan row: %v", err)
+			return nil, err
+		}
+		scans = append(scans, &scan)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over audit scan rows: %v", err)
+		return nil, err
+	}
+	return scans, nil
+}
+
+// GetExpected returns the devices an audit expects with their latest scan,
+// if any.
+func (d *Dao) GetExpected(ctx context.Context, tx pgx.Tx, auditID string) ([]*utils.AuditEntry, error) {
+	utils.Log(ctx).Printf("Fetching expected devices of audit with ID: %s", auditID)
+	query := `SELECT v.id, v.name, v.serial_number, v.status, v.location_id, s.location_id, s.scanned_at, s.scanned_by
+	FROM audit_items i
+	JOIN devices v ON v.id = i.device_id
+	LEFT JOIN LATERAL (
+		SELECT location_id, scanned_at, scanned_by FROM audit_scans
+		WHERE audit_id = i.audit_id AND device_id = i.device_id
+		ORDER BY scanned_at DESC
+		LIMIT 1
+	) s ON true
+	WHERE i.audit_id = $1
+	ORDER BY v.name, v.id`
+	return d.getEntries(ctx, tx, query, auditID)
+}
+
+// GetUnexpected returns the scanned devices an audit does not expect with
+// their latest scan.
+func (d *Dao) GetUnexpected(ctx context.Context, tx pgx.Tx, auditID string) ([]*utils.AuditEntry, error) {
+	utils.Log(ctx).Printf("Fetching unexpected devices of audit with ID: %s", auditID)
+	query := `SELECT DISTINCT ON (v.name, v.id) v.id, v.name, v.serial_number, v.status, v.location_id, s.location_id, s.scanned_at, s.scanned_by
+	FROM audit_scans s
+	JOIN devices v ON v.id = s.device_id
+	WHERE s.audit_id = $1
+		AND NOT EXISTS (SELECT 1 FROM audit_items i WHERE i.audit_id = s.audit_id AND i.device_id = s.device_id)
+	ORDER BY v.name, v.id, s.scanned_at DESC`
+	return d.getEntries(ctx, tx, query, auditID)
+}
+
+func (d *Dao) getEntries(ctx context.Context, tx pgx.Tx, query string, auditID string) ([]*utils.AuditEntry, error) {
+	rows, err := tx.Query(ctx, query, auditID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching audit entries: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*utils.AuditEntry
+	for rows.Next() {
+		var entry utils.AuditEntry
+		if err := rows.Scan(&entry.DeviceID, &entry.Name, &entry.SerialNumber, &entry.Status, &entry.LocationID,
+			&entry.ScannedLocationID, &entry.ScannedAt, &entry.ScannedBy); err != nil {
+			utils.Log(ctx).Errorf("Error scanning audit entry row: %v", err)
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over audit entry rows: %v", err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetUnknownCodes returns the distinct scanned codes that matched no device.
+func (d *Dao) GetUnknownCodes(ctx context.Context, tx pgx.Tx, auditID string) ([]string, error) {
+	utils.Log(ctx).Printf("Fetching unknown codes of audit with ID: %s", auditID)
+	query := `SELECT DISTINCT code FROM audit_scans WHERE audit_id = $1 AND device_id IS NULL ORDER BY code`
+	rows, err := tx.Query(ctx, query, auditID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching unknown codes: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var codes []string
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			utils.Log(ctx).Errorf("Error scanning code row: %v", err)
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over code rows: %v", err)
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (d *Dao) SetDeviceLocation(ctx context.Context, tx pgx.Tx, deviceID string, locationID *string) error {
+	utils.Log(ctx).Printf("Moving device %s to location %v", deviceID, locationID)
+	query := `UPDATE devices SET location_id = $2 WHERE id = $1`
+	if _, err := tx.Exec(ctx, query, deviceID, locationID); err != nil {
+		utils.Log(ctx).Errorf("Could not move device %s: %v", deviceID, err)
+		return err
+	}
+	return nil
+}
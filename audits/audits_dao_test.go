@@ -0,0 +1,158 @@
+package audits
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime"
+	"testing"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/locations"
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+var postgresURI string
+
+func init() {
+	viper.SetConfigName("app")
+	viper.AddConfigPath("../config")
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+	postgresURI = viper.GetString("postgres.dev") // Change to "postgres.dev" for development/local db
+	log.SetReportCaller(true)
+	log.SetFormatter(&log.TextFormatter{
+		ForceColors:     true,
+		FullTimestamp:   true,
+		TimestampFormat: "2006/01/02 15:04:05",
+		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
+			filename := path.Base(f.File)
+			return fmt.Sprintf("%s()", f.Function), fmt.Sprintf("\t%s:%d", filename, f.Line)
+		},
+	})
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestApplyCorrections(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+	svc := NewService(dao, utils.NewDB(pdb, nil, 0))
+
+	// Mock Data
+	var rooms []string
+	for _, name := range []string{"Test Lab 101", "Test Lab 102"} {
+		room := &utils.Location{Name: name, Kind: utils.LocationKindRoom}
+		if err := locations.NewDao().CreateLocation(ctx, tx, room); err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+		rooms = append(rooms, room.ID)
+	}
+	ownerID, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO owners (id, first_name, last_name, email)
+		VALUES ($1, $2, $3, $4)
+	`, ownerID, "John", "Doe", "john.doe@example.com")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	typeID, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO types (id, name, description) VALUES ($1, $2, $3)
+	`, typeID, "Test Type", "This is a test type")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	var devices []string
+	for i := 0; i < 3; i++ {
+		d_id, _ := gonanoid.New()
+		_, err = tx.Exec(ctx, `
+			INSERT INTO devices (id, serial_number, name, purchase_date, status, owner_id, type_id, location_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, d_id, fmt.Sprintf("SN-A%d", i), fmt.Sprintf("Test Device %d", i), "2023-01-01", "active", ownerID, typeID, rooms[0])
+		if err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+		devices = append(devices, d_id)
+	}
+	moved, found, missing := devices[0], devices[1], devices[2]
+	audit := &utils.Audit{
+		Name:       "Test Audit",
+		LocationID: &rooms[0],
+		Status:     utils.AuditOpen,
+		CreatedAt:  time.Now(),
+		CreatedBy:  "auditor",
+	}
+	if err := dao.CreateAudit(ctx, tx, audit); err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	if _, err := dao.ExpectLocation(ctx, tx, audit.ID, rooms[0]); err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	for i, deviceID := range []string{moved, found} {
+		scan := &utils.AuditScan{
+			AuditID:    audit.ID,
+			Code:       fmt.Sprintf("SN-A%d", i),
+			DeviceID:   &deviceID,
+			LocationID: &rooms[i],
+			ScannedAt:  time.Now(),
+			ScannedBy:  "auditor",
+		}
+		if err := dao.CreateScan(ctx, tx, scan); err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+	}
+	device := func(t *testing.T, deviceID string) (string, *string) {
+		var status string
+		var locationID *string
+		err := tx.QueryRow(ctx, `SELECT status, location_id FROM devices WHERE id = $1`, deviceID).Scan(&status, &locationID)
+		if err != nil {
+			t.Fatalf("Error getting device: %v", err)
+		}
+		return status, locationID
+	}
+	corrections := &Corrections{Relocate: true, MarkMissingLost: true, AppliedBy: "auditor"}
+
+	t.Run("ApplyCorrections", func(t *testing.T) {
+		entries, err := svc.applyCorrections(ctx, tx, audit.ID, corrections)
+		if err != nil {
+			t.Fatalf("Error applying corrections: %v", err)
+		}
+		if len(entries) != 2 {
+			t.Errorf("Expected 2 log entries, got %d", len(entries))
+		}
+		if _, location := device(t, moved); location == nil || *location != rooms[1] {
+			t.Errorf("Expected the device to be moved to %s, got %v", rooms[1], location)
+		}
+		if status, location := device(t, found); status != "active" || location == nil || *location != rooms[0] {
+			t.Errorf("Expected the found device to be unchanged, got %s in %v", status, location)
+		}
+		if status, _ := device(t, missing); status != utils.DeviceStatusLost {
+			t.Errorf("Expected the missing device to be %s, got %s", utils.DeviceStatusLost, status)
+		}
+	})
+	t.Run("ApplyCorrectionsAgain", func(t *testing.T) {
+		entries, err := svc.applyCorrections(ctx, tx, audit.ID, corrections)
+		if err != nil {
+			t.Fatalf("Error applying corrections: %v", err)
+		}
+		if len(entries) != 0 {
+			t.Errorf("Expected nothing left to correct, got %d log entries", len(entries))
+		}
+	})
+}
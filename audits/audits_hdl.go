@@ -0,0 +1,161 @@
+package audits
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Handler struct {
+	svc *Service
+	// atz *authz.Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{
+		svc: svc,
+		// atz: atz,
+	}
+}
+
+func (h *Handler) GetAudit(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	audit, err := h.svc.GetAudit(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(audit)
+}
+
+// GetAudits lists audits, optionally filtered by ?status=.
+func (h *Handler) GetAudits(w http.ResponseWriter, r *http.Request) {
+	audits, err := h.svc.GetAudits(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(audits)
+}
+
+func (h *Handler) CreateAudit(w http.ResponseWriter, r *http.Request) {
+	var audit utils.Audit
+	if err := json.NewDecoder(r.Body).Decode(&audit); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	if err := h.svc.CreateAudit(r.Context(), &audit); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(audit)
+}
+
+func (h *Handler) CloseAudit(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var closing Closing
+	if err := json.NewDecoder(r.Body).Decode(&closing); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	audit, err := h.svc.CloseAudit(r.Context(), id, &closing)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(audit)
+}
+
+func (h *Handler) GetExpected(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	entries, err := h.svc.GetExpected(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(entries)
+}
+
+func (h *Handler) GetScans(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	scans, err := h.svc.GetScans(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(scans)
+}
+
+func (h *Handler) Scan(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var scan utils.AuditScan
+	if err := json.NewDecoder(r.Body).Decode(&scan); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	if err := h.svc.Scan(r.Context(), id, &scan); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(scan)
+}
+
+func (h *Handler) GetReport(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	report, err := h.svc.GetReport(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
+
+func (h *Handler) ApplyCorrections(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var corrections Corrections
+	if err := json.NewDecoder(r.Body).Decode(&corrections); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	entries, err := h.svc.ApplyCorrections(r.Context(), id, &corrections)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(entries)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrInvalidRequest):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrWrongStatus):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,518 @@
+package audits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/departments"
+	"github.com/rickCrz7/Inventory-API/devices/components"
+	"github.com/rickCrz7/Inventory-API/devices/logs"
+	"github.com/rickCrz7/Inventory-API/locations"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+const logTypeAudit = "audit"
+
+var (
+	ErrInvalidRequest = errors.New("invalid audit request")
+	// ErrWrongStatus is returned when scanning, correcting or closing an
+	// audit that is already closed.
+	ErrWrongStatus = errors.New("audit is closed")
+)
+
+// Closing records who closed an audit.
+type Closing struct {
+	ClosedBy string `json:"closed_by"`
+}
+
+// Corrections brings the inventory in line with an audit. Relocate moves
+// wrong-location and unexpected devices to where they were scanned, and
+// MarkMissingLost marks missing devices as lost. DeviceIDs, when set, limits
+// the corrections to those devices.
+type Corrections struct {
+	Relocate        bool     `json:"relocate"`
+	MarkMissingLost bool     `json:"mark_missing_lost"`
+	DeviceIDs       []string `json:"device_ids"`
+	AppliedBy       string   `json:"applied_by"`
+	Note            string   `json:"note"`
+}
+
+func validateAudit(audit *utils.Audit) error {
+	if audit.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRequest)
+	}
+	if (audit.LocationID == nil) == (audit.DepartmentID == nil) {
+		return fmt.Errorf("%w: exactly one of location_id and department_id is required", ErrInvalidRequest)
+	}
+	if audit.CreatedBy == "" {
+		return fmt.Errorf("%w: created_by is required", ErrInvalidRequest)
+	}
+	return nil
+}
+
+func sameLocation(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// classify compares a scanned device with the audit. A scan without a
+// location cannot be in the wrong place.
+func classify(expected bool, location *string, scanned *string) string {
+	if !expected {
+		return utils.AuditUnexpected
+	}
+	if scanned != nil && !sameLocation(location, scanned) {
+		return utils.AuditWrongLocation
+	}
+	return utils.AuditFound
+}
+
+// reconcile sorts the expected devices of an audit into found, wrong-location
+// and missing by their latest scan.
+func reconcile(audit *utils.Audit, expected []*utils.AuditEntry, unexpected []*utils.AuditEntry, unknown []string) *utils.AuditReport {
+	report := &utils.AuditReport{
+		Audit:         audit,
+		Found:         []*utils.AuditEntry{},
+		WrongLocation: []*utils.AuditEntry{},
+		Missing:       []*utils.AuditEntry{},
+		Unexpected:    unexpected,
+		Unknown:       unknown,
+	}
+	if report.Unexpected == nil {
+		report.Unexpected = []*utils.AuditEntry{}
+	}
+	if report.Unknown == nil {
+		report.Unknown = []string{}
+	}
+	for _, entry := range expected {
+		if entry.ScannedAt == nil {
+			report.Missing = append(report.Missing, entry)
+			continue
+		}
+		switch classify(true, entry.LocationID, entry.ScannedLocationID) {
+		case utils.AuditWrongLocation:
+			report.WrongLocation = append(report.WrongLocation, entry)
+		default:
+			report.Found = append(report.Found, entry)
+		}
+	}
+	return report
+}
+
+// plan picks the devices a set of corrections changes: the ones to move to
+// where they were scanned and the ones to mark as lost.
+func plan(report *utils.AuditReport, corrections *Corrections) ([]*utils.AuditEntry, []*utils.AuditEntry) {
+	selected := func(entry *utils.AuditEntry) bool {
+		if len(corrections.DeviceIDs) == 0 {
+			return true
+		}
+		for _, id := range corrections.DeviceIDs {
+			if id == entry.DeviceID {
+				return true
+			}
+		}
+		return false
+	}
+	var moves, lost []*utils.AuditEntry
+	if corrections.Relocate {
+		for _, entries := range [][]*utils.AuditEntry{report.WrongLocation, report.Unexpected} {
+			for _, entry := range entries {
+				if entry.ScannedLocationID != nil && !sameLocation(entry.LocationID, entry.ScannedLocationID) && selected(entry) {
+					moves = append(moves, entry)
+				}
+			}
+		}
+	}
+	if corrections.MarkMissingLost {
+		for _, entry := range report.Missing {
+			if entry.Status != utils.DeviceStatusLost && selected(entry) {
+				lost = append(lost, entry)
+			}
+		}
+	}
+	return moves, lost
+}
+
+func describe(locationID *string) string {
+	if locationID == nil {
+		return "no location"
+	}
+	return "location " + *locationID
+}
+
+type Service struct {
+	dao            *Dao
+	locationsDao   *locations.Dao
+	departmentsDao *departments.Dao
+	logsDao        *logs.Dao
+	statuses       *components.StatusSetter
+	pdb            *utils.DB
+}
+
+func NewService(dao *Dao, pdb *utils.DB) *Service {
+	return &Service{
+		dao:            dao,
+		locationsDao:   locations.NewDao(),
+		departmentsDao: departments.NewDao(),
+		logsDao:        logs.NewDao(),
+		statuses:       components.NewStatusSetter(),
+		pdb:            pdb,
+	}
+}
+
+func (s *Service) GetAudit(ctx context.Context, id string) (*utils.Audit, error) {
+	ctx, span := tracing.Start(ctx, "audits.GetAudit")
+	defer span.End()
+
+	var audit *utils.Audit
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		audit, err = s.dao.GetAudit(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get audit: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return audit, nil
+}
+
+func (s *Service) GetAudits(ctx context.Context, status string) ([]*utils.Audit, error) {
+	ctx, span := tracing.Start(ctx, "audits.GetAudits")
+	defer span.End()
+
+	var audits []*utils.Audit
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		audits, err = s.dao.GetAudits(ctx, tx, status)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get audits: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
+
+// CreateAudit opens an audit and fixes its expected device list to what is in
+// its location or department right now.
+func (s *Service) CreateAudit(ctx context.Context, audit *utils.Audit) error {
+	ctx, span := tracing.Start(ctx, "audits.CreateAudit")
+	defer span.End()
+
+	if err := validateAudit(audit); err != nil {
+		return err
+	}
+	audit.ID = ""
+	audit.Status = utils.AuditOpen
+	audit.CreatedAt = time.Now()
+	audit.ClosedAt, audit.ClosedBy = nil, nil
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if audit.LocationID != nil {
+			if _, err := s.locationsDao.GetLocation(ctx, tx, *audit.LocationID); errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: location %s does not exist", ErrInvalidRequest, *audit.LocationID)
+			} else if err != nil {
+				return err
+			}
+		} else {
+			if _, err := s.departmentsDao.GetDepartment(ctx, tx, *audit.DepartmentID); errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: department %s does not exist", ErrInvalidRequest, *audit.DepartmentID)
+			} else if err != nil {
+				return err
+			}
+		}
+		if err := s.dao.CreateAudit(ctx, tx, audit); err != nil {
+			utils.Log(ctx).Errorf("Failed to create audit: %v", err)
+			return err
+		}
+		var count int64
+		var err error
+		if audit.LocationID != nil {
+			count, err = s.dao.ExpectLocation(ctx, tx, audit.ID, *audit.LocationID)
+		} else {
+			count, err = s.dao.ExpectDepartment(ctx, tx, audit.ID, *audit.DepartmentID)
+		}
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to build expected device list: %v", err)
+			return err
+		}
+		utils.Log(ctx).Printf("Audit %s expects %d devices", audit.ID, count)
+		return nil
+	})
+}
+
+// CloseAudit closes an audit. Its report stays available but it takes no
+// more scans or corrections.
+func (s *Service) CloseAudit(ctx context.Context, id string, closing *Closing) (*utils.Audit, error) {
+	ctx, span := tracing.Start(ctx, "audits.CloseAudit")
+	defer span.End()
+
+	if closing.ClosedBy == "" {
+		return nil, fmt.Errorf("%w: closed_by is required", ErrInvalidRequest)
+	}
+	var audit *utils.Audit
+	err := s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		audit, err = s.dao.LockAudit(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if audit.Status != utils.AuditOpen {
+			return fmt.Errorf("%w: audit %s", ErrWrongStatus, id)
+		}
+		now := time.Now()
+		if err := s.dao.CloseAudit(ctx, tx, id, now, closing.ClosedBy); err != nil {
+			utils.Log(ctx).Errorf("Failed to close audit: %v", err)
+			return err
+		}
+		audit.Status = utils.AuditClosed
+		audit.ClosedAt = &now
+		audit.ClosedBy = &closing.ClosedBy
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return audit, nil
+}
+
+// GetExpected returns the devices an audit expects with their latest scan.
+func (s *Service) GetExpected(ctx context.Context, id string) ([]*utils.AuditEntry, error) {
+	ctx, span := tracing.Start(ctx, "audits.GetExpected")
+	defer span.End()
+
+	var entries []*utils.AuditEntry
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		if _, err := s.dao.GetAudit(ctx, tx, id); err != nil {
+			return err
+		}
+		var err error
+		entries, err = s.dao.GetExpected(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get expected devices: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (s *Service) GetScans(ctx context.Context, id string) ([]*utils.AuditScan, error) {
+	ctx, span := tracing.Start(ctx, "audits.GetScans")
+	defer span.End()
+
+	var scans []*utils.AuditScan
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		if _, err := s.dao.GetAudit(ctx, tx, id); err != nil {
+			return err
+		}
+		var err error
+		scans, err = s.dao.GetScans(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get audit scans: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return scans, nil
+}
+
+// Scan records a code read during an audit. The code is looked up as an asset
+// tag, a serial number or a device ID, and the scan comes back with how the
+// device compares to the expected list. Codes that match no device are kept
+// as unknown.
+func (s *Service) Scan(ctx context.Context, auditID string, scan *utils.AuditScan) error {
+	ctx, span := tracing.Start(ctx, "audits.Scan")
+	defer span.End()
+
+	if scan.Code == "" {
+		return fmt.Errorf("%w: code is required", ErrInvalidRequest)
+	}
+	if scan.ScannedBy == "" {
+		return fmt.Errorf("%w: scanned_by is required", ErrInvalidRequest)
+	}
+	scan.ID = ""
+	scan.AuditID = auditID
+	scan.DeviceID = nil
+	scan.ScannedAt = time.Now()
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		audit, err := s.dao.LockAudit(ctx, tx, auditID)
+		if err != nil {
+			return err
+		}
+		if audit.Status != utils.AuditOpen {
+			return fmt.Errorf("%w: audit %s", ErrWrongStatus, auditID)
+		}
+		if scan.LocationID != nil {
+			if _, err := s.locationsDao.GetLocation(ctx, tx, *scan.LocationID); errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: location %s does not exist", ErrInvalidRequest, *scan.LocationID)
+			} else if err != nil {
+				return err
+			}
+		}
+
+		devices, err := s.dao.FindDevices(ctx, tx, scan.Code)
+		if err != nil {
+			return err
+		}
+		if len(devices) > 1 {
+			return fmt.Errorf("%w: code %s matches %d devices", ErrInvalidRequest, scan.Code, len(devices))
+		}
+		scan.Result = utils.AuditUnknown
+		for id, location := range devices {
+			expected, err := s.dao.IsExpected(ctx, tx, auditID, id)
+			if err != nil {
+				return err
+			}
+			scan.DeviceID = &id
+			scan.Result = classify(expected, location, scan.LocationID)
+		}
+		if err := s.dao.CreateScan(ctx, tx, scan); err != nil {
+			utils.Log(ctx).Errorf("Failed to record audit scan: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+// GetReport reconciles an audit's expected devices with its scans.
+func (s *Service) GetReport(ctx context.Context, id string) (*utils.AuditReport, error) {
+	ctx, span := tracing.Start(ctx, "audits.GetReport")
+	defer span.End()
+
+	var report *utils.AuditReport
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		audit, err := s.dao.GetAudit(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get audit: %v", err)
+			return err
+		}
+		report, err = s.report(ctx, tx, audit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *Service) report(ctx context.Context, tx pgx.Tx, audit *utils.Audit) (*utils.AuditReport, error) {
+	expected, err := s.dao.GetExpected(ctx, tx, audit.ID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Failed to get expected devices: %v", err)
+		return nil, err
+	}
+	unexpected, err := s.dao.GetUnexpected(ctx, tx, audit.ID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Failed to get unexpected devices: %v", err)
+		return nil, err
+	}
+	unknown, err := s.dao.GetUnknownCodes(ctx, tx, audit.ID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Failed to get unknown codes: %v", err)
+		return nil, err
+	}
+	return reconcile(audit, expected, unexpected, unknown), nil
+}
+
+// ApplyCorrections updates the inventory from an open audit's report and
+// logs every change on the device. It returns the log entries written.
+func (s *Service) ApplyCorrections(ctx context.Context, id string, corrections *Corrections) ([]*utils.DeviceLog, error) {
+	ctx, span := tracing.Start(ctx, "audits.ApplyCorrections")
+	defer span.End()
+
+	if corrections.AppliedBy == "" {
+		return nil, fmt.Errorf("%w: applied_by is required", ErrInvalidRequest)
+	}
+	if !corrections.Relocate && !corrections.MarkMissingLost {
+		return nil, fmt.Errorf("%w: relocate or mark_missing_lost is required", ErrInvalidRequest)
+	}
+	var entries []*utils.DeviceLog
+	err := s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		entries, err = s.applyCorrections(ctx, tx, id, corrections)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// applyCorrections does the work of ApplyCorrections inside tx.
+func (s *Service) applyCorrections(ctx context.Context, tx pgx.Tx, id string, corrections *Corrections) ([]*utils.DeviceLog, error) {
+	audit, err := s.dao.LockAudit(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if audit.Status != utils.AuditOpen {
+		return nil, fmt.Errorf("%w: audit %s", ErrWrongStatus, id)
+	}
+	report, err := s.report(ctx, tx, audit)
+	if err != nil {
+		return nil, err
+	}
+	moves, lost := plan(report, corrections)
+
+	now := time.Now()
+	var entries []*utils.DeviceLog
+	for _, entry := range moves {
+		if err := s.dao.SetDeviceLocation(ctx, tx, entry.DeviceID, entry.ScannedLocationID); err != nil {
+			return nil, err
+		}
+		note := fmt.Sprintf("Audit %q: moved from %s to %s", audit.Name, describe(entry.LocationID), describe(entry.ScannedLocationID))
+		logEntry, err := s.log(ctx, tx, entry.DeviceID, note, corrections, now)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, logEntry)
+	}
+	for _, entry := range lost {
+		if err := s.statuses.SetStatus(ctx, tx, entry.DeviceID, utils.DeviceStatusLost); err != nil {
+			return nil, err
+		}
+		note := fmt.Sprintf("Audit %q: not found, status %s -> %s", audit.Name, entry.Status, utils.DeviceStatusLost)
+		logEntry, err := s.log(ctx, tx, entry.DeviceID, note, corrections, now)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, logEntry)
+	}
+	return entries, nil
+}
+
+func (s *Service) log(ctx context.Context, tx pgx.Tx, deviceID string, note string, corrections *Corrections, at time.Time) (*utils.DeviceLog, error) {
+	if corrections.Note != "" {
+		note += ": " + corrections.Note
+	}
+	entry := &utils.DeviceLog{
+		DeviceID:  deviceID,
+		LogType:   logTypeAudit,
+		Note:      note,
+		CreatedAt: at,
+		CreatedBy: corrections.AppliedBy,
+	}
+	if err := s.logsDao.CreateLog(ctx, tx, entry); err != nil {
+		utils.Log(ctx).Errorf("Failed to write audit log: %v", err)
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,76 @@
+package audits
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+func TestValidateAudit(t *testing.T) {
+	location := "room-204"
+	department := "physics"
+	t.Run("Validate", func(t *testing.T) {
+		audit := &utils.Audit{Name: "2025 stocktake", LocationID: &location, CreatedBy: "auditor"}
+		if err := validateAudit(audit); err != nil {
+			t.Fatalf("Expected audit to be valid, got %v", err)
+		}
+	})
+	t.Run("ValidateNoScope", func(t *testing.T) {
+		audit := &utils.Audit{Name: "2025 stocktake", CreatedBy: "auditor"}
+		if err := validateAudit(audit); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+	t.Run("ValidateBothScopes", func(t *testing.T) {
+		audit := &utils.Audit{Name: "2025 stocktake", LocationID: &location, DepartmentID: &department, CreatedBy: "auditor"}
+		if err := validateAudit(audit); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+}
+
+func TestReconcile(t *testing.T) {
+	roomA, roomB := "room-a", "room-b"
+	now := time.Now()
+	expected := []*utils.AuditEntry{
+		{DeviceID: "found", LocationID: &roomA, ScannedLocationID: &roomA, ScannedAt: &now},
+		{DeviceID: "no-location", LocationID: &roomA, ScannedAt: &now},
+		{DeviceID: "moved", LocationID: &roomA, ScannedLocationID: &roomB, ScannedAt: &now},
+		{DeviceID: "missing", LocationID: &roomA, Status: "active"},
+		{DeviceID: "already-lost", LocationID: &roomA, Status: utils.DeviceStatusLost},
+	}
+	unexpected := []*utils.AuditEntry{
+		{DeviceID: "stray", LocationID: &roomB, ScannedLocationID: &roomA, ScannedAt: &now},
+	}
+	report := reconcile(&utils.Audit{ID: "a1"}, expected, unexpected, nil)
+
+	t.Run("Reconcile", func(t *testing.T) {
+		if len(report.Found) != 2 || len(report.WrongLocation) != 1 || len(report.Missing) != 2 || len(report.Unexpected) != 1 {
+			t.Fatalf("Unexpected report: %d found, %d wrong location, %d missing, %d unexpected",
+				len(report.Found), len(report.WrongLocation), len(report.Missing), len(report.Unexpected))
+		}
+		if report.WrongLocation[0].DeviceID != "moved" {
+			t.Errorf("Expected moved to be in the wrong location, got %s", report.WrongLocation[0].DeviceID)
+		}
+		if report.Unknown == nil {
+			t.Errorf("Expected an empty list of unknown codes")
+		}
+	})
+	t.Run("Plan", func(t *testing.T) {
+		moves, lost := plan(report, &Corrections{Relocate: true, MarkMissingLost: true})
+		if len(moves) != 2 {
+			t.Errorf("Expected moved and stray to be relocated, got %d moves", len(moves))
+		}
+		if len(lost) != 1 || lost[0].DeviceID != "missing" {
+			t.Errorf("Expected only missing to be marked lost, got %v", lost)
+		}
+	})
+	t.Run("PlanSelected", func(t *testing.T) {
+		moves, lost := plan(report, &Corrections{Relocate: true, MarkMissingLost: true, DeviceIDs: []string{"stray"}})
+		if len(moves) != 1 || moves[0].DeviceID != "stray" || len(lost) != 0 {
+			t.Errorf("Expected only stray to be corrected, got %d moves and %d lost", len(moves), len(lost))
+		}
+	})
+}
@@ -0,0 +1,278 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rickCrz7/Inventory-API/financials"
+	"github.com/rickCrz7/Inventory-API/labels"
+	"github.com/rickCrz7/Inventory-API/limits"
+	"github.com/rickCrz7/Inventory-API/owners/directory"
+	"github.com/rickCrz7/Inventory-API/server"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/utils"
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix prefixes environment variable overrides. Keys map to variables
+// by upper-casing and replacing "." and "-" with "_", so postgres.uri is
+// INVENTORY_POSTGRES_URI and grpc.tail-interval is INVENTORY_GRPC_TAIL_INTERVAL.
+const EnvPrefix = "INVENTORY"
+
+// secretKeys can also be read from a file named by "<key>-file" (or the
+// matching _FILE environment variable), e.g. INVENTORY_POSTGRES_URI_FILE
+// pointing at a mounted secret.
+var secretKeys = []string{
+	"postgres.uri",
+	"postgres.dev",
+	"postgres.prod",
+	"postgres.replica.uri",
+	"owners.sync.ldap.bind-password",
+	"limits.rate.api-keys",
+}
+
+// secretLists are the secretKeys holding a list, read from their file one
+// value per line.
+var secretLists = map[string]bool{
+	"limits.rate.api-keys": true,
+}
+
+type Config struct {
+	// Dev switches to development logging and the postgres.dev database.
+	Dev        bool       `mapstructure:"dev"`
+	App        App        `mapstructure:"app"`
+	GRPC       GRPC       `mapstructure:"grpc"`
+	Log        Log        `mapstructure:"log"`
+	Limits     Limits     `mapstructure:"limits"`
+	Tracing    Tracing    `mapstructure:"tracing"`
+	Owners     Owners     `mapstructure:"owners"`
+	Financials Financials `mapstructure:"financials"`
+	Labels     Labels     `mapstructure:"labels"`
+	Postgres   Postgres   `mapstructure:"postgres"`
+}
+
+type App struct {
+	Name          string        `mapstructure:"name"`
+	DrainDelay    time.Duration `mapstructure:"drain-delay"`
+	server.Config `mapstructure:",squash"`
+}
+
+type GRPC struct {
+	Addr         string        `mapstructure:"addr"`
+	TailInterval time.Duration `mapstructure:"tail-interval"`
+}
+
+type Log struct {
+	File       string `mapstructure:"file"`
+	MaxSize    int    `mapstructure:"max-size"`
+	MaxBackups int    `mapstructure:"max-backups"`
+	MaxAge     int    `mapstructure:"max-age"`
+	Level      string `mapstructure:"level"`
+}
+
+type Limits struct {
+	Rate limits.RateConfig `mapstructure:"rate"`
+	Body limits.BodyConfig `mapstructure:"body"`
+}
+
+type Tracing = tracing.Config
+
+type Owners struct {
+	Sync OwnersSync `mapstructure:"sync"`
+}
+
+type OwnersSync struct {
+	Source   string               `mapstructure:"source"`
+	Interval time.Duration        `mapstructure:"interval"`
+	CSV      OwnersSyncCSV        `mapstructure:"csv"`
+	LDAP     directory.LDAPConfig `mapstructure:"ldap"`
+}
+
+type OwnersSyncCSV struct {
+	Dir string `mapstructure:"dir"`
+}
+
+type Financials = financials.Config
+
+type Labels = labels.Config
+
+type Postgres struct {
+	// URI, when set, is used in every mode. Dev and Prod are the per-mode
+	// fallbacks.
+	URI  string `mapstructure:"uri"`
+	Dev  string `mapstructure:"dev"`
+	Prod string `mapstructure:"prod"`
+	Pool Pool   `mapstructure:"pool"`
+	// Replica, when its URI is set, serves read-only transactions.
+	Replica utils.ReplicaConfig `mapstructure:"replica"`
+	Retry   utils.RetryConfig   `mapstructure:"retry"`
+}
+
+// Pool holds the connection pool settings for each mode.
+type Pool struct {
+	Dev  utils.PoolConfig `mapstructure:"dev"`
+	Prod utils.PoolConfig `mapstructure:"prod"`
+}
+
+// ConnString returns the database URI for the current mode.
+func (c *Config) ConnString() string {
+	if c.Postgres.URI != "" {
+		return c.Postgres.URI
+	}
+	if c.Dev {
+		return c.Postgres.Dev
+	}
+	return c.Postgres.Prod
+}
+
+// PoolConfig returns the pool settings for the current mode.
+func (c *Config) PoolConfig() utils.PoolConfig {
+	if c.Dev {
+		return c.Postgres.Pool.Dev
+	}
+	return c.Postgres.Pool.Prod
+}
+
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("dev", false)
+	v.SetDefault("app.name", "inventory")
+	v.SetDefault("app.addr", ":80")
+	v.SetDefault("app.socket", "")
+	v.SetDefault("app.drain-delay", 5*time.Second)
+	v.SetDefault("app.timeouts.read", 30*time.Second)
+	v.SetDefault("app.timeouts.read-header", 5*time.Second)
+	v.SetDefault("app.timeouts.write", 60*time.Second)
+	v.SetDefault("app.timeouts.idle", 120*time.Second)
+	v.SetDefault("app.tls.cert-file", "")
+	v.SetDefault("app.tls.key-file", "")
+	v.SetDefault("app.tls.client-ca-file", "")
+	v.SetDefault("app.tls.client-auth", "require")
+	v.SetDefault("app.tls.reload-interval", time.Minute)
+	v.SetDefault("grpc.addr", ":9090")
+	v.SetDefault("grpc.tail-interval", 2*time.Second)
+	v.SetDefault("log.file", "inventory.log")
+	v.SetDefault("log.max-size", 5)
+	v.SetDefault("log.max-backups", 90)
+	v.SetDefault("log.max-age", 60)
+	v.SetDefault("log.level", "WARN")
+	v.SetDefault("limits.rate.default.per-second", 20)
+	v.SetDefault("limits.rate.default.burst", 40)
+	v.SetDefault("limits.rate.api-keys", []string{})
+	v.SetDefault("limits.rate.trusted-proxies", []string{})
+	v.SetDefault("limits.rate.exempt", []string{"/healthz", "/readyz", "/metrics"})
+	v.SetDefault("limits.rate.max-clients", 10000)
+	v.SetDefault("limits.body.classes.json", 1<<20)
+	v.SetDefault("tracing.exporter", "")
+	v.SetDefault("tracing.endpoint", "localhost:4317")
+	v.SetDefault("tracing.insecure", true)
+	v.SetDefault("tracing.sample-ratio", 1.0)
+	v.SetDefault("owners.sync.source", "csv")
+	v.SetDefault("owners.sync.interval", time.Duration(0))
+	v.SetDefault("owners.sync.csv.dir", "")
+	v.SetDefault("owners.sync.ldap.url", "")
+	v.SetDefault("owners.sync.ldap.bind-dn", "")
+	v.SetDefault("owners.sync.ldap.bind-password", "")
+	v.SetDefault("owners.sync.ldap.base-dn", "")
+	v.SetDefault("owners.sync.ldap.filter", "(objectClass=person)")
+	v.SetDefault("owners.sync.ldap.campus-id-attr", "employeeNumber")
+	v.SetDefault("owners.sync.ldap.first-name-attr", "givenName")
+	v.SetDefault("owners.sync.ldap.last-name-attr", "sn")
+	v.SetDefault("owners.sync.ldap.email-attr", "mail")
+	v.SetDefault("financials.currency", "USD")
+	v.SetDefault("financials.fiscal-year-end-month", 12)
+	v.SetDefault("labels.device-url", "")
+	v.SetDefault("postgres.uri", "")
+	v.SetDefault("postgres.dev", "")
+	v.SetDefault("postgres.prod", "")
+	for _, mode := range []string{"dev", "prod"} {
+		prefix := "postgres.pool." + mode + "."
+		v.SetDefault(prefix+"max-conns", 0)
+		v.SetDefault(prefix+"min-conns", 0)
+		v.SetDefault(prefix+"max-conn-lifetime", time.Duration(0))
+		v.SetDefault(prefix+"max-conn-idle-time", time.Duration(0))
+		v.SetDefault(prefix+"health-check-period", time.Duration(0))
+		v.SetDefault(prefix+"statement-timeout", time.Duration(0))
+		v.SetDefault(prefix+"application-name", "")
+		v.SetDefault(prefix+"search-path", "")
+	}
+	v.SetDefault("postgres.retry.max-attempts", 3)
+	v.SetDefault("postgres.retry.base-delay", 20*time.Millisecond)
+	v.SetDefault("postgres.retry.max-delay", time.Second)
+	v.SetDefault("postgres.replica.uri", "")
+	v.SetDefault("postgres.replica.max-lag", 5*time.Second)
+	v.SetDefault("postgres.replica.check-interval", 5*time.Second)
+	v.SetDefault("postgres.replica.pool.max-conns", 0)
+	v.SetDefault("postgres.replica.pool.min-conns", 0)
+	v.SetDefault("postgres.replica.pool.max-conn-lifetime", time.Duration(0))
+	v.SetDefault("postgres.replica.pool.max-conn-idle-time", time.Duration(0))
+	v.SetDefault("postgres.replica.pool.health-check-period", time.Duration(0))
+	v.SetDefault("postgres.replica.pool.statement-timeout", time.Duration(0))
+	v.SetDefault("postgres.replica.pool.application-name", "")
+	v.SetDefault("postgres.replica.pool.search-path", "")
+	v.SetDefault("postgres.pool.prod.max-conns", 50)
+	v.SetDefault("postgres.pool.prod.min-conns", 1)
+	v.SetDefault("postgres.pool.prod.max-conn-lifetime", 10*time.Minute)
+	v.SetDefault("postgres.pool.prod.max-conn-idle-time", time.Minute)
+	v.SetDefault("postgres.pool.prod.health-check-period", time.Minute)
+	for _, key := range secretKeys {
+		// Registering the key lets AutomaticEnv find the _FILE variable.
+		v.SetDefault(key+"-file", "")
+	}
+}
+
+// Load reads the configuration from path, or from config/app.yaml when path
+// is empty, then applies environment overrides and secret files. A missing
+// config/app.yaml is not an error so the API can run from the environment
+// alone; call Validate to check the result.
+func Load(path string) (*Config, error) {
+	v := viper.New()
+	setDefaults(v)
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	v.AutomaticEnv()
+
+	if path != "" {
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("reading config %s: %w", path, err)
+		}
+	} else {
+		v.SetConfigName("app")
+		v.AddConfigPath("config")
+		var notFound viper.ConfigFileNotFoundError
+		if err := v.ReadInConfig(); err != nil && !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("reading config/app.yaml: %w", err)
+		}
+	}
+
+	for _, key := range secretKeys {
+		file := v.GetString(key + "-file")
+		if file == "" {
+			continue
+		}
+		secret, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s-file: %w", key, err)
+		}
+		if secretLists[key] {
+			v.Set(key, strings.Fields(string(secret)))
+		} else {
+			v.Set(key, strings.TrimSpace(string(secret)))
+		}
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("decoding config: %w", err)
+	}
+	cfg.Tracing.ServiceName = cfg.App.Name
+	for _, pool := range []*utils.PoolConfig{&cfg.Postgres.Pool.Dev, &cfg.Postgres.Pool.Prod, &cfg.Postgres.Replica.Pool} {
+		if pool.ApplicationName == "" {
+			pool.ApplicationName = cfg.App.Name
+		}
+	}
+	return &cfg, nil
+}
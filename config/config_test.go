@@ -0,0 +1,124 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	file := filepath.Join(dir, name)
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("Error writing %s: %v", name, err)
+	}
+	return file
+}
+
+func TestLoadEnvOverrides(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "app.yaml", "grpc:\n  addr: \":9000\"\nlog:\n  level: INFO\n")
+	t.Setenv("INVENTORY_LOG_LEVEL", "DEBUG")
+	t.Setenv("INVENTORY_GRPC_TAIL_INTERVAL", "5s")
+	t.Setenv("INVENTORY_POSTGRES_URI", "postgres://inventory:secret@db/inventory")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Error loading config: %v", err)
+	}
+	if cfg.GRPC.Addr != ":9000" {
+		t.Errorf("Expected grpc.addr from file, got %q", cfg.GRPC.Addr)
+	}
+	if cfg.Log.Level != "DEBUG" {
+		t.Errorf("Expected log.level from env, got %q", cfg.Log.Level)
+	}
+	if cfg.GRPC.TailInterval != 5*time.Second {
+		t.Errorf("Expected grpc.tail-interval from env, got %s", cfg.GRPC.TailInterval)
+	}
+	if cfg.App.Addr != ":80" {
+		t.Errorf("Expected default app.addr, got %q", cfg.App.Addr)
+	}
+	if cfg.ConnString() != "postgres://inventory:secret@db/inventory" {
+		t.Errorf("Expected postgres.uri from env, got %q", cfg.ConnString())
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected valid config, got %v", err)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatal("Expected an error for a missing explicit config file")
+	}
+}
+
+func TestLoadSecretFile(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "app.yaml", "owners:\n  sync:\n    ldap:\n      bind-password-file: "+filepath.Join(dir, "ldap")+"\n")
+	writeFile(t, dir, "ldap", "hunter2\n")
+	t.Setenv("INVENTORY_LIMITS_RATE_API_KEYS_FILE", writeFile(t, dir, "keys", "key-one\nkey-two\n"))
+	t.Setenv("INVENTORY_POSTGRES_URI_FILE", writeFile(t, dir, "uri", "postgres://u:p@db/inventory\n"))
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Error loading config: %v", err)
+	}
+	if cfg.Postgres.URI != "postgres://u:p@db/inventory" {
+		t.Errorf("Expected postgres.uri from secret file, got %q", cfg.Postgres.URI)
+	}
+	if cfg.Owners.Sync.LDAP.BindPassword != "hunter2" {
+		t.Errorf("Expected bind password from secret file, got %q", cfg.Owners.Sync.LDAP.BindPassword)
+	}
+	if keys := cfg.Limits.Rate.APIKeys; len(keys) != 2 || keys[0] != "key-one" || keys[1] != "key-two" {
+		t.Errorf("Expected API keys from secret file, got %q", keys)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg, err := Load(writeFile(t, t.TempDir(), "app.yaml", "log:\n  level: LOUD\ntracing:\n  exporter: zipkin\nfinancials:\n  currency: usd\nlabels:\n  device-url: inventory.example.edu\n"))
+	if err != nil {
+		t.Fatalf("Error loading config: %v", err)
+	}
+	err = cfg.Validate()
+	if err == nil {
+		t.Fatal("Expected validation errors")
+	}
+	for _, want := range []string{"postgres", "log.level", "tracing.exporter", "financials.currency", "labels.device-url"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected an error about %s, got %v", want, err)
+		}
+	}
+
+	cfg.Labels.DeviceURL = ""
+	if err := cfg.Validate(); err == nil || strings.Contains(err.Error(), "labels.device-url") {
+		t.Errorf("Expected an empty labels.device-url to be allowed, got %v", err)
+	}
+}
+
+func TestPrintRedacts(t *testing.T) {
+	cfg := &Config{
+		Postgres: Postgres{URI: "postgres://inventory:secret@db/inventory", Prod: "host=db password=secret"},
+	}
+	cfg.App.Timeouts.Read = 30 * time.Second
+	cfg.Owners.Sync.LDAP.BindPassword = "hunter2"
+	cfg.Limits.Rate.APIKeys = []string{"k3y-0ne", "k3y-tw0"}
+
+	var out bytes.Buffer
+	if err := cfg.Print(&out); err != nil {
+		t.Fatalf("Error printing config: %v", err)
+	}
+	printed := out.String()
+	for _, secret := range []string{"secret", "hunter2", "k3y-0ne", "k3y-tw0"} {
+		if strings.Contains(printed, secret) {
+			t.Errorf("Expected %q to be redacted:\n%s", secret, printed)
+		}
+	}
+	for _, want := range []string{"postgres://inventory:REDACTED@db/inventory", "read: 30s", "bind-password: REDACTED"} {
+		if !strings.Contains(printed, want) {
+			t.Errorf("Expected %q in output:\n%s", want, printed)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+
+	"github.com/rickCrz7/Inventory-API/limits"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "REDACTED"
+
+var logLevels = []string{"TRACE", "DEBUG", "INFO", "WARN"}
+
+// Validate reports every problem with the configuration at once.
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if c.ConnString() == "" {
+		mode := "prod"
+		if c.Dev {
+			mode = "dev"
+		}
+		add("postgres: set postgres.uri or postgres.%s (env %s_POSTGRES_URI)", mode, EnvPrefix)
+	}
+	pools := map[string]utils.PoolConfig{
+		"pool.dev":     c.Postgres.Pool.Dev,
+		"pool.prod":    c.Postgres.Pool.Prod,
+		"replica.pool": c.Postgres.Replica.Pool,
+	}
+	for name, pool := range pools {
+		if pool.MaxConns < 0 || pool.MinConns < 0 {
+			add("postgres.%s: max-conns and min-conns must not be negative", name)
+		}
+		if pool.MaxConns > 0 && pool.MinConns > pool.MaxConns {
+			add("postgres.%s: min-conns %d is above max-conns %d", name, pool.MinConns, pool.MaxConns)
+		}
+		if pool.MaxConnLifetime < 0 || pool.MaxConnIdleTime < 0 || pool.HealthCheckPeriod < 0 || pool.StatementTimeout < 0 {
+			add("postgres.%s: durations must not be negative", name)
+		}
+	}
+	if c.Postgres.Retry.MaxAttempts < 0 || c.Postgres.Retry.BaseDelay < 0 || c.Postgres.Retry.MaxDelay < 0 {
+		add("postgres.retry must not be negative")
+	}
+	if c.Postgres.Replica.URI != "" {
+		if c.Postgres.Replica.CheckInterval <= 0 {
+			add("postgres.replica.check-interval must be positive")
+		}
+		if c.Postgres.Replica.MaxLag < 0 {
+			add("postgres.replica.max-lag must not be negative")
+		}
+	}
+	if c.App.Addr == "" && c.App.Socket == "" {
+		add("app: set app.addr or app.socket")
+	}
+	if c.App.DrainDelay < 0 {
+		add("app.drain-delay must not be negative")
+	}
+	for name, d := range map[string]time.Duration{
+		"read":        c.App.Timeouts.Read,
+		"read-header": c.App.Timeouts.ReadHeader,
+		"write":       c.App.Timeouts.Write,
+		"idle":        c.App.Timeouts.Idle,
+	} {
+		if d < 0 {
+			add("app.timeouts.%s must not be negative", name)
+		}
+	}
+	tls := c.App.TLS
+	if (tls.CertFile == "") != (tls.KeyFile == "") {
+		add("app.tls: cert-file and key-file must be set together")
+	}
+	if tls.ClientCAFile != "" && !tls.Enabled() {
+		add("app.tls.client-ca-file needs cert-file and key-file")
+	}
+	if tls.ClientAuth != "" && tls.ClientAuth != "require" && tls.ClientAuth != "verify-if-given" {
+		add("app.tls.client-auth must be require or verify-if-given, got %q", tls.ClientAuth)
+	}
+	if c.GRPC.Addr == "" {
+		add("grpc.addr must be set")
+	}
+	if c.GRPC.TailInterval <= 0 {
+		add("grpc.tail-interval must be positive")
+	}
+	if !contains(logLevels, strings.ToUpper(c.Log.Level)) {
+		add("log.level must be one of %s, got %q", strings.Join(logLevels, ", "), c.Log.Level)
+	}
+	if c.Limits.Rate.Default.PerSecond < 0 || c.Limits.Rate.Default.Burst < 0 {
+		add("limits.rate.default must not be negative")
+	}
+	for route, rate := range c.Limits.Rate.Routes {
+		if rate.PerSecond < 0 || rate.Burst < 0 {
+			add("limits.rate.routes[%s] must not be negative", route)
+		}
+	}
+	if _, err := limits.ParseTrustedProxies(c.Limits.Rate.TrustedProxies); err != nil {
+		add("limits.rate.trusted-proxies: %v", err)
+	}
+	if c.Limits.Rate.MaxClients < 0 {
+		add("limits.rate.max-clients must not be negative")
+	}
+	for route, class := range c.Limits.Body.Routes {
+		if _, ok := c.Limits.Body.Classes[class]; !ok {
+			add("limits.body.routes[%s] uses unknown class %q", route, class)
+		}
+	}
+	if _, ok := c.Limits.Body.Classes[limits.ClassJSON]; !ok {
+		add("limits.body.classes.%s must be set", limits.ClassJSON)
+	}
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout:
+	default:
+		add("tracing.exporter must be %s, %s or empty, got %q", tracing.ExporterOTLP, tracing.ExporterStdout, c.Tracing.Exporter)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		add("tracing.sample-ratio must be between 0 and 1")
+	}
+	if !isCurrencyCode(c.Financials.Currency) {
+		add("financials.currency must be a three letter ISO 4217 code like USD, got %q", c.Financials.Currency)
+	}
+	if c.Financials.FiscalYearEndMonth < 1 || c.Financials.FiscalYearEndMonth > 12 {
+		add("financials.fiscal-year-end-month must be between 1 and 12")
+	}
+	if url := c.Labels.DeviceURL; url != "" && !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		add("labels.device-url must be an http:// or https:// URL, got %q", url)
+	}
+	sync := c.Owners.Sync
+	if sync.Interval < 0 {
+		add("owners.sync.interval must not be negative")
+	}
+	switch sync.Source {
+	case "csv":
+		if sync.Interval > 0 && sync.CSV.Dir == "" {
+			add("owners.sync.source is csv but owners.sync.csv.dir is empty")
+		}
+	case "ldap":
+		if sync.LDAP.URL == "" {
+			add("owners.sync.source is ldap but owners.sync.ldap.url is empty")
+		}
+	default:
+		add("owners.sync.source must be csv or ldap, got %q", sync.Source)
+	}
+	return errors.Join(errs...)
+}
+
+// Redacted returns a copy safe to print: passwords are removed from database
+// URIs and other secrets are replaced.
+func (c *Config) Redacted() *Config {
+	out := *c
+	out.Postgres.URI = redactURI(c.Postgres.URI)
+	out.Postgres.Dev = redactURI(c.Postgres.Dev)
+	out.Postgres.Prod = redactURI(c.Postgres.Prod)
+	out.Postgres.Replica.URI = redactURI(c.Postgres.Replica.URI)
+	if out.Owners.Sync.LDAP.BindPassword != "" {
+		out.Owners.Sync.LDAP.BindPassword = redacted
+	}
+	if len(c.Limits.Rate.APIKeys) > 0 {
+		out.Limits.Rate.APIKeys = make([]string, len(c.Limits.Rate.APIKeys))
+		for i := range out.Limits.Rate.APIKeys {
+			out.Limits.Rate.APIKeys[i] = redacted
+		}
+	}
+	return &out
+}
+
+// Print writes the redacted configuration as YAML using the same keys as
+// config/app.yaml.
+func (c *Config) Print(w io.Writer) error {
+	var settings map[string]interface{}
+	if err := mapstructure.Decode(c.Redacted(), &settings); err != nil {
+		return err
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(humanize(settings)); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// humanize turns nested structs and maps into plain maps and durations into
+// strings such as "1m0s" so the output reads like the config file.
+func humanize(value interface{}) interface{} {
+	if d, ok := value.(time.Duration); ok {
+		return d.String()
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Struct:
+		var settings map[string]interface{}
+		if err := mapstructure.Decode(value, &settings); err != nil {
+			return value
+		}
+		return humanize(settings)
+	case reflect.Map:
+		settings := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			settings[fmt.Sprint(iter.Key().Interface())] = humanize(iter.Value().Interface())
+		}
+		return settings
+	}
+	return value
+}
+
+// redactURI keeps everything but the password of a URL connection string.
+// Key/value connection strings are redacted entirely since the password
+// could be anywhere in them.
+func redactURI(uri string) string {
+	if uri == "" {
+		return ""
+	}
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme == "" {
+		return redacted
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	if u.Query().Has("password") {
+		query := u.Query()
+		query.Set("password", redacted)
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,263 @@
+package consumables
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Dao struct{}
+
+func NewDao() *Dao {
+	return &Dao{}
+}
+
+const consumableColumns = `c.id, c.name, c.sku, c.unit, c.description, c.reorder_threshold, c.reorder_quantity,
+	COALESCE((SELECT sum(s.quantity) FROM consumable_stock s WHERE s.consumable_id = c.id), 0) AS on_hand`
+
+func scanConsumable(row pgx.Row, consumable *utils.Consumable) error {
+	return row.Scan(&consumable.ID, &consumable.Name, &consumable.SKU, &consumable.Unit, &consumable.Description,
+		&consumable.ReorderThreshold, &consumable.ReorderQuantity, &consumable.OnHand)
+}
+
+func (d *Dao) GetConsumable(ctx context.Context, tx pgx.Tx, id string) (*utils.Consumable, error) {
+	utils.Log(ctx).Printf("Fetching consumable with ID: %s", id)
+	query := `SELECT ` + consumableColumns + ` FROM consumables c WHERE c.id = $1`
+	var consumable utils.Consumable
+	if err := scanConsumable(tx.QueryRow(ctx, query, id), &consumable); err != nil {
+		utils.Log(ctx).Errorf("Error fetching consumable with ID %s: %v", id, err)
+		return nil, err
+	}
+	return &consumable, nil
+}
+
+// FindConflict returns a consumable other than id that has the same name or
+// SKU.
+func (d *Dao) FindConflict(ctx context.Context, tx pgx.Tx, id string, name string, sku *string) (*utils.Consumable, error) {
+	utils.Log(ctx).Printf("Checking consumable name %s for conflicts", name)
+	query := `SELECT ` + consumableColumns + ` FROM consumables c
+		WHERE c.id <> $1 AND (c.name = $2 OR c.sku = $3)
+		LIMIT 1`
+	var consumable utils.Consumable
+	if err := scanConsumable(tx.QueryRow(ctx, query, id, name, sku), &consumable); err != nil {
+		return nil, err
+	}
+	return &consumable, nil
+}
+
+func (d *Dao) GetConsumables(ctx context.Context, tx pgx.Tx) ([]*utils.Consumable, error) {
+	utils.Log(ctx).Println("Fetching all consumables")
+	query := `SELECT ` + consumableColumns + ` FROM consumables c ORDER BY c.name`
+	return d.queryConsumables(ctx, tx, query)
+}
+
+// GetLowStock returns the consumables with a reorder threshold whose quantity
+// on hand has fallen to or below it.
+func (d *Dao) GetLowStock(ctx context.Context, tx pgx.Tx) ([]*utils.Consumable, error) {
+	utils.Log(ctx).Println("Fetching low-stock consumables")
+	query := `SELECT * FROM (SELECT ` + consumableColumns + ` FROM consumables c) c
+		WHERE c.reorder_threshold > 0 AND c.on_hand <= c.reorder_threshold
+		ORDER BY c.name`
+	return d.queryConsumables(ctx, tx, query)
+}
+
+func (d *Dao) queryConsumables(ctx context.Context, tx pgx.Tx, query string) ([]*utils.Consumable, error) {
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching consumables: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var consumables []*utils.Consumable
+	for rows.Next() {
+		var consumable utils.Consumable
+		if err := scanConsumable(rows, &consumable); err != nil {
+			utils.Log(ctx).Errorf("Error scanning consumable row: %v", err)
+			return nil, err
+		}
+		consumables = append(consumables, &consumable)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over consumable rows: %v", err)
+		return nil, err
+	}
+	return consumables, nil
+}
+
+func (d *Dao) CreateConsumable(ctx context.Context, tx pgx.Tx, consumable *utils.Consumable) error {
+	utils.Log(ctx).Printf("Creating consumable: %+v", consumable)
+	if consumable.ID == "" {
+		id, err := gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Error generating ID for new consumable: %v", err)
+			return err
+		}
+		consumable.ID = id
+	}
+	query := `INSERT INTO consumables (id, name, sku, unit, description, reorder_threshold, reorder_quantity)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := tx.Exec(ctx, query, consumable.ID, consumable.Name, consumable.SKU, consumable.Unit, consumable.Description,
+		consumable.ReorderThreshold, consumable.ReorderQuantity)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error creating consumable: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (d *Dao) UpdateConsumable(ctx context.Context, tx pgx.Tx, consumable *utils.Consumable) error {
+	utils.Log(ctx).Printf("Updating consumable: %+v", consumable)
+	query := `UPDATE consumables
+		SET name = $2, sku = $3, unit = $4, description = $5, reorder_threshold = $6, reorder_quantity = $7
+		WHERE id = $1`
+	tag, err := tx.Exec(ctx, query, consumable.ID, consumable.Name, consumable.SKU, consumable.Unit, consumable.Description,
+		consumable.ReorderThreshold, consumable.ReorderQuantity)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error updating consumable with ID %s: %v", consumable.ID, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (d *Dao) DeleteConsumable(ctx context.Context, tx pgx.Tx, id string) error {
+	utils.Log(ctx).Printf("Deleting consumable with ID: %s", id)
+	query := `DELETE FROM consumables WHERE id = $1`
+	tag, err := tx.Exec(ctx, query, id)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error deleting consumable with ID %s: %v", id, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// CountMovements counts the ledger entries of a consumable.
+func (d *Dao) CountMovements(ctx context.Context, tx pgx.Tx, id string) (int, error) {
+	utils.Log(ctx).Printf("Counting movements of consumable with ID: %s", id)
+	query := `SELECT count(*) FROM consumable_movements WHERE consumable_id = $1`
+	var count int
+	if err := tx.QueryRow(ctx, query, id).Scan(&count); err != nil {
+		utils.Log(ctx).Errorf("Error counting movements of consumable %s: %v", id, err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetStock returns the locations holding a consumable and the quantity in
+// each.
+func (d *Dao) GetStock(ctx context.Context, tx pgx.Tx, id string) ([]*utils.ConsumableStock, error) {
+	utils.Log(ctx).Printf("Fetching stock of consumable with ID: %s", id)
+	query := `SELECT consumable_id, location_id, quantity FROM consumable_stock
+		WHERE consumable_id = $1 AND quantity > 0
+		ORDER BY location_id`
+	rows, err := tx.Query(ctx, query, id)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching stock of consumable %s: %v", id, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stock []*utils.ConsumableStock
+	for rows.Next() {
+		var level utils.ConsumableStock
+		if err := rows.Scan(&level.ConsumableID, &level.LocationID, &level.Quantity); err != nil {
+			utils.Log(ctx).Errorf("Error scanning stock row: %v", err)
+			return nil, err
+		}
+		stock = append(stock, &level)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over stock rows: %v", err)
+		return nil, err
+	}
+	return stock, nil
+}
+
+// AddStock increases the quantity of a consumable at a location.
+func (d *Dao) AddStock(ctx context.Context, tx pgx.Tx, id string, locationID string, quantity int) error {
+	utils.Log(ctx).Printf("Adding %d of consumable %s at location %s", quantity, id, locationID)
+	query := `INSERT INTO consumable_stock (consumable_id, location_id, quantity) VALUES ($1, $2, $3)
+		ON CONFLICT (consumable_id, location_id) DO UPDATE SET quantity = consumable_stock.quantity + EXCLUDED.quantity`
+	if _, err := tx.Exec(ctx, query, id, locationID, quantity); err != nil {
+		utils.Log(ctx).Errorf("Error adding stock of consumable %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+// RemoveStock decreases the quantity of a consumable at a location. It
+// returns false without changing anything when the location holds less than
+// quantity.
+func (d *Dao) RemoveStock(ctx context.Context, tx pgx.Tx, id string, locationID string, quantity int) (bool, error) {
+	utils.Log(ctx).Printf("Removing %d of consumable %s at location %s", quantity, id, locationID)
+	query := `UPDATE consumable_stock SET quantity = quantity - $3
+		WHERE consumable_id = $1 AND location_id = $2 AND quantity >= $3`
+	tag, err := tx.Exec(ctx, query, id, locationID, quantity)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error removing stock of consumable %s: %v", id, err)
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (d *Dao) CreateMovement(ctx context.Context, tx pgx.Tx, movement *utils.ConsumableMovement) error {
+	utils.Log(ctx).Printf("Recording consumable movement: %+v", movement)
+	if movement.ID == "" {
+		id, err := gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Error generating ID for new movement: %v", err)
+			return err
+		}
+		movement.ID = id
+	}
+	query := `INSERT INTO consumable_movements
+		(id, consumable_id, kind, quantity, from_location_id, to_location_id, owner_id, note, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	_, err := tx.Exec(ctx, query, movement.ID, movement.ConsumableID, movement.Kind, movement.Quantity,
+		movement.FromLocationID, movement.ToLocationID, movement.OwnerID, movement.Note, movement.CreatedAt, movement.CreatedBy)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error recording consumable movement: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetMovements returns the ledger of a consumable, newest first.
+func (d *Dao) GetMovements(ctx context.Context, tx pgx.Tx, id string) ([]*utils.ConsumableMovement, error) {
+	utils.Log(ctx).Printf("Fetching movements of consumable with ID: %s", id)
+	query := `SELECT id, consumable_id, kind, quantity, from_location_id, to_location_id, owner_id, note, created_at, created_by
+		FROM consumable_movements
+		WHERE consumable_id = $1
+		ORDER BY created_at DESC, id`
+	rows, err := tx.Query(ctx, query, id)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching movements of consumable %s: %v", id, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var movements []*utils.ConsumableMovement
+	for rows.Next() {
+		var movement utils.ConsumableMovement
+		err := rows.Scan(&movement.ID, &movement.ConsumableID, &movement.Kind, &movement.Quantity, &movement.FromLocationID,
+			&movement.ToLocationID, &movement.OwnerID, &movement.Note, &movement.CreatedAt, &movement.CreatedBy)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error scanning movement row: %v", err)
+			return nil, err
+		}
+		movements = append(movements, &movement)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over movement rows: %v", err)
+		return nil, err
+	}
+	return movements, nil
+}
@@ -0,0 +1,106 @@
+package consumables
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime"
+	"testing"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/locations"
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+var postgresURI string
+
+func init() {
+	viper.SetConfigName("app")
+	viper.AddConfigPath("../config")
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+	postgresURI = viper.GetString("postgres.dev") // Change to "postgres.dev" for development/local db
+	log.SetReportCaller(true)
+	log.SetFormatter(&log.TextFormatter{
+		ForceColors:     true,
+		FullTimestamp:   true,
+		TimestampFormat: "2006/01/02 15:04:05",
+		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
+			filename := path.Base(f.File)
+			return fmt.Sprintf("%s()", f.Function), fmt.Sprintf("\t%s:%d", filename, f.Line)
+		},
+	})
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestStock(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+
+	// Mock Data
+	room := &utils.Location{Name: "Test Supply Room", Kind: utils.LocationKindRoom}
+	if err := locations.NewDao().CreateLocation(ctx, tx, room); err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	suffix, _ := gonanoid.New(8)
+	toner := &utils.Consumable{Name: "Test Toner " + suffix, Unit: "cartridge", ReorderThreshold: 5, ReorderQuantity: 20}
+	if err := dao.CreateConsumable(ctx, tx, toner); err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+
+	t.Run("AddStock", func(t *testing.T) {
+		if err := dao.AddStock(ctx, tx, toner.ID, room.ID, 8); err != nil {
+			t.Fatalf("Error adding stock: %v", err)
+		}
+		consumable, err := dao.GetConsumable(ctx, tx, toner.ID)
+		if err != nil {
+			t.Fatalf("Error getting consumable: %v", err)
+		}
+		if consumable.OnHand != 8 {
+			t.Errorf("Expected 8 on hand, got %d", consumable.OnHand)
+		}
+	})
+	t.Run("RemoveTooMuch", func(t *testing.T) {
+		ok, err := dao.RemoveStock(ctx, tx, toner.ID, room.ID, 9)
+		if err != nil {
+			t.Fatalf("Error removing stock: %v", err)
+		}
+		if ok {
+			t.Errorf("Expected removing 9 of 8 to be refused")
+		}
+	})
+	t.Run("GetLowStock", func(t *testing.T) {
+		if ok, err := dao.RemoveStock(ctx, tx, toner.ID, room.ID, 3); err != nil || !ok {
+			t.Fatalf("Error removing stock: %v", err)
+		}
+		consumables, err := dao.GetLowStock(ctx, tx)
+		if err != nil {
+			t.Fatalf("Error getting low stock: %v", err)
+		}
+		found := false
+		for _, consumable := range consumables {
+			if consumable.ID == toner.ID {
+				found = consumable.OnHand == 5
+			}
+		}
+		if !found {
+			t.Errorf("Expected toner with 5 on hand to be low on stock")
+		}
+	})
+}
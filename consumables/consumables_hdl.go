@@ -0,0 +1,151 @@
+package consumables
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Handler struct {
+	svc *Service
+	// atz *authz.Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{
+		svc: svc,
+		// atz: atz,
+	}
+}
+
+func (h *Handler) GetConsumable(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	consumable, err := h.svc.GetConsumable(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(consumable)
+}
+
+func (h *Handler) GetConsumables(w http.ResponseWriter, r *http.Request) {
+	consumables, err := h.svc.GetConsumables(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(consumables)
+}
+
+func (h *Handler) GetLowStock(w http.ResponseWriter, r *http.Request) {
+	consumables, err := h.svc.GetLowStock(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(consumables)
+}
+
+func (h *Handler) CreateConsumable(w http.ResponseWriter, r *http.Request) {
+	var consumable utils.Consumable
+	if err := json.NewDecoder(r.Body).Decode(&consumable); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	if err := h.svc.CreateConsumable(r.Context(), &consumable); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(consumable)
+}
+
+func (h *Handler) UpdateConsumable(w http.ResponseWriter, r *http.Request) {
+	var consumable utils.Consumable
+	if err := json.NewDecoder(r.Body).Decode(&consumable); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	consumable.ID = mux.Vars(r)["id"]
+	if err := h.svc.UpdateConsumable(r.Context(), &consumable); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(consumable)
+}
+
+func (h *Handler) DeleteConsumable(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if err := h.svc.DeleteConsumable(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetStock(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	stock, err := h.svc.GetStock(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(stock)
+}
+
+func (h *Handler) GetMovements(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	movements, err := h.svc.GetMovements(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(movements)
+}
+
+func (h *Handler) RecordMovement(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var movement Movement
+	if err := json.NewDecoder(r.Body).Decode(&movement); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	entry, err := h.svc.RecordMovement(r.Context(), id, &movement)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entry)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrInvalidRequest):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrNameTaken), errors.Is(err, ErrConsumableInUse), errors.Is(err, ErrInsufficientStock):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,381 @@
+package consumables
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/locations"
+	"github.com/rickCrz7/Inventory-API/owners"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+const defaultUnit = "each"
+
+var (
+	ErrInvalidRequest = errors.New("invalid consumable request")
+	// ErrNameTaken is returned when another consumable already has the name
+	// or SKU.
+	ErrNameTaken = errors.New("consumable name or sku is already used")
+	// ErrConsumableInUse is returned when deleting a consumable that has
+	// entries in the stock ledger.
+	ErrConsumableInUse = errors.New("consumable has stock movements")
+	// ErrInsufficientStock is returned when a movement would take more out of
+	// a location than it holds.
+	ErrInsufficientStock = errors.New("insufficient stock")
+)
+
+// Movement is a request to change stock levels. LocationID is where stock is
+// received, issued from, adjusted or transferred from. Quantity is positive
+// except for adjustments, where a negative quantity removes stock.
+type Movement struct {
+	Kind         string `json:"kind"`
+	Quantity     int    `json:"quantity"`
+	LocationID   string `json:"location_id"`
+	ToLocationID string `json:"to_location_id"`
+	OwnerID      string `json:"owner_id"`
+	Note         string `json:"note"`
+	CreatedBy    string `json:"created_by"`
+}
+
+func validate(consumable *utils.Consumable) error {
+	consumable.Name = strings.TrimSpace(consumable.Name)
+	if consumable.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRequest)
+	}
+	if consumable.SKU != nil && strings.TrimSpace(*consumable.SKU) == "" {
+		consumable.SKU = nil
+	}
+	if consumable.Unit == "" {
+		consumable.Unit = defaultUnit
+	}
+	if consumable.ReorderThreshold < 0 || consumable.ReorderQuantity < 0 {
+		return fmt.Errorf("%w: reorder_threshold and reorder_quantity must not be negative", ErrInvalidRequest)
+	}
+	return nil
+}
+
+// toLedger checks a movement request and turns it into a ledger entry that
+// takes stock from FromLocationID and puts it at ToLocationID.
+func toLedger(id string, movement *Movement) (*utils.ConsumableMovement, error) {
+	if movement.LocationID == "" {
+		return nil, fmt.Errorf("%w: location_id is required", ErrInvalidRequest)
+	}
+	if movement.CreatedBy == "" {
+		return nil, fmt.Errorf("%w: created_by is required", ErrInvalidRequest)
+	}
+	entry := &utils.ConsumableMovement{
+		ConsumableID: id,
+		Kind:         movement.Kind,
+		Quantity:     movement.Quantity,
+		Note:         movement.Note,
+		CreatedAt:    time.Now(),
+		CreatedBy:    movement.CreatedBy,
+	}
+	location := movement.LocationID
+	switch movement.Kind {
+	case utils.MovementReceive:
+		entry.ToLocationID = &location
+	case utils.MovementIssue:
+		if movement.OwnerID == "" {
+			return nil, fmt.Errorf("%w: owner_id is required to issue stock", ErrInvalidRequest)
+		}
+		owner := movement.OwnerID
+		entry.FromLocationID = &location
+		entry.OwnerID = &owner
+	case utils.MovementAdjust:
+		if strings.TrimSpace(movement.Note) == "" {
+			return nil, fmt.Errorf("%w: a note explaining the adjustment is required", ErrInvalidRequest)
+		}
+		if movement.Quantity < 0 {
+			entry.Quantity = -movement.Quantity
+			entry.FromLocationID = &location
+		} else {
+			entry.ToLocationID = &location
+		}
+	case utils.MovementTransfer:
+		if movement.ToLocationID == "" || movement.ToLocationID == movement.LocationID {
+			return nil, fmt.Errorf("%w: to_location_id must name a different location", ErrInvalidRequest)
+		}
+		to := movement.ToLocationID
+		entry.FromLocationID = &location
+		entry.ToLocationID = &to
+	default:
+		return nil, fmt.Errorf("%w: kind must be one of %s, %s, %s, %s", ErrInvalidRequest,
+			utils.MovementReceive, utils.MovementIssue, utils.MovementAdjust, utils.MovementTransfer)
+	}
+	if entry.Quantity <= 0 {
+		return nil, fmt.Errorf("%w: quantity must not be zero and only adjustments may be negative", ErrInvalidRequest)
+	}
+	return entry, nil
+}
+
+type Service struct {
+	dao          *Dao
+	locationsDao *locations.Dao
+	ownersDao    *owners.Dao
+	pdb          *utils.DB
+}
+
+func NewService(dao *Dao, pdb *utils.DB) *Service {
+	return &Service{
+		dao:          dao,
+		locationsDao: locations.NewDao(),
+		ownersDao:    owners.NewDao(),
+		pdb:          pdb,
+	}
+}
+
+func (s *Service) GetConsumable(ctx context.Context, id string) (*utils.Consumable, error) {
+	ctx, span := tracing.Start(ctx, "consumables.GetConsumable")
+	defer span.End()
+
+	var consumable *utils.Consumable
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		consumable, err = s.dao.GetConsumable(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get consumable: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return consumable, nil
+}
+
+func (s *Service) GetConsumables(ctx context.Context) ([]*utils.Consumable, error) {
+	ctx, span := tracing.Start(ctx, "consumables.GetConsumables")
+	defer span.End()
+
+	var consumables []*utils.Consumable
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		consumables, err = s.dao.GetConsumables(ctx, tx)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get consumables: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return consumables, nil
+}
+
+// GetLowStock lists the consumables at or below their reorder threshold.
+func (s *Service) GetLowStock(ctx context.Context) ([]*utils.Consumable, error) {
+	ctx, span := tracing.Start(ctx, "consumables.GetLowStock")
+	defer span.End()
+
+	var consumables []*utils.Consumable
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		consumables, err = s.dao.GetLowStock(ctx, tx)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get low-stock consumables: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return consumables, nil
+}
+
+func (s *Service) CreateConsumable(ctx context.Context, consumable *utils.Consumable) error {
+	ctx, span := tracing.Start(ctx, "consumables.CreateConsumable")
+	defer span.End()
+
+	if err := validate(consumable); err != nil {
+		return err
+	}
+	consumable.OnHand = 0
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.checkConflict(ctx, tx, consumable); err != nil {
+			return err
+		}
+		if err := s.dao.CreateConsumable(ctx, tx, consumable); err != nil {
+			utils.Log(ctx).Errorf("Failed to create consumable: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+// UpdateConsumable changes the description and reorder settings of a
+// consumable. Stock levels only change through movements.
+func (s *Service) UpdateConsumable(ctx context.Context, consumable *utils.Consumable) error {
+	ctx, span := tracing.Start(ctx, "consumables.UpdateConsumable")
+	defer span.End()
+
+	if err := validate(consumable); err != nil {
+		return err
+	}
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.checkConflict(ctx, tx, consumable); err != nil {
+			return err
+		}
+		if err := s.dao.UpdateConsumable(ctx, tx, consumable); err != nil {
+			utils.Log(ctx).Errorf("Failed to update consumable: %v", err)
+			return err
+		}
+		updated, err := s.dao.GetConsumable(ctx, tx, consumable.ID)
+		if err != nil {
+			return err
+		}
+		consumable.OnHand = updated.OnHand
+		return nil
+	})
+}
+
+func (s *Service) checkConflict(ctx context.Context, tx pgx.Tx, consumable *utils.Consumable) error {
+	existing, err := s.dao.FindConflict(ctx, tx, consumable.ID, consumable.Name, consumable.SKU)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		utils.Log(ctx).Errorf("Failed to check consumable conflicts: %v", err)
+		return err
+	}
+	return fmt.Errorf("%w: used by %s", ErrNameTaken, existing.Name)
+}
+
+// DeleteConsumable deletes a consumable that has never had stock. Once the
+// ledger has entries the consumable is kept so its history stays intact.
+func (s *Service) DeleteConsumable(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "consumables.DeleteConsumable")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		count, err := s.dao.CountMovements(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to count consumable movements: %v", err)
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: %d movements", ErrConsumableInUse, count)
+		}
+		if err := s.dao.DeleteConsumable(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Failed to delete consumable: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+// GetStock lists the quantity of a consumable held at each location.
+func (s *Service) GetStock(ctx context.Context, id string) ([]*utils.ConsumableStock, error) {
+	ctx, span := tracing.Start(ctx, "consumables.GetStock")
+	defer span.End()
+
+	var stock []*utils.ConsumableStock
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		if _, err := s.dao.GetConsumable(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Failed to get consumable: %v", err)
+			return err
+		}
+		var err error
+		stock, err = s.dao.GetStock(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get consumable stock: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stock, nil
+}
+
+func (s *Service) GetMovements(ctx context.Context, id string) ([]*utils.ConsumableMovement, error) {
+	ctx, span := tracing.Start(ctx, "consumables.GetMovements")
+	defer span.End()
+
+	var movements []*utils.ConsumableMovement
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		if _, err := s.dao.GetConsumable(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Failed to get consumable: %v", err)
+			return err
+		}
+		var err error
+		movements, err = s.dao.GetMovements(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get consumable movements: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// RecordMovement appends a movement to the ledger and applies it to the stock
+// levels in the same transaction. Stock never goes below zero.
+func (s *Service) RecordMovement(ctx context.Context, id string, movement *Movement) (*utils.ConsumableMovement, error) {
+	ctx, span := tracing.Start(ctx, "consumables.RecordMovement")
+	defer span.End()
+
+	entry, err := toLedger(id, movement)
+	if err != nil {
+		return nil, err
+	}
+	err = s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if _, err := s.dao.GetConsumable(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Failed to get consumable: %v", err)
+			return err
+		}
+		for _, locationID := range []*string{entry.FromLocationID, entry.ToLocationID} {
+			if locationID == nil {
+				continue
+			}
+			if _, err := s.locationsDao.GetLocation(ctx, tx, *locationID); errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: location %s does not exist", ErrInvalidRequest, *locationID)
+			} else if err != nil {
+				return err
+			}
+		}
+		if entry.OwnerID != nil {
+			if _, err := s.ownersDao.GetOwner(ctx, tx, *entry.OwnerID); errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: owner %s does not exist", ErrInvalidRequest, *entry.OwnerID)
+			} else if err != nil {
+				return err
+			}
+		}
+
+		if entry.FromLocationID != nil {
+			ok, err := s.dao.RemoveStock(ctx, tx, id, *entry.FromLocationID, entry.Quantity)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("%w: fewer than %d at location %s", ErrInsufficientStock, entry.Quantity, *entry.FromLocationID)
+			}
+		}
+		if entry.ToLocationID != nil {
+			if err := s.dao.AddStock(ctx, tx, id, *entry.ToLocationID, entry.Quantity); err != nil {
+				return err
+			}
+		}
+		if err := s.dao.CreateMovement(ctx, tx, entry); err != nil {
+			utils.Log(ctx).Errorf("Failed to record consumable movement: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,47 @@
+package consumables
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+func TestToLedger(t *testing.T) {
+	t.Run("Receive", func(t *testing.T) {
+		entry, err := toLedger("c1", &Movement{Kind: utils.MovementReceive, Quantity: 10, LocationID: "room1", CreatedBy: "jdoe"})
+		if err != nil {
+			t.Fatalf("Expected movement to be valid, got %v", err)
+		}
+		if entry.FromLocationID != nil || entry.ToLocationID == nil || *entry.ToLocationID != "room1" {
+			t.Errorf("Expected stock to arrive at room1, got %+v", entry)
+		}
+	})
+	t.Run("IssueNeedsOwner", func(t *testing.T) {
+		_, err := toLedger("c1", &Movement{Kind: utils.MovementIssue, Quantity: 1, LocationID: "room1", CreatedBy: "jdoe"})
+		if !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+	t.Run("NegativeAdjust", func(t *testing.T) {
+		entry, err := toLedger("c1", &Movement{Kind: utils.MovementAdjust, Quantity: -3, LocationID: "room1", Note: "damaged", CreatedBy: "jdoe"})
+		if err != nil {
+			t.Fatalf("Expected movement to be valid, got %v", err)
+		}
+		if entry.Quantity != 3 || entry.FromLocationID == nil || entry.ToLocationID != nil {
+			t.Errorf("Expected 3 to leave room1, got %+v", entry)
+		}
+	})
+	t.Run("NegativeReceive", func(t *testing.T) {
+		_, err := toLedger("c1", &Movement{Kind: utils.MovementReceive, Quantity: -3, LocationID: "room1", CreatedBy: "jdoe"})
+		if !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+	t.Run("TransferToSameLocation", func(t *testing.T) {
+		_, err := toLedger("c1", &Movement{Kind: utils.MovementTransfer, Quantity: 2, LocationID: "room1", ToLocationID: "room1", CreatedBy: "jdoe"})
+		if !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+}
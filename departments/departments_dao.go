@@ -0,0 +1,264 @@
+package departments
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Dao struct{}
+
+func NewDao() *Dao {
+	return &Dao{}
+}
+
+func scanDepartments(ctx context.Context, rows pgx.Rows) ([]*utils.Department, error) {
+	defer rows.Close()
+	var departments []*utils.Department
+	for rows.Next() {
+		var department utils.Department
+		if err := rows.Scan(&department.ID, &department.ParentID, &department.Name, &department.CostCenter); err != nil {
+			utils.Log(ctx).Errorf("Error scanning department row: %v", err)
+			return nil, err
+		}
+		departments = append(departments, &department)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over department rows: %v", err)
+		return nil, err
+	}
+	return departments, nil
+}
+
+func (d *Dao) GetDepartment(ctx context.Context, tx pgx.Tx, id string) (*utils.Department, error) {
+	utils.Log(ctx).Printf("Fetching department with ID: %s", id)
+	query := `SELECT id, parent_id, name, cost_center FROM departments WHERE id = $1`
+	var department utils.Department
+	err := tx.QueryRow(ctx, query, id).Scan(&department.ID, &department.ParentID, &department.Name, &department.CostCenter)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching department with ID %s: %v", id, err)
+		return nil, err
+	}
+	return &department, nil
+}
+
+func (d *Dao) GetDepartmentByCostCenter(ctx context.Context, tx pgx.Tx, costCenter string) (*utils.Department, error) {
+	utils.Log(ctx).Printf("Fetching department with cost center: %s", costCenter)
+	query := `SELECT id, parent_id, name, cost_center FROM departments WHERE cost_center = $1`
+	var department utils.Department
+	err := tx.QueryRow(ctx, query, costCenter).Scan(&department.ID, &department.ParentID, &department.Name, &department.CostCenter)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching department with cost center %s: %v", costCenter, err)
+		return nil, err
+	}
+	return &department, nil
+}
+
+func (d *Dao) GetDepartments(ctx context.Context, tx pgx.Tx) ([]*utils.Department, error) {
+	utils.Log(ctx).Println("Fetching all departments")
+	query := `SELECT id, parent_id, name, cost_center FROM departments ORDER BY name`
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching departments: %v", err)
+		return nil, err
+	}
+	return scanDepartments(ctx, rows)
+}
+
+// IsWithin reports whether id is ancestorID or one of its sub-departments.
+func (d *Dao) IsWithin(ctx context.Context, tx pgx.Tx, id string, ancestorID string) (bool, error) {
+	utils.Log(ctx).Printf("Checking whether department %s is within %s", id, ancestorID)
+	query := `WITH RECURSIVE ancestors AS (
+		SELECT id, parent_id FROM departments WHERE id = $1
+		UNION
+		SELECT d.id, d.parent_id
+		FROM departments d
+		JOIN ancestors a ON d.id = a.parent_id
+	)
+	SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2)`
+	var within bool
+	if err := tx.QueryRow(ctx, query, id, ancestorID).Scan(&within); err != nil {
+		utils.Log(ctx).Errorf("Error checking ancestors of department %s: %v", id, err)
+		return false, err
+	}
+	return within, nil
+}
+
+func (d *Dao) CreateDepartment(ctx context.Context, tx pgx.Tx, department *utils.Department) error {
+	utils.Log(ctx).Printf("Creating department: %+v", department)
+	if department.ID == "" {
+		id, err := gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Error generating ID for new department: %v", err)
+			return err
+		}
+		department.ID = id
+	}
+	query := `INSERT INTO departments (id, parent_id, name, cost_center) VALUES ($1, $2, $3, $4)`
+	_, err := tx.Exec(ctx, query, department.ID, department.ParentID, department.Name, department.CostCenter)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error creating department: %v", err)
+		return err
+	}
+	return nil
+}
+
+// UpdateDepartment also renames the cost center on devices charged to it.
+func (d *Dao) UpdateDepartment(ctx context.Context, tx pgx.Tx, department *utils.Department) error {
+	utils.Log(ctx).Printf("Updating department: %+v", department)
+	query := `UPDATE departments SET parent_id = $2, name = $3, cost_center = $4 WHERE id = $1`
+	tag, err := tx.Exec(ctx, query, department.ID, department.ParentID, department.Name, department.CostCenter)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error updating department with ID %s: %v", department.ID, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (d *Dao) DeleteDepartment(ctx context.Context, tx pgx.Tx, id string) error {
+	utils.Log(ctx).Printf("Deleting department with ID: %s", id)
+	query := `DELETE FROM departments WHERE id = $1`
+	tag, err := tx.Exec(ctx, query, id)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error deleting department with ID %s: %v", id, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// CountUsage counts the direct sub-departments and owners of a department,
+// and the devices charged to its cost center.
+func (d *Dao) CountUsage(ctx context.Context, tx pgx.Tx, id string) (int, int, int, error) {
+	utils.Log(ctx).Printf("Counting usage of department with ID: %s", id)
+	query := `SELECT
+		(SELECT count(*) FROM departments WHERE parent_id = $1),
+		(SELECT count(*) FROM owners WHERE department_id = $1),
+		(SELECT count(*) FROM devices v JOIN departments d ON d.cost_center = v.cost_center WHERE d.id = $1)`
+	var children, owners, devices int
+	if err := tx.QueryRow(ctx, query, id).Scan(&children, &owners, &devices); err != nil {
+		utils.Log(ctx).Errorf("Error counting usage of department %s: %v", id, err)
+		return 0, 0, 0, err
+	}
+	return children, owners, devices, nil
+}
+
+// GetOwners returns the owners in a department and, when recursive is set, in
+// all of its sub-departments.
+func (d *Dao) GetOwners(ctx context.Context, tx pgx.Tx, id string, recursive bool) ([]*utils.Owner, error) {
+	utils.Log(ctx).Printf("Fetching owners in department %s (recursive: %t)", id, recursive)
+	query := `WITH RECURSIVE tree AS (
+		SELECT id FROM departments WHERE id = $1
+		UNION
+		SELECT d.id
+		FROM departments d
+		JOIN tree t ON d.parent_id = t.id
+		WHERE $2
+	)
+	SELECT id, first_name, last_name, campus_id, email, status, department_id
+	FROM owners
+	WHERE department_id IN (SELECT id FROM tree)
+	ORDER BY last_name, first_name`
+	rows, err := tx.Query(ctx, query, id, recursive)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching owners in department %s: %v", id, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var owners []*utils.Owner
+	for rows.Next() {
+		var owner utils.Owner
+		if err := rows.Scan(&owner.ID, &owner.FirstName, &owner.LastName,
+			&owner.CampusID, &owner.Email, &owner.Status, &owner.DepartmentID); err != nil {
+			utils.Log(ctx).Errorf("Error scanning owner row: %v", err)
+			return nil, err
+		}
+		owners = append(owners, &owner)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over owner rows: %v", err)
+		return nil, err
+	}
+	return owners, nil
+}
+
+// GetReport rolls device counts and purchase value up the department tree.
+// A device counts towards the department of its cost center, or of its owner
+// when it has none. Devices with no department at all are left out. Values
+// are summed per currency, with currency standing in for devices that have
+// none. With a nil id every department is reported, otherwise only the given
+// one.
+func (d *Dao) GetReport(ctx context.Context, tx pgx.Tx, id *string, currency string) ([]*utils.DepartmentReport, error) {
+	utils.Log(ctx).Printf("Fetching department report for %v", id)
+	query := `WITH RECURSIVE tree AS (
+		SELECT id AS root_id, id FROM departments
+		UNION ALL
+		SELECT t.root_id, d.id
+		FROM departments d
+		JOIN tree t ON d.parent_id = t.id
+	), charged AS (
+		SELECT v.id, COALESCE(v.purchase_cost, 0) AS cost, COALESCE(v.currency, $2) AS currency,
+			COALESCE(c.id, o.department_id) AS department_id
+		FROM devices v
+		JOIN owners o ON o.id = v.owner_id
+		LEFT JOIN departments c ON c.cost_center = v.cost_center
+	)
+	SELECT d.id, d.parent_id, d.name, d.cost_center, c.currency,
+		count(c.id) FILTER (WHERE c.department_id = d.id),
+		COALESCE(sum(c.cost) FILTER (WHERE c.department_id = d.id), 0),
+		count(c.id),
+		COALESCE(sum(c.cost), 0)
+	FROM departments d
+	JOIN tree t ON t.root_id = d.id
+	LEFT JOIN charged c ON c.department_id = t.id
+	WHERE $1::varchar IS NULL OR d.id = $1
+	GROUP BY d.id, c.currency
+	ORDER BY d.name, d.id, c.currency`
+	rows, err := tx.Query(ctx, query, id, currency)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching department report: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Rows come one per department and currency; departments without
+	// devices have a single row with no currency.
+	var reports []*utils.DepartmentReport
+	for rows.Next() {
+		var row utils.DepartmentReport
+		var currency *string
+		var directDevices, devices int
+		var value utils.DepartmentValue
+		if err := rows.Scan(&row.ID, &row.ParentID, &row.Name, &row.CostCenter, &currency,
+			&directDevices, &value.DirectValue, &devices, &value.Value); err != nil {
+			utils.Log(ctx).Errorf("Error scanning department report row: %v", err)
+			return nil, err
+		}
+		report := &row
+		if n := len(reports); n > 0 && reports[n-1].ID == row.ID {
+			report = reports[n-1]
+		} else {
+			report.Values = []*utils.DepartmentValue{}
+			reports = append(reports, report)
+		}
+		report.DirectDevices += directDevices
+		report.Devices += devices
+		if currency != nil {
+			value.Currency = *currency
+			report.Values = append(report.Values, &value)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over department report rows: %v", err)
+		return nil, err
+	}
+	return reports, nil
+}
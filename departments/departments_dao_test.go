@@ -0,0 +1,139 @@
+package departments
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime"
+	"testing"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+var postgresURI string
+
+func init() {
+	viper.SetConfigName("app")
+	viper.AddConfigPath("../config")
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+	postgresURI = viper.GetString("postgres.dev") // Change to "postgres.dev" for development/local db
+	log.SetReportCaller(true)
+	log.SetFormatter(&log.TextFormatter{
+		ForceColors:     true,
+		FullTimestamp:   true,
+		TimestampFormat: "2006/01/02 15:04:05",
+		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
+			filename := path.Base(f.File)
+			return fmt.Sprintf("%s()", f.Function), fmt.Sprintf("\t%s:%d", filename, f.Line)
+		},
+	})
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestGetReport(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+
+	// Mock Data
+	suffix, _ := gonanoid.New(8)
+	faculty := &utils.Department{Name: "Test Faculty", CostCenter: "F-" + suffix}
+	if err := dao.CreateDepartment(ctx, tx, faculty); err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	lab := &utils.Department{ParentID: &faculty.ID, Name: "Test Lab", CostCenter: "L-" + suffix}
+	if err := dao.CreateDepartment(ctx, tx, lab); err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	id, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO owners (id, first_name, last_name, email, department_id)
+		VALUES ($1, $2, $3, $4, $5)
+	`, id, "John", "Doe", "john.doe@example.com", faculty.ID)
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	t_id, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO types (id, name, description) VALUES ($1, $2, $3)
+	`, t_id, "Test Type", "This is a test type")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	// One device follows its owner into the faculty, the others are charged
+	// to the lab's cost center, one of them bought in euros.
+	eur := "EUR"
+	for i, device := range []struct {
+		costCenter *string
+		currency   *string
+	}{{nil, nil}, {&lab.CostCenter, nil}, {&lab.CostCenter, &eur}} {
+		d_id, _ := gonanoid.New()
+		_, err = tx.Exec(ctx, `
+			INSERT INTO devices (id, serial_number, name, purchase_date, purchase_cost, currency, status, owner_id, type_id, cost_center) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`, d_id, fmt.Sprintf("SN%d", i), "Test Device", "2023-01-01", 1000, device.currency, "active", id, t_id, device.costCenter)
+		if err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+	}
+
+	t.Run("GetReport", func(t *testing.T) {
+		reports, err := dao.GetReport(ctx, tx, &faculty.ID, "USD")
+		if err != nil {
+			t.Fatalf("Error getting report: %v", err)
+		}
+		if len(reports) != 1 {
+			t.Fatalf("Expected 1 report, got %d", len(reports))
+		}
+		report := reports[0]
+		if report.DirectDevices != 1 || report.Devices != 3 {
+			t.Errorf("Expected 1 direct device and 3 in total, got %d and %d", report.DirectDevices, report.Devices)
+		}
+		want := []utils.DepartmentValue{
+			{Currency: "EUR", DirectValue: 0, Value: 1000},
+			{Currency: "USD", DirectValue: 1000, Value: 2000},
+		}
+		if len(report.Values) != len(want) {
+			t.Fatalf("Expected %d currencies, got %d", len(want), len(report.Values))
+		}
+		for i, value := range report.Values {
+			if *value != want[i] {
+				t.Errorf("Expected %+v, got %+v", want[i], *value)
+			}
+		}
+	})
+	t.Run("CountUsage", func(t *testing.T) {
+		children, owners, devices, err := dao.CountUsage(ctx, tx, lab.ID)
+		if err != nil {
+			t.Fatalf("Error counting usage: %v", err)
+		}
+		if children != 0 || owners != 0 || devices != 1 {
+			t.Errorf("Expected 0 sub-departments, 0 owners and 1 device, got %d, %d and %d", children, owners, devices)
+		}
+	})
+	t.Run("GetOwners", func(t *testing.T) {
+		owners, err := dao.GetOwners(ctx, tx, faculty.ID, true)
+		if err != nil {
+			t.Fatalf("Error getting owners: %v", err)
+		}
+		if len(owners) != 1 || owners[0].ID != id {
+			t.Fatalf("Expected owner %s, got %d owners", id, len(owners))
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package departments
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Handler struct {
+	svc *Service
+	// atz *authz.Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{
+		svc: svc,
+		// atz: atz,
+	}
+}
+
+func (h *Handler) GetDepartment(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	department, err := h.svc.GetDepartment(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(department)
+}
+
+func (h *Handler) GetDepartments(w http.ResponseWriter, r *http.Request) {
+	departments, err := h.svc.GetDepartments(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(departments)
+}
+
+func (h *Handler) CreateDepartment(w http.ResponseWriter, r *http.Request) {
+	var department utils.Department
+	if err := json.NewDecoder(r.Body).Decode(&department); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	if err := h.svc.CreateDepartment(r.Context(), &department); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(department)
+}
+
+func (h *Handler) UpdateDepartment(w http.ResponseWriter, r *http.Request) {
+	var department utils.Department
+	if err := json.NewDecoder(r.Body).Decode(&department); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	department.ID = mux.Vars(r)["id"]
+	if err := h.svc.UpdateDepartment(r.Context(), &department); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(department)
+}
+
+func (h *Handler) DeleteDepartment(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if err := h.svc.DeleteDepartment(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetOwners lists the owners in a department and its sub-departments, or
+// only in the department itself with ?recursive=false.
+func (h *Handler) GetOwners(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	recursive := true
+	if raw := r.URL.Query().Get("recursive"); raw != "" {
+		var err error
+		recursive, err = strconv.ParseBool(raw)
+		if err != nil {
+			http.Error(w, "recursive must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+	owners, err := h.svc.GetOwners(r.Context(), id, recursive)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(owners)
+}
+
+func (h *Handler) GetReports(w http.ResponseWriter, r *http.Request) {
+	reports, err := h.svc.GetReports(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reports)
+}
+
+func (h *Handler) GetReport(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	report, err := h.svc.GetReport(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrInvalidRequest):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrCostCenterTaken), errors.Is(err, ErrDepartmentInUse):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
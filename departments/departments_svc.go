@@ -0,0 +1,250 @@
+package departments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+var (
+	ErrInvalidRequest = errors.New("invalid department request")
+	// ErrCostCenterTaken is returned when another department already uses the
+	// cost center code.
+	ErrCostCenterTaken = errors.New("cost center is already used by another department")
+	// ErrDepartmentInUse is returned when deleting a department that still has
+	// sub-departments, owners or devices charged to it.
+	ErrDepartmentInUse = errors.New("department still has sub-departments, owners or devices")
+)
+
+func validate(department *utils.Department) error {
+	if strings.TrimSpace(department.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRequest)
+	}
+	if strings.TrimSpace(department.CostCenter) == "" {
+		return fmt.Errorf("%w: cost_center is required", ErrInvalidRequest)
+	}
+	return nil
+}
+
+type Service struct {
+	dao *Dao
+	pdb *utils.DB
+	// currency is assumed for devices whose purchase cost has none.
+	currency string
+}
+
+func NewService(dao *Dao, pdb *utils.DB, currency string) *Service {
+	return &Service{
+		dao:      dao,
+		pdb:      pdb,
+		currency: currency,
+	}
+}
+
+func (s *Service) GetDepartment(ctx context.Context, id string) (*utils.Department, error) {
+	ctx, span := tracing.Start(ctx, "departments.GetDepartment")
+	defer span.End()
+
+	var department *utils.Department
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		department, err = s.dao.GetDepartment(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get department: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return department, nil
+}
+
+func (s *Service) GetDepartments(ctx context.Context) ([]*utils.Department, error) {
+	ctx, span := tracing.Start(ctx, "departments.GetDepartments")
+	defer span.End()
+
+	var departments []*utils.Department
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		departments, err = s.dao.GetDepartments(ctx, tx)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get departments: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return departments, nil
+}
+
+func (s *Service) CreateDepartment(ctx context.Context, department *utils.Department) error {
+	ctx, span := tracing.Start(ctx, "departments.CreateDepartment")
+	defer span.End()
+
+	if err := validate(department); err != nil {
+		return err
+	}
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.check(ctx, tx, department); err != nil {
+			return err
+		}
+		if err := s.dao.CreateDepartment(ctx, tx, department); err != nil {
+			utils.Log(ctx).Errorf("Failed to create department: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+func (s *Service) UpdateDepartment(ctx context.Context, department *utils.Department) error {
+	ctx, span := tracing.Start(ctx, "departments.UpdateDepartment")
+	defer span.End()
+
+	if err := validate(department); err != nil {
+		return err
+	}
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.check(ctx, tx, department); err != nil {
+			return err
+		}
+		if err := s.dao.UpdateDepartment(ctx, tx, department); err != nil {
+			utils.Log(ctx).Errorf("Failed to update department: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+// check makes sure the cost center is free and the parent exists and is not
+// the department itself or one of its sub-departments.
+func (s *Service) check(ctx context.Context, tx pgx.Tx, department *utils.Department) error {
+	existing, err := s.dao.GetDepartmentByCostCenter(ctx, tx, department.CostCenter)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if existing != nil && existing.ID != department.ID {
+		return fmt.Errorf("%w: %s", ErrCostCenterTaken, department.CostCenter)
+	}
+
+	if department.ParentID == nil {
+		return nil
+	}
+	parentID := *department.ParentID
+	if _, err := s.dao.GetDepartment(ctx, tx, parentID); errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: parent department %s does not exist", ErrInvalidRequest, parentID)
+	} else if err != nil {
+		return err
+	}
+	if department.ID == "" {
+		return nil
+	}
+	within, err := s.dao.IsWithin(ctx, tx, parentID, department.ID)
+	if err != nil {
+		return err
+	}
+	if within {
+		return fmt.Errorf("%w: a department cannot be moved under itself or its sub-departments", ErrInvalidRequest)
+	}
+	return nil
+}
+
+func (s *Service) DeleteDepartment(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "departments.DeleteDepartment")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		children, owners, devices, err := s.dao.CountUsage(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to count department usage: %v", err)
+			return err
+		}
+		if children > 0 || owners > 0 || devices > 0 {
+			return fmt.Errorf("%w: %d sub-departments, %d owners, %d devices", ErrDepartmentInUse, children, owners, devices)
+		}
+		if err := s.dao.DeleteDepartment(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Failed to delete department: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+// GetOwners lists the owners in a department, including those in its
+// sub-departments when recursive is set.
+func (s *Service) GetOwners(ctx context.Context, id string, recursive bool) ([]*utils.Owner, error) {
+	ctx, span := tracing.Start(ctx, "departments.GetOwners")
+	defer span.End()
+
+	var owners []*utils.Owner
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		if _, err := s.dao.GetDepartment(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Failed to get department: %v", err)
+			return err
+		}
+		var err error
+		owners, err = s.dao.GetOwners(ctx, tx, id, recursive)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get owners in department: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return owners, nil
+}
+
+// GetReports returns the device count and purchase value per currency of
+// every department, rolled up over its sub-departments.
+func (s *Service) GetReports(ctx context.Context) ([]*utils.DepartmentReport, error) {
+	ctx, span := tracing.Start(ctx, "departments.GetReports")
+	defer span.End()
+
+	var reports []*utils.DepartmentReport
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		reports, err = s.dao.GetReport(ctx, tx, nil, s.currency)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get department reports: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (s *Service) GetReport(ctx context.Context, id string) (*utils.DepartmentReport, error) {
+	ctx, span := tracing.Start(ctx, "departments.GetReport")
+	defer span.End()
+
+	var report *utils.DepartmentReport
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		reports, err := s.dao.GetReport(ctx, tx, &id, s.currency)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get department report: %v", err)
+			return err
+		}
+		if len(reports) == 0 {
+			return pgx.ErrNoRows
+		}
+		report = reports[0]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
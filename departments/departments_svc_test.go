@@ -0,0 +1,29 @@
+package departments
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("Validate", func(t *testing.T) {
+		department := &utils.Department{Name: "Physics", CostCenter: "CC-1200"}
+		if err := validate(department); err != nil {
+			t.Fatalf("Expected department to be valid, got %v", err)
+		}
+	})
+	t.Run("ValidateMissingName", func(t *testing.T) {
+		department := &utils.Department{CostCenter: "CC-1200"}
+		if err := validate(department); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+	t.Run("ValidateMissingCostCenter", func(t *testing.T) {
+		department := &utils.Department{Name: "Physics", CostCenter: " "}
+		if err := validate(department); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,213 @@
+package components
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Dao struct{}
+
+func NewDao() *Dao {
+	return &Dao{}
+}
+
+const componentColumns = `id, parent_id, child_id, cascade_status, note, attached_at, attached_by, detached_at, detached_by`
+
+func scanComponent(row pgx.Row, component *utils.DeviceComponent) error {
+	return row.Scan(&component.ID, &component.ParentID, &component.ChildID, &component.CascadeStatus, &component.Note,
+		&component.AttachedAt, &component.AttachedBy, &component.DetachedAt, &component.DetachedBy)
+}
+
+func (d *Dao) queryComponents(ctx context.Context, tx pgx.Tx, query string, args ...interface{}) ([]*utils.DeviceComponent, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching device components: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var components []*utils.DeviceComponent
+	for rows.Next() {
+		var component utils.DeviceComponent
+		if err := scanComponent(rows, &component); err != nil {
+			utils.Log(ctx).Errorf("Error scanning device component row: %v", err)
+			return nil, err
+		}
+		components = append(components, &component)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over device component rows: %v", err)
+		return nil, err
+	}
+	return components, nil
+}
+
+// GetDeviceName returns the name of a device, or pgx.ErrNoRows when it does
+// not exist.
+func (d *Dao) GetDeviceName(ctx context.Context, tx pgx.Tx, id string) (string, error) {
+	utils.Log(ctx).Printf("Fetching name of device with ID: %s", id)
+	var name string
+	if err := tx.QueryRow(ctx, `SELECT name FROM devices WHERE id = $1`, id).Scan(&name); err != nil {
+		utils.Log(ctx).Errorf("Error fetching device with ID %s: %v", id, err)
+		return "", err
+	}
+	return name, nil
+}
+
+// GetAttachment returns the current relation of a child device to its
+// parent, or pgx.ErrNoRows when it is not attached.
+func (d *Dao) GetAttachment(ctx context.Context, tx pgx.Tx, childID string) (*utils.DeviceComponent, error) {
+	utils.Log(ctx).Printf("Fetching attachment of device with ID: %s", childID)
+	query := `SELECT ` + componentColumns + ` FROM device_components
+		WHERE child_id = $1 AND detached_at IS NULL
+		FOR UPDATE`
+	var component utils.DeviceComponent
+	if err := scanComponent(tx.QueryRow(ctx, query, childID), &component); err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
+
+// IsAncestor reports whether ancestorID is above deviceID in the current
+// component tree.
+func (d *Dao) IsAncestor(ctx context.Context, tx pgx.Tx, ancestorID string, deviceID string) (bool, error) {
+	utils.Log(ctx).Printf("Checking whether device %s is above device %s", ancestorID, deviceID)
+	query := `WITH RECURSIVE up AS (
+		SELECT parent_id FROM device_components WHERE child_id = $2 AND detached_at IS NULL
+		UNION
+		SELECT c.parent_id
+		FROM device_components c
+		JOIN up ON c.child_id = up.parent_id
+		WHERE c.detached_at IS NULL
+	)
+	SELECT EXISTS (SELECT 1 FROM up WHERE parent_id = $1)`
+	var found bool
+	if err := tx.QueryRow(ctx, query, ancestorID, deviceID).Scan(&found); err != nil {
+		utils.Log(ctx).Errorf("Error walking up the component tree of device %s: %v", deviceID, err)
+		return false, err
+	}
+	return found, nil
+}
+
+func (d *Dao) CreateAttachment(ctx context.Context, tx pgx.Tx, component *utils.DeviceComponent) error {
+	utils.Log(ctx).Printf("Attaching device %s to device %s", component.ChildID, component.ParentID)
+	if component.ID == "" {
+		id, err := gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Error generating ID for new device component: %v", err)
+			return err
+		}
+		component.ID = id
+	}
+	query := `INSERT INTO device_components (` + componentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := tx.Exec(ctx, query, component.ID, component.ParentID, component.ChildID, component.CascadeStatus, component.Note,
+		component.AttachedAt, component.AttachedBy, component.DetachedAt, component.DetachedBy)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error attaching device component: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (d *Dao) Detach(ctx context.Context, tx pgx.Tx, id string, detachedAt time.Time, detachedBy string) error {
+	utils.Log(ctx).Printf("Detaching device component with ID: %s", id)
+	query := `UPDATE device_components SET detached_at = $2, detached_by = $3 WHERE id = $1 AND detached_at IS NULL`
+	tag, err := tx.Exec(ctx, query, id, detachedAt, detachedBy)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error detaching device component %s: %v", id, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// GetHistory returns every relation a device has had as parent or child,
+// newest first.
+func (d *Dao) GetHistory(ctx context.Context, tx pgx.Tx, deviceID string) ([]*utils.DeviceComponent, error) {
+	utils.Log(ctx).Printf("Fetching component history of device with ID: %s", deviceID)
+	query := `SELECT ` + componentColumns + ` FROM device_components
+		WHERE parent_id = $1 OR child_id = $1
+		ORDER BY attached_at DESC, id`
+	return d.queryComponents(ctx, tx, query, deviceID)
+}
+
+// GetDescendants returns the current relations below a device, at any depth.
+func (d *Dao) GetDescendants(ctx context.Context, tx pgx.Tx, deviceID string) ([]*utils.DeviceComponent, error) {
+	utils.Log(ctx).Printf("Fetching components below device with ID: %s", deviceID)
+	query := `WITH RECURSIVE tree AS (
+		SELECT ` + componentColumns + ` FROM device_components WHERE parent_id = $1 AND detached_at IS NULL
+		UNION
+		SELECT c.id, c.parent_id, c.child_id, c.cascade_status, c.note, c.attached_at, c.attached_by, c.detached_at, c.detached_by
+		FROM device_components c
+		JOIN tree t ON c.parent_id = t.child_id
+		WHERE c.detached_at IS NULL
+	)
+	SELECT ` + componentColumns + ` FROM tree ORDER BY attached_at, id`
+	return d.queryComponents(ctx, tx, query, deviceID)
+}
+
+// SetDeviceStatus sets the status of one device, stamping lost_at when it
+// becomes lost, or returns pgx.ErrNoRows when it does not exist.
+func (d *Dao) SetDeviceStatus(ctx context.Context, tx pgx.Tx, deviceID string, status string) error {
+	utils.Log(ctx).Printf("Setting status of device %s to %s", deviceID, status)
+	query := `UPDATE devices
+	SET status = $2, lost_at = CASE WHEN $2 = $3 AND status <> $3 THEN now() ELSE lost_at END
+	WHERE id = $1`
+	tag, err := tx.Exec(ctx, query, deviceID, status, utils.DeviceStatusLost)
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not set status of device %s: %v", deviceID, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// CascadeStatus sets status on the devices reachable from a parent through
+// attached relations that cascade, stopping at relations that do not. It
+// returns the IDs of the devices whose status changed.
+func (d *Dao) CascadeStatus(ctx context.Context, tx pgx.Tx, parentID string, status string) ([]string, error) {
+	utils.Log(ctx).Printf("Cascading status %s from device %s", status, parentID)
+	query := `WITH RECURSIVE tree AS (
+		SELECT child_id FROM device_components
+		WHERE parent_id = $1 AND detached_at IS NULL AND cascade_status
+		UNION
+		SELECT c.child_id
+		FROM device_components c
+		JOIN tree t ON c.parent_id = t.child_id
+		WHERE c.detached_at IS NULL AND c.cascade_status
+	)
+	UPDATE devices
+	SET status = $2, lost_at = CASE WHEN $2 = $3 THEN now() ELSE lost_at END
+	WHERE id IN (SELECT child_id FROM tree) AND status <> $2
+	RETURNING id`
+	rows, err := tx.Query(ctx, query, parentID, status, utils.DeviceStatusLost)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error cascading status from device %s: %v", parentID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			utils.Log(ctx).Errorf("Error scanning cascaded device ID: %v", err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over cascaded device IDs: %v", err)
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,157 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime"
+	"testing"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/devices/logs"
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+var postgresURI string
+
+func init() {
+	viper.SetConfigName("app")
+	viper.AddConfigPath("../../config")
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+	postgresURI = viper.GetString("postgres.dev") // Change to "postgres.dev" for development/local db
+	log.SetReportCaller(true)
+	log.SetFormatter(&log.TextFormatter{
+		ForceColors:     true,
+		FullTimestamp:   true,
+		TimestampFormat: "2006/01/02 15:04:05",
+		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
+			filename := path.Base(f.File)
+			return fmt.Sprintf("%s()", f.Function), fmt.Sprintf("\t%s:%d", filename, f.Line)
+		},
+	})
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestComponents(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+	// Mock Data
+	id, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO owners (id, first_name, last_name, email)
+		VALUES ($1, $2, $3, $4)
+	`, id, "John", "Doe", "john.doe@example.com")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	t_id, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO types (id, name, description) VALUES ($1, $2, $3)
+	`, t_id, "Test Type", "This is a test type")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	var devices []string
+	for i := 0; i < 3; i++ {
+		d_id, _ := gonanoid.New()
+		_, err = tx.Exec(ctx, `
+			INSERT INTO devices (id, serial_number, name, purchase_date, status, owner_id, type_id) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, d_id, fmt.Sprintf("SN-C%d", i), fmt.Sprintf("Test Device %d", i), "2023-01-01", "active", id, t_id)
+		if err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+		devices = append(devices, d_id)
+	}
+	workstation, dock, monitor := devices[0], devices[1], devices[2]
+	for _, component := range []*utils.DeviceComponent{
+		{ParentID: workstation, ChildID: dock, CascadeStatus: true},
+		{ParentID: dock, ChildID: monitor, CascadeStatus: true},
+	} {
+		component.AttachedAt = time.Now()
+		component.AttachedBy = "tester"
+		if err := dao.CreateAttachment(ctx, tx, component); err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+	}
+
+	t.Run("IsAncestor", func(t *testing.T) {
+		found, err := dao.IsAncestor(ctx, tx, workstation, monitor)
+		if err != nil {
+			t.Fatalf("Error walking the tree: %v", err)
+		}
+		if !found {
+			t.Errorf("Expected the workstation to be above the monitor")
+		}
+	})
+	t.Run("GetDescendants", func(t *testing.T) {
+		relations, err := dao.GetDescendants(ctx, tx, workstation)
+		if err != nil {
+			t.Fatalf("Error getting descendants: %v", err)
+		}
+		if len(relations) != 2 {
+			t.Errorf("Expected 2 relations, got %d", len(relations))
+		}
+	})
+	t.Run("CascadeStatus", func(t *testing.T) {
+		ids, err := dao.CascadeStatus(ctx, tx, workstation, "in_repair")
+		if err != nil {
+			t.Fatalf("Error cascading status: %v", err)
+		}
+		if len(ids) != 2 {
+			t.Errorf("Expected dock and monitor to change, got %v", ids)
+		}
+	})
+	t.Run("SetStatus", func(t *testing.T) {
+		if err := NewStatusSetter().SetStatus(ctx, tx, workstation, "lost"); err != nil {
+			t.Fatalf("Error setting status: %v", err)
+		}
+		var status string
+		if err := tx.QueryRow(ctx, `SELECT status FROM devices WHERE id = $1`, monitor).Scan(&status); err != nil {
+			t.Fatalf("Error getting status: %v", err)
+		}
+		if status != "lost" {
+			t.Errorf("Expected the monitor to be lost, got %s", status)
+		}
+		entries, err := logs.NewDao().GetLogs(ctx, tx, monitor)
+		if err != nil {
+			t.Fatalf("Error getting logs: %v", err)
+		}
+		if len(entries) != 1 || entries[0].LogType != logTypeStatus {
+			t.Errorf("Expected one status log entry on the monitor, got %+v", entries)
+		}
+	})
+	t.Run("Detach", func(t *testing.T) {
+		current, err := dao.GetAttachment(ctx, tx, monitor)
+		if err != nil {
+			t.Fatalf("Error getting attachment: %v", err)
+		}
+		if err := dao.Detach(ctx, tx, current.ID, time.Now(), "tester"); err != nil {
+			t.Fatalf("Error detaching: %v", err)
+		}
+		history, err := dao.GetHistory(ctx, tx, monitor)
+		if err != nil {
+			t.Fatalf("Error getting history: %v", err)
+		}
+		if len(history) != 1 || history[0].DetachedAt == nil {
+			t.Errorf("Expected one detached relation, got %+v", history)
+		}
+	})
+}
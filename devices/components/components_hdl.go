@@ -0,0 +1,81 @@
+package components
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Handler struct {
+	svc *Service
+	// atz *authz.Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{
+		svc: svc,
+		// atz: atz,
+	}
+}
+
+func (h *Handler) Attach(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var component utils.DeviceComponent
+	if err := json.NewDecoder(r.Body).Decode(&component); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	if err := h.svc.Attach(r.Context(), id, &component); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(component)
+}
+
+func (h *Handler) Detach(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	var detachment Detachment
+	if err := json.NewDecoder(r.Body).Decode(&detachment); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	component, err := h.svc.Detach(r.Context(), vars["id"], vars["child_id"], &detachment)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(component)
+}
+
+func (h *Handler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	history, err := h.svc.GetHistory(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(history)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrInvalidRequest):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrAlreadyAttached), errors.Is(err, ErrCycle):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,70 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/devices/logs"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+const (
+	logTypeStatus = "status"
+	// cascadeActor is recorded as the author of log entries for status
+	// changes copied from a parent device.
+	cascadeActor = "system"
+)
+
+// StatusSetter changes device statuses for every service that does, so a
+// change always reaches the components whose relation cascades status and is
+// logged on each of them.
+type StatusSetter struct {
+	dao     *Dao
+	logsDao *logs.Dao
+}
+
+func NewStatusSetter() *StatusSetter {
+	return &StatusSetter{
+		dao:     NewDao(),
+		logsDao: logs.NewDao(),
+	}
+}
+
+// SetStatus sets the status of a device and cascades it to its components.
+func (s *StatusSetter) SetStatus(ctx context.Context, tx pgx.Tx, deviceID string, status string) error {
+	if err := s.dao.SetDeviceStatus(ctx, tx, deviceID, status); err != nil {
+		return err
+	}
+	return s.Cascade(ctx, tx, deviceID, status)
+}
+
+// Cascade copies the status a device was just given to its components, for
+// callers that save the status along with other fields of the device.
+func (s *StatusSetter) Cascade(ctx context.Context, tx pgx.Tx, parentID string, status string) error {
+	parentName, err := s.dao.GetDeviceName(ctx, tx, parentID)
+	if err != nil {
+		return err
+	}
+	cascaded, err := s.dao.CascadeStatus(ctx, tx, parentID, status)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error cascading status to components: %v", err)
+		return err
+	}
+	now := time.Now()
+	for _, id := range cascaded {
+		entry := &utils.DeviceLog{
+			DeviceID:  id,
+			LogType:   logTypeStatus,
+			Note:      fmt.Sprintf("Status set to %s along with parent device %s (%s)", status, parentName, parentID),
+			CreatedAt: now,
+			CreatedBy: cascadeActor,
+		}
+		if err := s.logsDao.CreateLog(ctx, tx, entry); err != nil {
+			utils.Log(ctx).Errorf("Error logging cascaded status: %v", err)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/devices/logs"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+const logTypeComponent = "component"
+
+var (
+	ErrInvalidRequest = errors.New("invalid component request")
+	// ErrAlreadyAttached is returned when attaching a device that is already
+	// a component of another device.
+	ErrAlreadyAttached = errors.New("device is already attached to a parent")
+	// ErrCycle is returned when attaching a device below itself.
+	ErrCycle = errors.New("attachment would create a cycle")
+)
+
+// Detachment records who removed a component from its parent and why.
+type Detachment struct {
+	DetachedBy string `json:"detached_by"`
+	Note       string `json:"note"`
+}
+
+type Service struct {
+	dao     *Dao
+	logsDao *logs.Dao
+	pdb     *utils.DB
+}
+
+func NewService(dao *Dao, pdb *utils.DB) *Service {
+	return &Service{
+		dao:     dao,
+		logsDao: logs.NewDao(),
+		pdb:     pdb,
+	}
+}
+
+// Attach makes component.ChildID a component of parentID and logs the change
+// on both devices.
+func (s *Service) Attach(ctx context.Context, parentID string, component *utils.DeviceComponent) error {
+	ctx, span := tracing.Start(ctx, "components.Attach")
+	defer span.End()
+
+	if component.ChildID == "" {
+		return fmt.Errorf("%w: child_id is required", ErrInvalidRequest)
+	}
+	if component.AttachedBy == "" {
+		return fmt.Errorf("%w: attached_by is required", ErrInvalidRequest)
+	}
+	if component.ChildID == parentID {
+		return fmt.Errorf("%w: a device cannot be its own component", ErrCycle)
+	}
+	component.ID = ""
+	component.ParentID = parentID
+	component.AttachedAt = time.Now()
+	component.DetachedAt = nil
+	component.DetachedBy = nil
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		parentName, err := s.dao.GetDeviceName(ctx, tx, parentID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get parent device: %v", err)
+			return err
+		}
+		childName, err := s.dao.GetDeviceName(ctx, tx, component.ChildID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: device %s does not exist", ErrInvalidRequest, component.ChildID)
+		} else if err != nil {
+			return err
+		}
+		current, err := s.dao.GetAttachment(ctx, tx, component.ChildID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+		if current != nil {
+			return fmt.Errorf("%w: %s is attached to %s", ErrAlreadyAttached, component.ChildID, current.ParentID)
+		}
+		cycle, err := s.dao.IsAncestor(ctx, tx, component.ChildID, parentID)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return fmt.Errorf("%w: %s is above %s", ErrCycle, component.ChildID, parentID)
+		}
+		if err := s.dao.CreateAttachment(ctx, tx, component); err != nil {
+			utils.Log(ctx).Errorf("Failed to attach component: %v", err)
+			return err
+		}
+		return s.log(ctx, tx, component.AttachedAt, component.AttachedBy, component.Note,
+			parentID, fmt.Sprintf("Attached component %s (%s)", childName, component.ChildID),
+			component.ChildID, fmt.Sprintf("Attached to %s (%s)", parentName, parentID))
+	})
+}
+
+// Detach removes childID from parentID. The relation is kept as history.
+func (s *Service) Detach(ctx context.Context, parentID string, childID string, detachment *Detachment) (*utils.DeviceComponent, error) {
+	ctx, span := tracing.Start(ctx, "components.Detach")
+	defer span.End()
+
+	if detachment.DetachedBy == "" {
+		return nil, fmt.Errorf("%w: detached_by is required", ErrInvalidRequest)
+	}
+	var component *utils.DeviceComponent
+	err := s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		component, err = s.dao.GetAttachment(ctx, tx, childID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get component attachment: %v", err)
+			return err
+		}
+		if component.ParentID != parentID {
+			return fmt.Errorf("%w: %s is not a component of %s", pgx.ErrNoRows, childID, parentID)
+		}
+		parentName, err := s.dao.GetDeviceName(ctx, tx, parentID)
+		if err != nil {
+			return err
+		}
+		childName, err := s.dao.GetDeviceName(ctx, tx, childID)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := s.dao.Detach(ctx, tx, component.ID, now, detachment.DetachedBy); err != nil {
+			utils.Log(ctx).Errorf("Failed to detach component: %v", err)
+			return err
+		}
+		component.DetachedAt = &now
+		component.DetachedBy = &detachment.DetachedBy
+		return s.log(ctx, tx, now, detachment.DetachedBy, detachment.Note,
+			parentID, fmt.Sprintf("Detached component %s (%s)", childName, childID),
+			childID, fmt.Sprintf("Detached from %s (%s)", parentName, parentID))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return component, nil
+}
+
+// log writes a component entry to the parent's and the child's device log.
+func (s *Service) log(ctx context.Context, tx pgx.Tx, at time.Time, by string, note string, parentID string, parentNote string, childID string, childNote string) error {
+	for deviceID, text := range map[string]string{parentID: parentNote, childID: childNote} {
+		if note != "" {
+			text += ": " + note
+		}
+		entry := &utils.DeviceLog{
+			DeviceID:  deviceID,
+			LogType:   logTypeComponent,
+			Note:      text,
+			CreatedAt: at,
+			CreatedBy: by,
+		}
+		if err := s.logsDao.CreateLog(ctx, tx, entry); err != nil {
+			utils.Log(ctx).Errorf("Failed to write component log: %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// GetHistory lists every attachment a device has had as parent or child,
+// including detached ones.
+func (s *Service) GetHistory(ctx context.Context, deviceID string) ([]*utils.DeviceComponent, error) {
+	ctx, span := tracing.Start(ctx, "components.GetHistory")
+	defer span.End()
+
+	var history []*utils.DeviceComponent
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		if _, err := s.dao.GetDeviceName(ctx, tx, deviceID); err != nil {
+			return err
+		}
+		var err error
+		history, err = s.dao.GetHistory(ctx, tx, deviceID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get component history: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
@@ -2,11 +2,11 @@ package devices
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/jackc/pgx/v5"
 	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/rickCrz7/Inventory-API/utils"
-	log "github.com/sirupsen/logrus"
 )
 
 type Dao struct{}
@@ -16,26 +16,26 @@ func NewDao() *Dao {
 }
 
 func (d *Dao) GetDevice(ctx context.Context, tx pgx.Tx, id string) (*utils.Device, error) {
-	log.Printf("Fetching device with ID: %s", id)
-	query := `SELECT id, serial_number, name, type_id, owner_id, purchase_date, status
+	utils.Log(ctx).Printf("Fetching device with ID: %s", id)
+	query := `SELECT id, serial_number, name, type_id, owner_id, location_id, cost_center, purchase_date, purchase_cost, currency, vendor, po_number, invoice_ref, status, asset_tag
 	FROM devices
 	WHERE id = $1`
 	var device utils.Device
-	err := tx.QueryRow(ctx, query, id).Scan(&device.ID, &device.SerialNumber, &device.Name, &device.TypeID, &device.OwnerID, &device.PurchaseDate, &device.Status)
+	err := tx.QueryRow(ctx, query, id).Scan(&device.ID, &device.SerialNumber, &device.Name, &device.TypeID, &device.OwnerID, &device.LocationID, &device.CostCenter, &device.PurchaseDate, &device.PurchaseCost, &device.Currency, &device.Vendor, &device.PONumber, &device.InvoiceRef, &device.Status, &device.AssetTag)
 	if err != nil {
-		log.Errorf("Error fetching device with ID %s: %v", id, err)
+		utils.Log(ctx).Errorf("Error fetching device with ID %s: %v", id, err)
 		return nil, err
 	}
 	return &device, nil
 }
 
 func (d *Dao) GetDevices(ctx context.Context, tx pgx.Tx) ([]*utils.Device, error) {
-	log.Println("Fetching all devices")
-	query := `SELECT id, serial_number, name, type_id, owner_id, purchase_date, status
+	utils.Log(ctx).Println("Fetching all devices")
+	query := `SELECT id, serial_number, name, type_id, owner_id, location_id, cost_center, purchase_date, purchase_cost, currency, vendor, po_number, invoice_ref, status, asset_tag
 	FROM devices`
 	rows, err := tx.Query(ctx, query)
 	if err != nil {
-		log.Errorf("Error fetching devices: %v", err)
+		utils.Log(ctx).Errorf("Error fetching devices: %v", err)
 		return nil, err
 	}
 	defer rows.Close()
@@ -43,59 +43,191 @@ func (d *Dao) GetDevices(ctx context.Context, tx pgx.Tx) ([]*utils.Device, error
 	var devices []*utils.Device
 	for rows.Next() {
 		var device utils.Device
-		if err := rows.Scan(&device.ID, &device.SerialNumber, &device.Name, &device.TypeID, &device.OwnerID, &device.PurchaseDate, &device.Status); err != nil {
-			log.Errorf("Error scanning device row: %v", err)
+		if err := rows.Scan(&device.ID, &device.SerialNumber, &device.Name, &device.TypeID, &device.OwnerID, &device.LocationID, &device.CostCenter, &device.PurchaseDate, &device.PurchaseCost, &device.Currency, &device.Vendor, &device.PONumber, &device.InvoiceRef, &device.Status, &device.AssetTag); err != nil {
+			utils.Log(ctx).Errorf("Error scanning device row: %v", err)
 			return nil, err
 		}
 		devices = append(devices, &device)
 	}
 	if err := rows.Err(); err != nil {
-		log.Errorf("Error iterating over device rows: %v", err)
+		utils.Log(ctx).Errorf("Error iterating over device rows: %v", err)
 		return nil, err
 	}
 	return devices, nil
 }
 
 func (d *Dao) CreateDevice(ctx context.Context, tx pgx.Tx, device *utils.Device) error {
-	log.Printf("Creating device: %+v", device)
+	utils.Log(ctx).Printf("Creating device: %+v", device)
 	if device.ID == "" {
 		id, err := gonanoid.New()
 		if err != nil {
-			log.Errorf("Error generating ID for new device: %v", err)
+			utils.Log(ctx).Errorf("Error generating ID for new device: %v", err)
 			return err
 		}
 		device.ID = id
 	}
-	query := `INSERT INTO devices (id, serial_number, name, type_id, owner_id, purchase_date, status)
-	VALUES ($1, $2, $3, $4, $5, $6, $7)`
-	_, err := tx.Exec(ctx, query, device.ID, device.SerialNumber, device.Name, device.TypeID, device.OwnerID, device.PurchaseDate, device.Status)
+	tag, err := d.NextAssetTag(ctx, tx, device.TypeID)
 	if err != nil {
-		log.Errorf("Error creating device: %v", err)
+		return err
+	}
+	device.AssetTag = &tag
+	query := `INSERT INTO devices (id, serial_number, name, type_id, owner_id, location_id, cost_center, purchase_date, purchase_cost, currency, vendor, po_number, invoice_ref, status, asset_tag, lost_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, CASE WHEN $14 = $16 THEN now() END)`
+	_, err = tx.Exec(ctx, query, device.ID, device.SerialNumber, device.Name, device.TypeID, device.OwnerID, device.LocationID, device.CostCenter,
+		device.PurchaseDate, device.PurchaseCost, device.Currency, device.Vendor, device.PONumber, device.InvoiceRef, device.Status, device.AssetTag,
+		utils.DeviceStatusLost)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error creating device: %v", err)
 		return err
 	}
 	return nil
 }
 
+// NextAssetTag hands out the next asset tag for a device of the given type,
+// e.g. LAP-000123. Numbers run per prefix; a type without a prefix uses the
+// first three letters or digits of its name, or DEV. The counter row stays
+// locked until the transaction ends, so tags are never handed out twice.
+func (d *Dao) NextAssetTag(ctx context.Context, tx pgx.Tx, typeID string) (string, error) {
+	utils.Log(ctx).Printf("Assigning asset tag for type: %s", typeID)
+	query := `INSERT INTO asset_tag_sequences (prefix, last_value)
+	SELECT COALESCE(asset_tag_prefix, NULLIF(upper(left(regexp_replace(name, '[^A-Za-z0-9]', '', 'g'), 3)), ''), 'DEV'), 1
+	FROM types
+	WHERE id = $1
+	ON CONFLICT (prefix) DO UPDATE SET last_value = asset_tag_sequences.last_value + 1
+	RETURNING prefix, last_value`
+	var prefix string
+	var number int
+	if err := tx.QueryRow(ctx, query, typeID).Scan(&prefix, &number); err != nil {
+		utils.Log(ctx).Errorf("Error assigning asset tag for type %s: %v", typeID, err)
+		return "", err
+	}
+	return fmt.Sprintf("%s-%06d", prefix, number), nil
+}
+
+func (d *Dao) GetDeviceByTag(ctx context.Context, tx pgx.Tx, tag string) (*utils.Device, error) {
+	utils.Log(ctx).Printf("Fetching device with asset tag: %s", tag)
+	query := `SELECT id, serial_number, name, type_id, owner_id, location_id, cost_center, purchase_date, purchase_cost, currency, vendor, po_number, invoice_ref, status, asset_tag
+	FROM devices
+	WHERE asset_tag = upper($1)`
+	var device utils.Device
+	err := tx.QueryRow(ctx, query, tag).Scan(&device.ID, &device.SerialNumber, &device.Name, &device.TypeID, &device.OwnerID, &device.LocationID, &device.CostCenter, &device.PurchaseDate, &device.PurchaseCost, &device.Currency, &device.Vendor, &device.PONumber, &device.InvoiceRef, &device.Status, &device.AssetTag)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching device with asset tag %s: %v", tag, err)
+		return nil, err
+	}
+	return &device, nil
+}
+
+// UpdateDevice leaves location_id alone; moves go through the locations
+// package so they are logged. The asset tag is never changed.
 func (d *Dao) UpdateDevice(ctx context.Context, tx pgx.Tx, device *utils.Device) error {
-	log.Printf("Updating device: %+v", device)
+	utils.Log(ctx).Printf("Updating device: %+v", device)
 	query := `UPDATE devices
-	SET serial_number = $1, name = $2, type_id = $3, owner_id = $4, purchase_date = $5, status = $6
+	SET serial_number = $1, name = $2, type_id = $3, owner_id = $4, purchase_date = $5, status = $6, cost_center = $8, purchase_cost = $9,
+		currency = $10, vendor = $11, po_number = $12, invoice_ref = $13,
+		lost_at = CASE WHEN $6 = $14 AND status <> $14 THEN now() ELSE lost_at END
 	WHERE id = $7`
-	_, err := tx.Exec(ctx, query, device.SerialNumber, device.Name, device.TypeID, device.OwnerID, device.PurchaseDate, device.Status, device.ID)
+	_, err := tx.Exec(ctx, query, device.SerialNumber, device.Name, device.TypeID, device.OwnerID, device.PurchaseDate, device.Status, device.ID, device.CostCenter, device.PurchaseCost,
+		device.Currency, device.Vendor, device.PONumber, device.InvoiceRef, utils.DeviceStatusLost)
 	if err != nil {
-		log.Errorf("Error updating device with ID %s: %v", device.ID, err)
+		utils.Log(ctx).Errorf("Error updating device with ID %s: %v", device.ID, err)
 		return err
 	}
 	return nil
 }
 
 func (d *Dao) DeleteDevice(ctx context.Context, tx pgx.Tx, id string) error {
-	log.Printf("Deleting device with ID: %s", id)
+	utils.Log(ctx).Printf("Deleting device with ID: %s", id)
 	query := `DELETE FROM devices WHERE id = $1`
 	_, err := tx.Exec(ctx, query, id)
 	if err != nil {
-		log.Errorf("Error deleting device with ID %s: %v", id, err)
+		utils.Log(ctx).Errorf("Error deleting device with ID %s: %v", id, err)
 		return err
 	}
 	return nil
 }
+
+func (d *Dao) GetDevicesByIDs(ctx context.Context, tx pgx.Tx, ids []string) ([]*utils.Device, error) {
+	utils.Log(ctx).Printf("Fetching devices for IDs: %v", ids)
+	query := `SELECT id, serial_number, name, type_id, owner_id, location_id, cost_center, purchase_date, purchase_cost, currency, vendor, po_number, invoice_ref, status, asset_tag
+	FROM devices
+	WHERE id = ANY($1)`
+	rows, err := tx.Query(ctx, query, ids)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching devices: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var devices []*utils.Device
+	for rows.Next() {
+		var device utils.Device
+		if err := rows.Scan(&device.ID, &device.SerialNumber, &device.Name, &device.TypeID, &device.OwnerID, &device.LocationID, &device.CostCenter, &device.PurchaseDate, &device.PurchaseCost, &device.Currency, &device.Vendor, &device.PONumber, &device.InvoiceRef, &device.Status, &device.AssetTag); err != nil {
+			utils.Log(ctx).Errorf("Error scanning device row: %v", err)
+			return nil, err
+		}
+		devices = append(devices, &device)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over device rows: %v", err)
+		return nil, err
+	}
+	return devices, nil
+}
+
+func (d *Dao) GetDevicesByOwnerIDs(ctx context.Context, tx pgx.Tx, ownerIDs []string) ([]*utils.Device, error) {
+	utils.Log(ctx).Printf("Fetching devices for owner IDs: %v", ownerIDs)
+	query := `SELECT id, serial_number, name, type_id, owner_id, location_id, cost_center, purchase_date, purchase_cost, currency, vendor, po_number, invoice_ref, status, asset_tag
+	FROM devices
+	WHERE owner_id = ANY($1)`
+	rows, err := tx.Query(ctx, query, ownerIDs)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching devices: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var devices []*utils.Device
+	for rows.Next() {
+		var device utils.Device
+		if err := rows.Scan(&device.ID, &device.SerialNumber, &device.Name, &device.TypeID, &device.OwnerID, &device.LocationID, &device.CostCenter, &device.PurchaseDate, &device.PurchaseCost, &device.Currency, &device.Vendor, &device.PONumber, &device.InvoiceRef, &device.Status, &device.AssetTag); err != nil {
+			utils.Log(ctx).Errorf("Error scanning device row: %v", err)
+			return nil, err
+		}
+		devices = append(devices, &device)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over device rows: %v", err)
+		return nil, err
+	}
+	return devices, nil
+}
+
+func (d *Dao) GetPhotos(ctx context.Context, tx pgx.Tx, deviceID string) ([]*utils.DevicePhoto, error) {
+	utils.Log(ctx).Printf("Fetching photos for device with ID: %s", deviceID)
+	query := `SELECT id, device_id, photo, created_at
+	FROM device_photos
+	WHERE device_id = $1
+	ORDER BY created_at`
+	rows, err := tx.Query(ctx, query, deviceID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching photos for device with ID %s: %v", deviceID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var photos []*utils.DevicePhoto
+	for rows.Next() {
+		var photo utils.DevicePhoto
+		if err := rows.Scan(&photo.ID, &photo.DeviceID, &photo.Photo, &photo.CreatedAt); err != nil {
+			utils.Log(ctx).Errorf("Error scanning photo row: %v", err)
+			return nil, err
+		}
+		photos = append(photos, &photo)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over photo rows: %v", err)
+		return nil, err
+	}
+	return photos, nil
+}
@@ -38,7 +38,7 @@ func init() {
 }
 
 func TestGetDevice(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -99,7 +99,7 @@ func TestGetDevice(t *testing.T) {
 }
 
 func TestGetDevices(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -148,10 +148,19 @@ func TestGetDevices(t *testing.T) {
 			t.Fatalf("Expected at least 5 devices, got %d", len(devices))
 		}
 	})
+	t.Run("GetDevicesByOwnerIDs", func(t *testing.T) {
+		devices, err := dao.GetDevicesByOwnerIDs(ctx, tx, []string{id})
+		if err != nil {
+			t.Fatalf("Error getting devices: %v", err)
+		}
+		if len(devices) != 5 {
+			t.Fatalf("Expected 5 devices, got %d", len(devices))
+		}
+	})
 }
 
 func TestCreateDevice(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -239,7 +248,7 @@ func TestCreateDevice(t *testing.T) {
 }
 
 func TestUpdateDevice(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -305,7 +314,7 @@ func TestUpdateDevice(t *testing.T) {
 }
 
 func TestDeleteDevice(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -352,4 +361,71 @@ func TestDeleteDevice(t *testing.T) {
 			t.Fatalf("Expected error getting deleted device, got nil")
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestGetPhotos(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+	// Mock Data
+	id, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO owners (id, first_name, last_name, email)
+		VALUES ($1, $2, $3, $4)
+	`, id, "John", "Doe", "john.doe@example.com")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	t_id, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO types (id, name, description) VALUES ($1, $2, $3)
+	`, t_id, "Test Type", "This is a test type")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	d_id, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO devices (id, serial_number, name, purchase_date, status, owner_id, type_id) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, d_id, "SN123456", "Test Device", "2023-01-01", "active", id, t_id)
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		p_id, _ := gonanoid.New()
+		_, err = tx.Exec(ctx, `
+			INSERT INTO device_photos (id, device_id, photo, created_at) VALUES ($1, $2, $3, NOW())
+		`, p_id, d_id, fmt.Sprintf("photo-%d.jpg", i))
+		if err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+	}
+	t.Run("GetPhotos", func(t *testing.T) {
+		photos, err := dao.GetPhotos(ctx, tx, d_id)
+		if err != nil {
+			t.Fatalf("Error getting photos: %v", err)
+		}
+		if len(photos) != 2 {
+			t.Fatalf("Expected 2 photos, got %d", len(photos))
+		}
+	})
+	t.Run("GetPhotosNotFound", func(t *testing.T) {
+		photos, err := dao.GetPhotos(ctx, tx, "nonexistent-id")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if photos != nil {
+			t.Fatal("Expected photos to be nil")
+		}
+	})
+}
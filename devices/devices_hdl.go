@@ -2,9 +2,11 @@ package devices
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
 	"github.com/rickCrz7/Inventory-API/utils"
 )
 
@@ -22,6 +24,10 @@ func NewHandler(svc *Service) *Handler {
 
 func (h *Handler) GetDevice(w http.ResponseWriter, r *http.Request) {
 	id := mux.Vars(r)["id"]
+	if raw := r.URL.Query().Get("expand"); raw != "" {
+		h.getDeviceDetail(w, r, id, raw)
+		return
+	}
 	device, err := h.svc.GetDevice(r.Context(), id)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -32,6 +38,38 @@ func (h *Handler) GetDevice(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(device)
 }
 
+func (h *Handler) GetDeviceByTag(w http.ResponseWriter, r *http.Request) {
+	tag := mux.Vars(r)["tag"]
+	device, err := h.svc.GetDeviceByTag(r.Context(), tag)
+	if errors.Is(err, pgx.ErrNoRows) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(device)
+}
+
+func (h *Handler) getDeviceDetail(w http.ResponseWriter, r *http.Request, id, rawExpand string) {
+	expand, err := ParseExpand(rawExpand)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	detail, err := h.svc.GetDeviceDetail(r.Context(), id, expand)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(detail)
+}
+
 func (h *Handler) GetDevices(w http.ResponseWriter, r *http.Request) {
 	devices, err := h.svc.GetDevices(r.Context())
 	if err != nil {
@@ -46,7 +84,7 @@ func (h *Handler) GetDevices(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) CreateDevice(w http.ResponseWriter, r *http.Request) {
 	var device utils.Device
 	if err := json.NewDecoder(r.Body).Decode(&device); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
 		return
 	}
 	if err := h.svc.CreateDevice(r.Context(), &device); err != nil {
@@ -60,7 +98,7 @@ func (h *Handler) CreateDevice(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) UpdateDevice(w http.ResponseWriter, r *http.Request) {
 	var device utils.Device
 	if err := json.NewDecoder(r.Body).Decode(&device); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
 		return
 	}
 	if err := h.svc.UpdateDevice(r.Context(), &device); err != nil {
@@ -79,3 +117,21 @@ func (h *Handler) DeleteDevice(w http.ResponseWriter, r *http.Request) {
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// GetComponents returns the device with its attached components nested below
+// it.
+func (h *Handler) GetComponents(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	tree, err := h.svc.GetComponents(r.Context(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(tree)
+}
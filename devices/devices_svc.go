@@ -2,138 +2,333 @@ package devices
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rickCrz7/Inventory-API/devices/components"
+	"github.com/rickCrz7/Inventory-API/devices/logs"
+	dev_properties "github.com/rickCrz7/Inventory-API/devices/properties"
+	"github.com/rickCrz7/Inventory-API/locations"
+	"github.com/rickCrz7/Inventory-API/owners"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/types"
 	"github.com/rickCrz7/Inventory-API/utils"
-	log "github.com/sirupsen/logrus"
 )
 
 type Service struct {
-	dao *Dao
-	pdb *pgxpool.Pool
+	dao           *Dao
+	componentsDao *components.Dao
+	statuses      *components.StatusSetter
+	ownersDao     *owners.Dao
+	typesDao      *types.Dao
+	propertiesDao *dev_properties.Dao
+	logsDao       *logs.Dao
+	locationsDao  *locations.Dao
+	pdb           *utils.DB
 }
 
-func NewService(dao *Dao, pdb *pgxpool.Pool) *Service {
+func NewService(dao *Dao, pdb *utils.DB) *Service {
 	return &Service{
-		dao: dao,
-		pdb: pdb,
+		dao:           dao,
+		componentsDao: components.NewDao(),
+		statuses:      components.NewStatusSetter(),
+		ownersDao:     owners.NewDao(),
+		typesDao:      types.NewDao(),
+		propertiesDao: dev_properties.NewDao(),
+		logsDao:       logs.NewDao(),
+		locationsDao:  locations.NewDao(),
+		pdb:           pdb,
 	}
 }
 
+// Expand lists the relations to embed in a DeviceDetail.
+type Expand struct {
+	Owner      bool
+	Type       bool
+	Properties bool
+	Logs       bool
+	Photos     bool
+	Location   bool
+}
+
+// ParseExpand parses the comma separated ?expand= value, e.g.
+// "owner,type,properties,logs,photos,location".
+func ParseExpand(raw string) (Expand, error) {
+	var expand Expand
+	for _, name := range strings.Split(raw, ",") {
+		switch strings.TrimSpace(name) {
+		case "":
+		case "owner":
+			expand.Owner = true
+		case "type":
+			expand.Type = true
+		case "properties":
+			expand.Properties = true
+		case "logs":
+			expand.Logs = true
+		case "photos":
+			expand.Photos = true
+		case "location":
+			expand.Location = true
+		default:
+			return Expand{}, fmt.Errorf("unknown expand value %q", name)
+		}
+	}
+	return expand, nil
+}
+
 func (s *Service) GetDevice(ctx context.Context, id string) (*utils.Device, error) {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadOnly,
+	ctx, span := tracing.Start(ctx, "devices.GetDevice")
+	defer span.End()
+
+	var device *utils.Device
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		device, err = s.dao.GetDevice(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error fetching device with ID %s: %v", id, err)
+			return err
+		}
+		return nil
 	})
 	if err != nil {
-		log.Errorf("Error starting transaction: %v", err)
 		return nil, err
 	}
-	defer tx.Rollback(ctx)
+	return device, nil
+}
 
-	device, err := s.dao.GetDevice(ctx, tx, id)
-	if err != nil {
-		log.Errorf("Error fetching device with ID %s: %v", id, err)
-		return nil, err
-	}
+// GetDeviceByTag looks a device up by its asset tag, ignoring case.
+func (s *Service) GetDeviceByTag(ctx context.Context, tag string) (*utils.Device, error) {
+	ctx, span := tracing.Start(ctx, "devices.GetDeviceByTag")
+	defer span.End()
 
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
+	var device *utils.Device
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		device, err = s.dao.GetDeviceByTag(ctx, tx, tag)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error fetching device with asset tag %s: %v", tag, err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
-
 	return device, nil
 }
 
 func (s *Service) GetDevices(ctx context.Context) ([]*utils.Device, error) {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadOnly,
-	})
-	if err != nil {
-		log.Errorf("Error starting transaction: %v", err)
-		return nil, err
-	}
-	defer tx.Rollback(ctx)
+	ctx, span := tracing.Start(ctx, "devices.GetDevices")
+	defer span.End()
 
-	devices, err := s.dao.GetDevices(ctx, tx)
+	var devices []*utils.Device
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		devices, err = s.dao.GetDevices(ctx, tx)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error fetching devices: %v", err)
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		log.Errorf("Error fetching devices: %v", err)
 		return nil, err
 	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
-		return nil, err
-	}
-
 	return devices, nil
 }
 
 func (s *Service) CreateDevice(ctx context.Context, device *utils.Device) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadWrite,
+	ctx, span := tracing.Start(ctx, "devices.CreateDevice")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.dao.CreateDevice(ctx, tx, device); err != nil {
+			utils.Log(ctx).Errorf("Error creating device: %v", err)
+			return err
+		}
+		return nil
 	})
-	if err != nil {
-		log.Errorf("Error starting transaction: %v", err)
-		return err
-	}
-	defer tx.Rollback(ctx)
+}
 
-	if err := s.dao.CreateDevice(ctx, tx, device); err != nil {
-		log.Errorf("Error creating device: %v", err)
-		return err
-	}
+// UpdateDevice saves a device. When its status changes, the change is copied
+// to the attached components whose relation cascades status, and logged on
+// each of them.
+func (s *Service) UpdateDevice(ctx context.Context, device *utils.Device) error {
+	ctx, span := tracing.Start(ctx, "devices.UpdateDevice")
+	defer span.End()
 
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
-		return err
-	}
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		current, err := s.dao.GetDevice(ctx, tx, device.ID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error fetching device with ID %s: %v", device.ID, err)
+			return err
+		}
+		if err := s.dao.UpdateDevice(ctx, tx, device); err != nil {
+			utils.Log(ctx).Errorf("Error updating device: %v", err)
+			return err
+		}
+		if current.Status == device.Status {
+			return nil
+		}
+		return s.statuses.Cascade(ctx, tx, device.ID, device.Status)
+	})
+}
 
-	return nil
+func (s *Service) DeleteDevice(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "devices.DeleteDevice")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.dao.DeleteDevice(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Error deleting device: %v", err)
+			return err
+		}
+		return nil
+	})
 }
 
-func (s *Service) UpdateDevice(ctx context.Context, device *utils.Device) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadWrite,
+func (s *Service) GetDevicesByOwnerIDs(ctx context.Context, ownerIDs []string) ([]*utils.Device, error) {
+	ctx, span := tracing.Start(ctx, "devices.GetDevicesByOwnerIDs")
+	defer span.End()
+
+	var devices []*utils.Device
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		devices, err = s.dao.GetDevicesByOwnerIDs(ctx, tx, ownerIDs)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error fetching devices: %v", err)
+			return err
+		}
+		return nil
 	})
 	if err != nil {
-		log.Errorf("Error starting transaction: %v", err)
-		return err
+		return nil, err
 	}
-	defer tx.Rollback(ctx)
+	return devices, nil
+}
 
-	if err := s.dao.UpdateDevice(ctx, tx, device); err != nil {
-		log.Errorf("Error updating device: %v", err)
-		return err
-	}
+// GetDeviceDetail loads a device and the requested relations in a single
+// read-only transaction, so they are consistent with each other.
+func (s *Service) GetDeviceDetail(ctx context.Context, id string, expand Expand) (*utils.DeviceDetail, error) {
+	ctx, span := tracing.Start(ctx, "devices.GetDeviceDetail")
+	defer span.End()
 
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
-		return err
-	}
+	var detail *utils.DeviceDetail
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		device, err := s.dao.GetDevice(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error fetching device with ID %s: %v", id, err)
+			return err
+		}
+		detail = &utils.DeviceDetail{Device: *device}
 
-	return nil
+		if expand.Owner {
+			detail.Owner, err = s.ownersDao.GetOwner(ctx, tx, device.OwnerID)
+			if err != nil {
+				utils.Log(ctx).Errorf("Error fetching owner for device with ID %s: %v", id, err)
+				return err
+			}
+		}
+		if expand.Type {
+			detail.Type, err = s.typesDao.GetType(ctx, tx, device.TypeID)
+			if err != nil {
+				utils.Log(ctx).Errorf("Error fetching type for device with ID %s: %v", id, err)
+				return err
+			}
+		}
+		if expand.Properties {
+			detail.Properties, err = s.propertiesDao.GetLabeledProperties(ctx, tx, id)
+			if err != nil {
+				utils.Log(ctx).Errorf("Error fetching properties for device with ID %s: %v", id, err)
+				return err
+			}
+		}
+		if expand.Logs {
+			detail.Logs, err = s.logsDao.GetLogs(ctx, tx, id)
+			if err != nil {
+				utils.Log(ctx).Errorf("Error fetching logs for device with ID %s: %v", id, err)
+				return err
+			}
+		}
+		if expand.Photos {
+			detail.Photos, err = s.dao.GetPhotos(ctx, tx, id)
+			if err != nil {
+				utils.Log(ctx).Errorf("Error fetching photos for device with ID %s: %v", id, err)
+				return err
+			}
+		}
+		if expand.Location && device.LocationID != nil {
+			detail.Location, err = s.locationsDao.GetLocation(ctx, tx, *device.LocationID)
+			if err != nil {
+				utils.Log(ctx).Errorf("Error fetching location for device with ID %s: %v", id, err)
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return detail, nil
 }
 
-func (s *Service) DeleteDevice(ctx context.Context, id string) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadWrite,
+// GetComponents returns a device with its attached components as a tree.
+func (s *Service) GetComponents(ctx context.Context, id string) (*utils.DeviceTree, error) {
+	ctx, span := tracing.Start(ctx, "devices.GetComponents")
+	defer span.End()
+
+	var tree *utils.DeviceTree
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		root, err := s.dao.GetDevice(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error fetching device with ID %s: %v", id, err)
+			return err
+		}
+		relations, err := s.componentsDao.GetDescendants(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error fetching components of device %s: %v", id, err)
+			return err
+		}
+		ids := make([]string, 0, len(relations))
+		for _, relation := range relations {
+			ids = append(ids, relation.ChildID)
+		}
+		devices, err := s.dao.GetDevicesByIDs(ctx, tx, ids)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error fetching component devices: %v", err)
+			return err
+		}
+		tree = buildTree(root, relations, devices)
+		return nil
 	})
 	if err != nil {
-		log.Errorf("Error starting transaction: %v", err)
-		return err
+		return nil, err
 	}
-	defer tx.Rollback(ctx)
+	return tree, nil
+}
 
-	if err := s.dao.DeleteDevice(ctx, tx, id); err != nil {
-		log.Errorf("Error deleting device: %v", err)
-		return err
+// buildTree nests the devices under root following the relations.
+func buildTree(root *utils.Device, relations []*utils.DeviceComponent, devices []*utils.Device) *utils.DeviceTree {
+	byID := make(map[string]*utils.Device, len(devices))
+	for _, device := range devices {
+		byID[device.ID] = device
 	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
-		return err
+	children := make(map[string][]*utils.DeviceComponent)
+	for _, relation := range relations {
+		children[relation.ParentID] = append(children[relation.ParentID], relation)
 	}
 
-	return nil
+	var build func(device *utils.Device, relation *utils.DeviceComponent) *utils.DeviceTree
+	build = func(device *utils.Device, relation *utils.DeviceComponent) *utils.DeviceTree {
+		node := &utils.DeviceTree{Device: *device, Relation: relation, Components: []*utils.DeviceTree{}}
+		for _, child := range children[device.ID] {
+			if childDevice, ok := byID[child.ChildID]; ok {
+				node.Components = append(node.Components, build(childDevice, child))
+			}
+		}
+		return node
+	}
+	return build(root, nil)
 }
@@ -0,0 +1,49 @@
+package devices
+
+import (
+	"testing"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+func TestParseExpand(t *testing.T) {
+	t.Run("ParseExpand", func(t *testing.T) {
+		expand, err := ParseExpand("owner, type,properties,logs,photos,location")
+		if err != nil {
+			t.Fatalf("Error parsing expand: %v", err)
+		}
+		if expand != (Expand{Owner: true, Type: true, Properties: true, Logs: true, Photos: true, Location: true}) {
+			t.Fatalf("Expected all relations, got %+v", expand)
+		}
+	})
+	t.Run("ParseExpandUnknown", func(t *testing.T) {
+		if _, err := ParseExpand("owner,warranty"); err == nil {
+			t.Fatal("Expected error for unknown expand value, got nil")
+		}
+	})
+}
+
+func TestBuildTree(t *testing.T) {
+	root := &utils.Device{ID: "ws"}
+	relations := []*utils.DeviceComponent{
+		{ParentID: "ws", ChildID: "dock"},
+		{ParentID: "ws", ChildID: "gpu"},
+		{ParentID: "dock", ChildID: "monitor"},
+	}
+	devices := []*utils.Device{{ID: "dock"}, {ID: "gpu"}, {ID: "monitor"}}
+
+	tree := buildTree(root, relations, devices)
+	if tree.Relation != nil {
+		t.Errorf("Expected no relation at the root, got %+v", tree.Relation)
+	}
+	if len(tree.Components) != 2 {
+		t.Fatalf("Expected 2 components, got %d", len(tree.Components))
+	}
+	dock := tree.Components[0]
+	if dock.ID != "dock" || len(dock.Components) != 1 || dock.Components[0].ID != "monitor" {
+		t.Errorf("Expected the monitor under the dock, got %+v", dock)
+	}
+	if len(tree.Components[1].Components) != 0 {
+		t.Errorf("Expected the gpu to have no components")
+	}
+}
@@ -38,7 +38,7 @@ func init() {
 }
 
 func TestGetLogs(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -109,10 +109,37 @@ func TestGetLogs(t *testing.T) {
 			t.Fatal("Expected logs to be nil")
 		}
 	})
+
+	t.Run("GetLogsByDeviceIDs", func(t *testing.T) {
+		logs, err := dao.GetLogsByDeviceIDs(ctx, tx, []string{d_id, "nonexistent-id"})
+		if err != nil {
+			t.Fatalf("Error getting logs: %v", err)
+		}
+		if len(logs) != 5 {
+			t.Fatalf("Expected 5 logs, got %d", len(logs))
+		}
+	})
+
+	t.Run("GetLogsSince", func(t *testing.T) {
+		logs, err := dao.GetLogsSince(ctx, tx, d_id, time.Now().Add(-time.Hour))
+		if err != nil {
+			t.Fatalf("Error getting logs: %v", err)
+		}
+		if len(logs) != 5 {
+			t.Fatalf("Expected 5 logs, got %d", len(logs))
+		}
+		logs, err = dao.GetLogsSince(ctx, tx, d_id, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatalf("Error getting logs: %v", err)
+		}
+		if len(logs) != 0 {
+			t.Fatalf("Expected 0 logs, got %d", len(logs))
+		}
+	})
 }
 
 func TestCreateLogs(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -223,7 +250,7 @@ func TestCreateLogs(t *testing.T) {
 }
 
 func TestDeleteLogs(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
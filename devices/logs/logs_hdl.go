@@ -35,7 +35,7 @@ func (h *Handler) GetLogs(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) CreateLog(w http.ResponseWriter, r *http.Request) {
 	var logEntry utils.DeviceLog
 	if err := json.NewDecoder(r.Body).Decode(&logEntry); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
 		return
 	}
 	if err := h.svc.CreateLog(r.Context(), &logEntry); err != nil {
@@ -2,19 +2,19 @@ package logs
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rickCrz7/Inventory-API/tracing"
 	"github.com/rickCrz7/Inventory-API/utils"
-	log "github.com/sirupsen/logrus"
 )
 
 type Service struct {
 	dao *Dao
-	pdb *pgxpool.Pool
+	pdb *utils.DB
 }
 
-func NewService(dao *Dao, pdb *pgxpool.Pool) *Service {
+func NewService(dao *Dao, pdb *utils.DB) *Service {
 	return &Service{
 		dao: dao,
 		pdb: pdb,
@@ -22,67 +22,87 @@ func NewService(dao *Dao, pdb *pgxpool.Pool) *Service {
 }
 
 func (s *Service) GetLogs(ctx context.Context, deviceID string) ([]*utils.DeviceLog, error) {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadOnly,
+	ctx, span := tracing.Start(ctx, "logs.GetLogs")
+	defer span.End()
+
+	var logs []*utils.DeviceLog
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		logs, err = s.dao.GetLogs(ctx, tx, deviceID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error fetching logs: %v", err)
+			return err
+		}
+		return nil
 	})
 	if err != nil {
-		log.Errorf("Error starting transaction: %v", err)
 		return nil, err
 	}
-	defer tx.Rollback(ctx)
-
-	logs, err := s.dao.GetLogs(ctx, tx, deviceID)
-	if err != nil {
-		log.Errorf("Error fetching logs: %v", err)
-		return nil, err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
-		return nil, err
-	}
-
 	return logs, nil
 }
 
 func (s *Service) CreateLog(ctx context.Context, logEntry *utils.DeviceLog) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{})
-	if err != nil {
-		log.Errorf("Error beginning transaction: %v", err)
-		return err
-	}
-	defer tx.Rollback(ctx)
-
-	if err := s.dao.CreateLog(ctx, tx, logEntry); err != nil {
-		log.Errorf("Error creating log: %v", err)
-		return err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
-		return err
-	}
-
-	return nil
+	ctx, span := tracing.Start(ctx, "logs.CreateLog")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.dao.CreateLog(ctx, tx, logEntry); err != nil {
+			utils.Log(ctx).Errorf("Error creating log: %v", err)
+			return err
+		}
+		return nil
+	})
 }
 
 func (s *Service) DeleteLog(ctx context.Context, id string) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{})
-	if err != nil {
-		log.Errorf("Error beginning transaction: %v", err)
-		return err
-	}
-	defer tx.Rollback(ctx)
+	ctx, span := tracing.Start(ctx, "logs.DeleteLog")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.dao.DeleteLog(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Error deleting log: %v", err)
+			return err
+		}
+		return nil
+	})
+}
 
-	if err := s.dao.DeleteLog(ctx, tx, id); err != nil {
-		log.Errorf("Error deleting log: %v", err)
-		return err
+func (s *Service) GetLogsByDeviceIDs(ctx context.Context, deviceIDs []string) ([]*utils.DeviceLog, error) {
+	ctx, span := tracing.Start(ctx, "logs.GetLogsByDeviceIDs")
+	defer span.End()
+
+	var logs []*utils.DeviceLog
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		logs, err = s.dao.GetLogsByDeviceIDs(ctx, tx, deviceIDs)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error fetching logs: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return logs, nil
+}
 
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
-		return err
+func (s *Service) GetLogsSince(ctx context.Context, deviceID string, since time.Time) ([]*utils.DeviceLog, error) {
+	ctx, span := tracing.Start(ctx, "logs.GetLogsSince")
+	defer span.End()
+
+	var logs []*utils.DeviceLog
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		logs, err = s.dao.GetLogsSince(ctx, tx, deviceID, since)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error fetching logs: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	return nil
+	return logs, nil
 }
@@ -6,7 +6,6 @@ import (
 	"github.com/jackc/pgx/v5"
 	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/rickCrz7/Inventory-API/utils"
-	log "github.com/sirupsen/logrus"
 )
 
 type Dao struct{}
@@ -16,7 +15,7 @@ func NewDao() *Dao {
 }
 
 func (d *Dao) GetProperties(ctx context.Context, tx pgx.Tx, device_id string) ([]*utils.DeviceProperty, error) {
-	log.Printf("Fetching properties with Device ID: %s", device_id)
+	utils.Log(ctx).Printf("Fetching properties with Device ID: %s", device_id)
 	var properties []*utils.DeviceProperty
 	query := `SELECT id, device_id, type_property_id, value FROM device_properties WHERE device_id = $1`
 	rows, err := tx.Query(ctx, query, device_id)
@@ -39,12 +38,12 @@ func (d *Dao) GetProperties(ctx context.Context, tx pgx.Tx, device_id string) ([
 }
 
 func (d *Dao) CreateProperty(ctx context.Context, tx pgx.Tx, property *utils.DeviceProperty) error {
-	log.Printf("Creating property for Device ID: %s", property.DeviceID)
+	utils.Log(ctx).Printf("Creating property for Device ID: %s", property.DeviceID)
 	if property.ID == "" {
 		var err error
 		property.ID, err = gonanoid.New()
 		if err != nil {
-			log.Errorf("Error generating ID for property: %v", err)
+			utils.Log(ctx).Errorf("Error generating ID for property: %v", err)
 			return err
 		}
 	}
@@ -52,32 +51,82 @@ func (d *Dao) CreateProperty(ctx context.Context, tx pgx.Tx, property *utils.Dev
 	query := `INSERT INTO device_properties (id, device_id, type_property_id, value) VALUES ($1, $2, $3, $4)`
 	_, err := tx.Exec(ctx, query, property.ID, property.DeviceID, property.TypePropertyID, property.Value)
 	if err != nil {
-		log.Errorf("Error creating property for Device ID %s: %v", property.DeviceID, err)
+		utils.Log(ctx).Errorf("Error creating property for Device ID %s: %v", property.DeviceID, err)
 		return err
 	}
 	return nil
 }
 
 func (d *Dao) UpdateProperty(ctx context.Context, tx pgx.Tx, property *utils.DeviceProperty) error {
-	log.Printf("Updating property for Device ID: %s", property.DeviceID)
+	utils.Log(ctx).Printf("Updating property for Device ID: %s", property.DeviceID)
 
 	query := `UPDATE device_properties SET type_property_id = $1, value = $2 WHERE id = $3`
 	_, err := tx.Exec(ctx, query, property.TypePropertyID, property.Value, property.ID)
 	if err != nil {
-		log.Errorf("Error updating property for Device ID %s: %v", property.DeviceID, err)
+		utils.Log(ctx).Errorf("Error updating property for Device ID %s: %v", property.DeviceID, err)
 		return err
 	}
 	return nil
 }
 
 func (d *Dao) DeleteProperty(ctx context.Context, tx pgx.Tx, id string) error {
-	log.Printf("Deleting property with ID: %s", id)
+	utils.Log(ctx).Printf("Deleting property with ID: %s", id)
 
 	query := `DELETE FROM device_properties WHERE id = $1`
 	_, err := tx.Exec(ctx, query, id)
 	if err != nil {
-		log.Errorf("Error deleting property with ID %s: %v", id, err)
+		utils.Log(ctx).Errorf("Error deleting property with ID %s: %v", id, err)
 		return err
 	}
 	return nil
 }
+
+func (d *Dao) GetPropertiesByDeviceIDs(ctx context.Context, tx pgx.Tx, device_ids []string) ([]*utils.DeviceProperty, error) {
+	utils.Log(ctx).Printf("Fetching properties with Device IDs: %v", device_ids)
+	var properties []*utils.DeviceProperty
+	query := `SELECT id, device_id, type_property_id, value FROM device_properties WHERE device_id = ANY($1)`
+	rows, err := tx.Query(ctx, query, device_ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var property utils.DeviceProperty
+		if err := rows.Scan(&property.ID, &property.DeviceID, &property.TypePropertyID, &property.Value); err != nil {
+			return nil, err
+		}
+		properties = append(properties, &property)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
+
+func (d *Dao) GetLabeledProperties(ctx context.Context, tx pgx.Tx, device_id string) ([]*utils.LabeledDeviceProperty, error) {
+	utils.Log(ctx).Printf("Fetching labeled properties with Device ID: %s", device_id)
+	var properties []*utils.LabeledDeviceProperty
+	query := `SELECT dp.id, dp.device_id, dp.type_property_id, dp.value, tp.name, tp.data_type
+	FROM device_properties dp
+	JOIN type_properties tp ON tp.id = dp.type_property_id
+	WHERE dp.device_id = $1
+	ORDER BY tp.name`
+	rows, err := tx.Query(ctx, query, device_id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var property utils.LabeledDeviceProperty
+		if err := rows.Scan(&property.ID, &property.DeviceID, &property.TypePropertyID, &property.Value, &property.Name, &property.DataType); err != nil {
+			return nil, err
+		}
+		properties = append(properties, &property)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
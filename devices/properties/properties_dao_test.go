@@ -37,7 +37,7 @@ func init() {
 }
 
 func TestGetProperties(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -146,10 +146,32 @@ func TestGetProperties(t *testing.T) {
 			t.Fatal("Expected properties to be nil")
 		}
 	})
+	t.Run("GetPropertiesByDeviceIDs", func(t *testing.T) {
+		properties, err := dao.GetPropertiesByDeviceIDs(ctx, tx, []string{d_id, "nonexistent-id"})
+		if err != nil {
+			t.Fatalf("Error getting properties: %v", err)
+		}
+		if len(properties) != 3 {
+			t.Fatalf("Expected 3 properties, got %d", len(properties))
+		}
+	})
+	t.Run("GetLabeledProperties", func(t *testing.T) {
+		properties, err := dao.GetLabeledProperties(ctx, tx, d_id)
+		if err != nil {
+			t.Fatalf("Error getting labeled properties: %v", err)
+		}
+		if len(properties) != 3 {
+			t.Fatalf("Expected 3 properties, got %d", len(properties))
+		}
+		// Ordered by type property name
+		if properties[0].Name != "color" || properties[0].DataType != "string" || properties[0].Value != "red" {
+			t.Fatalf("Expected color/string/red, got %s/%s/%s", properties[0].Name, properties[0].DataType, properties[0].Value)
+		}
+	})
 }
 
 func TestCreateProperty(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -253,7 +275,7 @@ func TestCreateProperty(t *testing.T) {
 }
 
 func TestUpdateProperty(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -336,7 +358,7 @@ func TestUpdateProperty(t *testing.T) {
 }
 
 func TestDeleteProperty(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
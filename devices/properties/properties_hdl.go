@@ -35,7 +35,7 @@ func (h *Handler) GetProperties(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) CreateProperty(w http.ResponseWriter, r *http.Request) {
 	var prop utils.DeviceProperty
 	if err := json.NewDecoder(r.Body).Decode(&prop); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
 		return
 	}
 	if err := h.svc.CreateProperty(r.Context(), &prop); err != nil {
@@ -49,7 +49,7 @@ func (h *Handler) CreateProperty(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) UpdateProperty(w http.ResponseWriter, r *http.Request) {
 	var prop utils.DeviceProperty
 	if err := json.NewDecoder(r.Body).Decode(&prop); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
 		return
 	}
 	if err := h.svc.UpdateProperty(r.Context(), &prop); err != nil {
@@ -4,17 +4,16 @@ import (
 	"context"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rickCrz7/Inventory-API/tracing"
 	"github.com/rickCrz7/Inventory-API/utils"
-	log "github.com/sirupsen/logrus"
 )
 
 type Service struct {
 	dao *Dao
-	pdb *pgxpool.Pool
+	pdb *utils.DB
 }
 
-func NewService(dao *Dao, pdb *pgxpool.Pool) *Service {
+func NewService(dao *Dao, pdb *utils.DB) *Service {
 	return &Service{
 		dao: dao,
 		pdb: pdb,
@@ -22,90 +21,80 @@ func NewService(dao *Dao, pdb *pgxpool.Pool) *Service {
 }
 
 func (s *Service) GetProperties(ctx context.Context, id string) ([]*utils.DeviceProperty, error) {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadOnly,
+	ctx, span := tracing.Start(ctx, "properties.GetProperties")
+	defer span.End()
+
+	var props []*utils.DeviceProperty
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		props, err = s.dao.GetProperties(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error getting properties: %v", err)
+			return err
+		}
+		return nil
 	})
 	if err != nil {
-		log.Errorf("Error beginning transaction: %v", err)
-		return nil, err
-	}
-	defer tx.Rollback(ctx)
-
-	props, err := s.dao.GetProperties(ctx, tx, id)
-	if err != nil {
-		log.Errorf("Error getting properties: %v", err)
-		return nil, err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
 		return nil, err
 	}
 	return props, nil
 }
 
 func (s *Service) CreateProperty(ctx context.Context, prop *utils.DeviceProperty) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadWrite,
+	ctx, span := tracing.Start(ctx, "properties.CreateProperty")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.dao.CreateProperty(ctx, tx, prop); err != nil {
+			utils.Log(ctx).Errorf("Error creating property: %v", err)
+			return err
+		}
+		return nil
 	})
-	if err != nil {
-		log.Errorf("Error beginning transaction: %v", err)
-		return err
-	}
-	defer tx.Rollback(ctx)
-
-	if err := s.dao.CreateProperty(ctx, tx, prop); err != nil {
-		log.Errorf("Error creating property: %v", err)
-		return err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
-		return err
-	}
-	return nil
 }
 
 func (s *Service) UpdateProperty(ctx context.Context, prop *utils.DeviceProperty) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadWrite,
+	ctx, span := tracing.Start(ctx, "properties.UpdateProperty")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.dao.UpdateProperty(ctx, tx, prop); err != nil {
+			utils.Log(ctx).Errorf("Error updating property: %v", err)
+			return err
+		}
+		return nil
 	})
-	if err != nil {
-		log.Errorf("Error beginning transaction: %v", err)
-		return err
-	}
-	defer tx.Rollback(ctx)
-
-	if err := s.dao.UpdateProperty(ctx, tx, prop); err != nil {
-		log.Errorf("Error updating property: %v", err)
-		return err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
-		return err
-	}
-	return nil
 }
 
 func (s *Service) DeleteProperty(ctx context.Context, id string) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadWrite,
+	ctx, span := tracing.Start(ctx, "properties.DeleteProperty")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.dao.DeleteProperty(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Error deleting property: %v", err)
+			return err
+		}
+		return nil
 	})
-	if err != nil {
-		log.Errorf("Error beginning transaction: %v", err)
-		return err
-	}
-	defer tx.Rollback(ctx)
-
-	if err := s.dao.DeleteProperty(ctx, tx, id); err != nil {
-		log.Errorf("Error deleting property: %v", err)
-		return err
-	}
+}
 
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
-		return err
+func (s *Service) GetPropertiesByDeviceIDs(ctx context.Context, deviceIDs []string) ([]*utils.DeviceProperty, error) {
+	ctx, span := tracing.Start(ctx, "properties.GetPropertiesByDeviceIDs")
+	defer span.End()
+
+	var props []*utils.DeviceProperty
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		props, err = s.dao.GetPropertiesByDeviceIDs(ctx, tx, deviceIDs)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error getting properties: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return props, nil
 }
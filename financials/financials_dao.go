@@ -0,0 +1,65 @@
+package financials
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Dao struct{}
+
+func NewDao() *Dao {
+	return &Dao{}
+}
+
+// asset is a device with a purchase cost, with what is needed to value and
+// group it.
+type asset struct {
+	Value          utils.BookValue
+	TypeName       string
+	DepartmentName *string
+	Schedule       Schedule
+}
+
+// GetAssets returns the devices bought on or before asOf that have a purchase
+// cost and had not been lost by then. A device counts as lost from its
+// lost_at, or always when it is lost without one. A device belongs to the
+// department of its cost center, or of its owner when it has none. Devices
+// without a currency are reported in defaultCurrency.
+func (d *Dao) GetAssets(ctx context.Context, tx pgx.Tx, asOf time.Time, defaultCurrency string) ([]*asset, error) {
+	utils.Log(ctx).Printf("Fetching assets as of %s", asOf.Format(time.DateOnly))
+	query := `SELECT v.id, v.name, v.type_id, t.name, dep.id, dep.name, v.purchase_date, v.purchase_cost, COALESCE(v.currency, $2),
+		t.depreciation_method, t.useful_life_months, t.salvage_percent, t.declining_factor
+	FROM devices v
+	JOIN types t ON t.id = v.type_id
+	JOIN owners o ON o.id = v.owner_id
+	LEFT JOIN departments c ON c.cost_center = v.cost_center
+	LEFT JOIN departments dep ON dep.id = COALESCE(c.id, o.department_id)
+	WHERE v.purchase_cost IS NOT NULL AND v.purchase_date <= $1 AND (v.status <> $3 OR v.lost_at > $1)
+	ORDER BY v.purchase_date, v.id`
+	rows, err := tx.Query(ctx, query, asOf, defaultCurrency, utils.DeviceStatusLost)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching assets: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assets []*asset
+	for rows.Next() {
+		var a asset
+		if err := rows.Scan(&a.Value.DeviceID, &a.Value.Name, &a.Value.TypeID, &a.TypeName, &a.Value.DepartmentID, &a.DepartmentName,
+			&a.Value.PurchaseDate, &a.Value.Cost, &a.Value.Currency,
+			&a.Schedule.Method, &a.Schedule.UsefulLifeMonths, &a.Schedule.SalvagePercent, &a.Schedule.DecliningFactor); err != nil {
+			utils.Log(ctx).Errorf("Error scanning asset row: %v", err)
+			return nil, err
+		}
+		assets = append(assets, &a)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over asset rows: %v", err)
+		return nil, err
+	}
+	return assets, nil
+}
@@ -0,0 +1,129 @@
+package financials
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime"
+	"testing"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/devices/components"
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+var postgresURI string
+
+func init() {
+	viper.SetConfigName("app")
+	viper.AddConfigPath("../config")
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+	postgresURI = viper.GetString("postgres.dev") // Change to "postgres.dev" for development/local db
+	log.SetReportCaller(true)
+	log.SetFormatter(&log.TextFormatter{
+		ForceColors:     true,
+		FullTimestamp:   true,
+		TimestampFormat: "2006/01/02 15:04:05",
+		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
+			filename := path.Base(f.File)
+			return fmt.Sprintf("%s()", f.Function), fmt.Sprintf("\t%s:%d", filename, f.Line)
+		},
+	})
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestGetAssetsLost(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+
+	// Mock Data
+	ownerID, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO owners (id, first_name, last_name, email)
+		VALUES ($1, $2, $3, $4)
+	`, ownerID, "John", "Doe", "john.doe@example.com")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	typeID, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO types (id, name, description) VALUES ($1, $2, $3)
+	`, typeID, "Test Type", "This is a test type")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	now := time.Now()
+	lostAt := now.AddDate(0, 0, -10)
+	insert := func(status string, lostAt *time.Time) string {
+		d_id, _ := gonanoid.New()
+		_, err := tx.Exec(ctx, `
+			INSERT INTO devices (id, serial_number, name, purchase_date, purchase_cost, status, lost_at, owner_id, type_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`, d_id, "SN-"+d_id, "Test Device", "2023-01-01", 1000, status, lostAt, ownerID, typeID)
+		if err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+		return d_id
+	}
+	active := insert(utils.DeviceStatusActive, nil)
+	lostLater := insert(utils.DeviceStatusLost, &lostAt)
+	lostUnknown := insert(utils.DeviceStatusLost, nil)
+
+	assetIDs := func(asOf time.Time) map[string]bool {
+		t.Helper()
+		assets, err := dao.GetAssets(ctx, tx, asOf, "USD")
+		if err != nil {
+			t.Fatalf("Error getting assets: %v", err)
+		}
+		ids := map[string]bool{}
+		for _, a := range assets {
+			ids[a.Value.DeviceID] = true
+		}
+		return ids
+	}
+
+	t.Run("GetAssetsBeforeLost", func(t *testing.T) {
+		ids := assetIDs(now.AddDate(0, 0, -30))
+		if !ids[active] || !ids[lostLater] {
+			t.Errorf("Expected the active device and the one lost later, got %v", ids)
+		}
+		if ids[lostUnknown] {
+			t.Errorf("Expected the device lost at an unknown time to be left out")
+		}
+	})
+	t.Run("GetAssetsAfterLost", func(t *testing.T) {
+		ids := assetIDs(now)
+		if !ids[active] || ids[lostLater] || ids[lostUnknown] {
+			t.Errorf("Expected only the active device, got %v", ids)
+		}
+	})
+	t.Run("SetDeviceStatusLost", func(t *testing.T) {
+		if err := components.NewDao().SetDeviceStatus(ctx, tx, active, utils.DeviceStatusLost); err != nil {
+			t.Fatalf("Error setting device status: %v", err)
+		}
+		if ids := assetIDs(now.AddDate(0, 0, -30)); !ids[active] {
+			t.Errorf("Expected the device to count before it was lost")
+		}
+		if ids := assetIDs(now.Add(time.Hour)); ids[active] {
+			t.Errorf("Expected the device to be left out once lost")
+		}
+	})
+}
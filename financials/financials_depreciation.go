@@ -0,0 +1,70 @@
+package financials
+
+import (
+	"math"
+	"time"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+// defaultDecliningFactor gives double declining balance when a type does not
+// set its own factor.
+const defaultDecliningFactor = 2.0
+
+// Schedule is the depreciation setting of a device's type.
+type Schedule struct {
+	Method           string
+	UsefulLifeMonths *int
+	SalvagePercent   float64
+	DecliningFactor  *float64
+}
+
+// monthsBetween counts the whole months from purchased to asOf, or 0 when
+// asOf is earlier.
+func monthsBetween(purchased, asOf time.Time) int {
+	months := (asOf.Year()-purchased.Year())*12 + int(asOf.Month()) - int(purchased.Month())
+	if asOf.Day() < purchased.Day() {
+		months--
+	}
+	return max(months, 0)
+}
+
+// Depreciate returns the accumulated depreciation of cost at asOf. Devices
+// depreciate monthly and never below their salvage value, which they reach
+// at the end of their useful life.
+func Depreciate(cost float64, schedule Schedule, purchased, asOf time.Time) float64 {
+	if schedule.UsefulLifeMonths == nil || *schedule.UsefulLifeMonths <= 0 {
+		return 0
+	}
+	life := *schedule.UsefulLifeMonths
+	months := monthsBetween(purchased, asOf)
+	salvage := cost * schedule.SalvagePercent / 100
+
+	var book float64
+	switch {
+	case months >= life:
+		book = salvage
+	case schedule.Method == utils.DepreciationStraightLine:
+		book = cost - (cost-salvage)*float64(months)/float64(life)
+	case schedule.Method == utils.DepreciationDecliningBalance:
+		factor := defaultDecliningFactor
+		if schedule.DecliningFactor != nil {
+			factor = *schedule.DecliningFactor
+		}
+		rate := math.Min(factor/float64(life), 1)
+		book = math.Max(cost*math.Pow(1-rate, float64(months)), salvage)
+	default:
+		return 0
+	}
+	return roundCents(cost - book)
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// FiscalYearEnd returns the last day of fiscal year, which ends in endMonth.
+// Fiscal years are named by the calendar year they end in.
+func FiscalYearEnd(year int, endMonth time.Month) time.Time {
+	return time.Date(year, endMonth+1, 0, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,44 @@
+package financials
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+func TestDepreciate(t *testing.T) {
+	life := 60
+	purchased := time.Date(2022, time.January, 15, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name     string
+		schedule Schedule
+		asOf     time.Time
+		want     float64
+	}{
+		{"None", Schedule{Method: utils.DepreciationNone}, time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC), 0},
+		{"BeforePurchase", Schedule{Method: utils.DepreciationStraightLine, UsefulLifeMonths: &life}, time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC), 0},
+		{"PartialMonth", Schedule{Method: utils.DepreciationStraightLine, UsefulLifeMonths: &life}, time.Date(2022, time.February, 14, 0, 0, 0, 0, time.UTC), 0},
+		{"StraightLine", Schedule{Method: utils.DepreciationStraightLine, UsefulLifeMonths: &life}, time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC), 480},
+		{"StraightLineSalvage", Schedule{Method: utils.DepreciationStraightLine, UsefulLifeMonths: &life, SalvagePercent: 10}, time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC), 432},
+		{"StraightLineEnd", Schedule{Method: utils.DepreciationStraightLine, UsefulLifeMonths: &life, SalvagePercent: 10}, time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC), 1080},
+		{"DecliningBalance", Schedule{Method: utils.DepreciationDecliningBalance, UsefulLifeMonths: &life}, time.Date(2023, time.January, 15, 0, 0, 0, 0, time.UTC), 401.08},
+		{"DecliningBalanceEnd", Schedule{Method: utils.DepreciationDecliningBalance, UsefulLifeMonths: &life, SalvagePercent: 5}, time.Date(2027, time.January, 15, 0, 0, 0, 0, time.UTC), 1140},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Depreciate(1200, c.schedule, purchased, c.asOf); got != c.want {
+				t.Errorf("Expected %v, got %v", c.want, got)
+			}
+		})
+	}
+}
+
+func TestFiscalYearEnd(t *testing.T) {
+	if got := FiscalYearEnd(2024, time.June); !got.Equal(time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 2024-06-30, got %s", got.Format(time.DateOnly))
+	}
+	if got := FiscalYearEnd(2024, time.December); !got.Equal(time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 2024-12-31, got %s", got.Format(time.DateOnly))
+	}
+}
@@ -0,0 +1,101 @@
+package financials
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Handler struct {
+	svc *Service
+	// atz *authz.Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{
+		svc: svc,
+		// atz: atz,
+	}
+}
+
+// asOf reads the valuation date from ?as_of=YYYY-MM-DD or, for fiscal year
+// close, ?fiscal_year=YYYY. It defaults to today.
+func (h *Handler) asOf(r *http.Request) (time.Time, error) {
+	query := r.URL.Query()
+	if raw := query.Get("as_of"); raw != "" {
+		asOf, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			return time.Time{}, errors.New("as_of must be a date like 2006-01-02")
+		}
+		return asOf, nil
+	}
+	if raw := query.Get("fiscal_year"); raw != "" {
+		year, err := strconv.Atoi(raw)
+		if err != nil || year < 1 {
+			return time.Time{}, errors.New("fiscal_year must be a year like 2024")
+		}
+		return h.svc.FiscalYearEnd(year), nil
+	}
+	return time.Now().UTC().Truncate(24 * time.Hour), nil
+}
+
+type bookValuesResponse struct {
+	AsOf    string             `json:"as_of"`
+	Devices []*utils.BookValue `json:"devices"`
+}
+
+type totalsResponse struct {
+	AsOf    string                  `json:"as_of"`
+	GroupBy string                  `json:"group_by"`
+	Totals  []*utils.FinancialTotal `json:"totals"`
+}
+
+func (h *Handler) GetBookValues(w http.ResponseWriter, r *http.Request) {
+	asOf, err := h.asOf(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	values, err := h.svc.GetBookValues(r.Context(), asOf)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bookValuesResponse{AsOf: asOf.Format(time.DateOnly), Devices: values})
+}
+
+// GetTotals sums book values per ?group_by=type (the default) or department.
+func (h *Handler) GetTotals(w http.ResponseWriter, r *http.Request) {
+	asOf, err := h.asOf(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	groupBy := r.URL.Query().Get("group_by")
+	if groupBy == "" {
+		groupBy = GroupByType
+	}
+	totals, err := h.svc.GetTotals(r.Context(), asOf, groupBy)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(totalsResponse{AsOf: asOf.Format(time.DateOnly), GroupBy: groupBy, Totals: totals})
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidRequest):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
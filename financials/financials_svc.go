@@ -0,0 +1,141 @@
+package financials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+const (
+	GroupByType       = "type"
+	GroupByDepartment = "department"
+)
+
+// noDepartment names the total of devices that belong to no department.
+const noDepartment = "No department"
+
+var ErrInvalidRequest = errors.New("invalid financial report request")
+
+type Config struct {
+	// Currency is the ISO 4217 code assumed for devices without one.
+	Currency string `mapstructure:"currency"`
+	// FiscalYearEndMonth is the month (1-12) fiscal years end in.
+	FiscalYearEndMonth int `mapstructure:"fiscal-year-end-month"`
+}
+
+type Service struct {
+	dao *Dao
+	pdb *utils.DB
+	cfg Config
+}
+
+func NewService(dao *Dao, pdb *utils.DB, cfg Config) *Service {
+	return &Service{
+		dao: dao,
+		pdb: pdb,
+		cfg: cfg,
+	}
+}
+
+// FiscalYearEnd returns the closing date of the given fiscal year.
+func (s *Service) FiscalYearEnd(year int) time.Time {
+	return FiscalYearEnd(year, time.Month(s.cfg.FiscalYearEndMonth))
+}
+
+func (s *Service) getAssets(ctx context.Context, asOf time.Time) ([]*asset, error) {
+	var assets []*asset
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		assets, err = s.dao.GetAssets(ctx, tx, asOf, s.cfg.Currency)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get assets: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range assets {
+		v := &a.Value
+		v.Method = a.Schedule.Method
+		v.Accumulated = Depreciate(v.Cost, a.Schedule, v.PurchaseDate, asOf)
+		v.BookValue = roundCents(v.Cost - v.Accumulated)
+	}
+	return assets, nil
+}
+
+// GetBookValues values every device with a purchase cost at asOf.
+func (s *Service) GetBookValues(ctx context.Context, asOf time.Time) ([]*utils.BookValue, error) {
+	ctx, span := tracing.Start(ctx, "financials.GetBookValues")
+	defer span.End()
+
+	assets, err := s.getAssets(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]*utils.BookValue, len(assets))
+	for i, a := range assets {
+		values[i] = &a.Value
+	}
+	return values, nil
+}
+
+// GetTotals sums book values at asOf per type or per department, keeping
+// currencies apart.
+func (s *Service) GetTotals(ctx context.Context, asOf time.Time, groupBy string) ([]*utils.FinancialTotal, error) {
+	ctx, span := tracing.Start(ctx, "financials.GetTotals")
+	defer span.End()
+
+	if groupBy != GroupByType && groupBy != GroupByDepartment {
+		return nil, fmt.Errorf("%w: group_by must be %s or %s", ErrInvalidRequest, GroupByType, GroupByDepartment)
+	}
+	assets, err := s.getAssets(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+	return total(assets, groupBy), nil
+}
+
+func total(assets []*asset, groupBy string) []*utils.FinancialTotal {
+	type key struct{ group, currency string }
+	byKey := map[key]*utils.FinancialTotal{}
+	var totals []*utils.FinancialTotal
+	for _, a := range assets {
+		v := &a.Value
+		groupID, name := &v.TypeID, a.TypeName
+		if groupBy == GroupByDepartment {
+			groupID, name = v.DepartmentID, noDepartment
+			if a.DepartmentName != nil {
+				name = *a.DepartmentName
+			}
+		}
+		k := key{currency: v.Currency}
+		if groupID != nil {
+			k.group = *groupID
+		}
+		t, ok := byKey[k]
+		if !ok {
+			t = &utils.FinancialTotal{GroupID: groupID, Name: name, Currency: v.Currency}
+			byKey[k] = t
+			totals = append(totals, t)
+		}
+		t.Devices++
+		t.Cost = roundCents(t.Cost + v.Cost)
+		t.Accumulated = roundCents(t.Accumulated + v.Accumulated)
+		t.BookValue = roundCents(t.BookValue + v.BookValue)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Name != totals[j].Name {
+			return totals[i].Name < totals[j].Name
+		}
+		return totals[i].Currency < totals[j].Currency
+	})
+	return totals
+}
@@ -0,0 +1,57 @@
+package financials
+
+import (
+	"testing"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+func TestTotal(t *testing.T) {
+	physics, chemistry := "physics", "chemistry"
+	physicsName, chemistryName := "Physics", "Chemistry"
+	newAsset := func(typeID string, department *string, departmentName *string, currency string, cost, accumulated float64) *asset {
+		return &asset{
+			Value: utils.BookValue{
+				TypeID:       typeID,
+				DepartmentID: department,
+				Currency:     currency,
+				Cost:         cost,
+				Accumulated:  accumulated,
+				BookValue:    cost - accumulated,
+			},
+			TypeName:       typeID,
+			DepartmentName: departmentName,
+		}
+	}
+	assets := []*asset{
+		newAsset("laptop", &physics, &physicsName, "USD", 1000, 400),
+		newAsset("laptop", &chemistry, &chemistryName, "USD", 1200, 200),
+		newAsset("laptop", &physics, &physicsName, "EUR", 900, 0),
+		newAsset("monitor", nil, nil, "USD", 300, 100),
+	}
+
+	t.Run("ByType", func(t *testing.T) {
+		totals := total(assets, GroupByType)
+		if len(totals) != 3 {
+			t.Fatalf("Expected 3 totals, got %d", len(totals))
+		}
+		if totals[0].Name != "laptop" || totals[0].Currency != "EUR" || totals[0].Devices != 1 {
+			t.Errorf("Expected EUR laptops first, got %+v", totals[0])
+		}
+		if totals[1].Devices != 2 || totals[1].Cost != 2200 || totals[1].BookValue != 1600 {
+			t.Errorf("Expected 2 USD laptops worth 1600, got %+v", totals[1])
+		}
+	})
+	t.Run("ByDepartment", func(t *testing.T) {
+		totals := total(assets, GroupByDepartment)
+		if len(totals) != 4 {
+			t.Fatalf("Expected 4 totals, got %d", len(totals))
+		}
+		if totals[0].Name != chemistryName {
+			t.Errorf("Expected Chemistry first, got %s", totals[0].Name)
+		}
+		if totals[1].Name != noDepartment || totals[1].GroupID != nil {
+			t.Errorf("Expected devices without department second, got %+v", totals[1])
+		}
+	})
+}
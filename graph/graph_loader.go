@@ -0,0 +1,174 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+// loader batches lookups by key within a single GraphQL request. Resolvers
+// call load, which queues the key and returns a thunk. graphql-go evaluates
+// thunks breadth-first, so the first thunk evaluated at a given depth fetches
+// every key queued so far with a single service call.
+type loader[V any] struct {
+	fetch   func(ctx context.Context, keys []string) (map[string]V, error)
+	mu      sync.Mutex
+	pending []string
+	queued  map[string]bool
+	results map[string]V
+	errs    map[string]error
+}
+
+func newLoader[V any](fetch func(ctx context.Context, keys []string) (map[string]V, error)) *loader[V] {
+	return &loader[V]{
+		fetch:   fetch,
+		queued:  map[string]bool{},
+		results: map[string]V{},
+		errs:    map[string]error{},
+	}
+}
+
+func (l *loader[V]) load(ctx context.Context, key string) func() (interface{}, error) {
+	l.mu.Lock()
+	_, loaded := l.results[key]
+	_, failed := l.errs[key]
+	if !loaded && !failed && !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.queued[key] {
+			l.flush(ctx)
+		}
+		if err, ok := l.errs[key]; ok {
+			return nil, err
+		}
+		return l.results[key], nil
+	}
+}
+
+// flush must be called with l.mu held.
+func (l *loader[V]) flush(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+	for _, key := range keys {
+		delete(l.queued, key)
+	}
+
+	results, err := l.fetch(ctx, keys)
+	for _, key := range keys {
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+		// Missing keys are stored too so they are not fetched again.
+		l.results[key] = results[key]
+	}
+}
+
+type loaders struct {
+	owners               *loader[*utils.Owner]
+	types                *loader[*utils.Type]
+	typeProperties       *loader[*utils.TypeProperty]
+	typePropertiesByType *loader[[]*utils.TypeProperty]
+	devicesByOwner       *loader[[]*utils.Device]
+	propertiesByDevice   *loader[[]*utils.DeviceProperty]
+	logsByDevice         *loader[[]*utils.DeviceLog]
+}
+
+func (s *Service) newLoaders() *loaders {
+	return &loaders{
+		owners: newLoader(func(ctx context.Context, ids []string) (map[string]*utils.Owner, error) {
+			owners, err := s.owners.GetOwnersByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := map[string]*utils.Owner{}
+			for _, owner := range owners {
+				byID[owner.ID] = owner
+			}
+			return byID, nil
+		}),
+		types: newLoader(func(ctx context.Context, ids []string) (map[string]*utils.Type, error) {
+			types, err := s.types.GetTypesByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := map[string]*utils.Type{}
+			for _, t := range types {
+				byID[t.ID] = t
+			}
+			return byID, nil
+		}),
+		typeProperties: newLoader(func(ctx context.Context, ids []string) (map[string]*utils.TypeProperty, error) {
+			properties, err := s.typeProperties.GetPropertiesByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := map[string]*utils.TypeProperty{}
+			for _, property := range properties {
+				byID[property.ID] = property
+			}
+			return byID, nil
+		}),
+		typePropertiesByType: newLoader(func(ctx context.Context, typeIDs []string) (map[string][]*utils.TypeProperty, error) {
+			properties, err := s.typeProperties.GetPropertiesByTypeIDs(ctx, typeIDs)
+			if err != nil {
+				return nil, err
+			}
+			byType := map[string][]*utils.TypeProperty{}
+			for _, property := range properties {
+				byType[property.TypeID] = append(byType[property.TypeID], property)
+			}
+			return byType, nil
+		}),
+		devicesByOwner: newLoader(func(ctx context.Context, ownerIDs []string) (map[string][]*utils.Device, error) {
+			devices, err := s.devices.GetDevicesByOwnerIDs(ctx, ownerIDs)
+			if err != nil {
+				return nil, err
+			}
+			byOwner := map[string][]*utils.Device{}
+			for _, device := range devices {
+				byOwner[device.OwnerID] = append(byOwner[device.OwnerID], device)
+			}
+			return byOwner, nil
+		}),
+		propertiesByDevice: newLoader(func(ctx context.Context, deviceIDs []string) (map[string][]*utils.DeviceProperty, error) {
+			properties, err := s.deviceProperties.GetPropertiesByDeviceIDs(ctx, deviceIDs)
+			if err != nil {
+				return nil, err
+			}
+			byDevice := map[string][]*utils.DeviceProperty{}
+			for _, property := range properties {
+				byDevice[property.DeviceID] = append(byDevice[property.DeviceID], property)
+			}
+			return byDevice, nil
+		}),
+		logsByDevice: newLoader(func(ctx context.Context, deviceIDs []string) (map[string][]*utils.DeviceLog, error) {
+			logs, err := s.deviceLogs.GetLogsByDeviceIDs(ctx, deviceIDs)
+			if err != nil {
+				return nil, err
+			}
+			byDevice := map[string][]*utils.DeviceLog{}
+			for _, logEntry := range logs {
+				byDevice[logEntry.DeviceID] = append(byDevice[logEntry.DeviceID], logEntry)
+			}
+			return byDevice, nil
+		}),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestLoader(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("BatchesQueuedKeys", func(t *testing.T) {
+		var calls [][]string
+		l := newLoader(func(ctx context.Context, keys []string) (map[string]string, error) {
+			calls = append(calls, keys)
+			results := map[string]string{}
+			for _, key := range keys {
+				results[key] = "value-" + key
+			}
+			return results, nil
+		})
+
+		a := l.load(ctx, "a")
+		b := l.load(ctx, "b")
+		again := l.load(ctx, "a")
+
+		value, err := b()
+		if err != nil {
+			t.Fatalf("Error loading b: %v", err)
+		}
+		if value != "value-b" {
+			t.Fatalf("Expected value-b, got %v", value)
+		}
+		if _, err := a(); err != nil {
+			t.Fatalf("Error loading a: %v", err)
+		}
+		if _, err := again(); err != nil {
+			t.Fatalf("Error loading a again: %v", err)
+		}
+		if len(calls) != 1 {
+			t.Fatalf("Expected 1 fetch, got %d", len(calls))
+		}
+		if len(calls[0]) != 2 {
+			t.Fatalf("Expected 2 keys in batch, got %v", calls[0])
+		}
+	})
+
+	t.Run("CachesLoadedKeys", func(t *testing.T) {
+		fetches := 0
+		l := newLoader(func(ctx context.Context, keys []string) (map[string]string, error) {
+			fetches++
+			return map[string]string{}, nil
+		})
+
+		if _, err := l.load(ctx, "missing")(); err != nil {
+			t.Fatalf("Error loading missing key: %v", err)
+		}
+		value, err := l.load(ctx, "missing")()
+		if err != nil {
+			t.Fatalf("Error loading missing key: %v", err)
+		}
+		if value != "" {
+			t.Fatalf("Expected zero value, got %v", value)
+		}
+		if fetches != 1 {
+			t.Fatalf("Expected 1 fetch, got %d", fetches)
+		}
+	})
+
+	t.Run("ReturnsFetchError", func(t *testing.T) {
+		l := newLoader(func(ctx context.Context, keys []string) (map[string]string, error) {
+			return nil, errors.New("boom")
+		})
+
+		if _, err := l.load(ctx, "a")(); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,529 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/graphql-go/graphql"
+	"github.com/rickCrz7/Inventory-API/devices"
+	"github.com/rickCrz7/Inventory-API/devices/logs"
+	dev_properties "github.com/rickCrz7/Inventory-API/devices/properties"
+	"github.com/rickCrz7/Inventory-API/owners"
+	"github.com/rickCrz7/Inventory-API/types"
+	"github.com/rickCrz7/Inventory-API/types/properties"
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type Service struct {
+	owners           *owners.Service
+	types            *types.Service
+	typeProperties   *properties.Service
+	devices          *devices.Service
+	deviceProperties *dev_properties.Service
+	deviceLogs       *logs.Service
+	schema           graphql.Schema
+}
+
+func NewService(
+	ownersSvc *owners.Service,
+	typesSvc *types.Service,
+	typePropertiesSvc *properties.Service,
+	devicesSvc *devices.Service,
+	devicePropertiesSvc *dev_properties.Service,
+	deviceLogsSvc *logs.Service,
+) (*Service, error) {
+	s := &Service{
+		owners:           ownersSvc,
+		types:            typesSvc,
+		typeProperties:   typePropertiesSvc,
+		devices:          devicesSvc,
+		deviceProperties: devicePropertiesSvc,
+		deviceLogs:       deviceLogsSvc,
+	}
+	schema, err := s.buildSchema()
+	if err != nil {
+		log.Errorf("Error building GraphQL schema: %v", err)
+		return nil, err
+	}
+	s.schema = schema
+	return s, nil
+}
+
+// Execute runs a GraphQL request with a fresh set of batch loaders, so
+// nested relations are fetched once per level rather than once per parent.
+func (s *Service) Execute(ctx context.Context, query, operationName string, variables map[string]interface{}) *graphql.Result {
+	ctx = withLoaders(ctx, s.newLoaders())
+	result := graphql.Do(graphql.Params{
+		Schema:         s.schema,
+		RequestString:  query,
+		OperationName:  operationName,
+		VariableValues: variables,
+		Context:        ctx,
+	})
+	if result.HasErrors() {
+		utils.Log(ctx).Errorf("GraphQL request returned errors: %v", result.Errors)
+	}
+	return result
+}
+
+func (s *Service) buildSchema() (graphql.Schema, error) {
+	var ownerType, typeType, typePropertyType, deviceType, devicePropertyType, deviceLogType *graphql.Object
+
+	ownerType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Owner",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":            &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"first_name":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"last_name":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"campus_id":     &graphql.Field{Type: graphql.String},
+				"department_id": &graphql.Field{Type: graphql.ID},
+				"email":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"status":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"devices": &graphql.Field{
+					Type: graphql.NewList(deviceType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						owner := p.Source.(*utils.Owner)
+						return loadersFrom(p.Context).devicesByOwner.load(p.Context, owner.ID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	typeType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Type",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":                  &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name":                &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"description":         &graphql.Field{Type: graphql.String},
+				"depreciation_method": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"useful_life_months":  &graphql.Field{Type: graphql.Int},
+				"salvage_percent":     &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+				"declining_factor":    &graphql.Field{Type: graphql.Float},
+				"asset_tag_prefix":    &graphql.Field{Type: graphql.String},
+				"properties": &graphql.Field{
+					Type: graphql.NewList(typePropertyType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						t := p.Source.(*utils.Type)
+						return loadersFrom(p.Context).typePropertiesByType.load(p.Context, t.ID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	typePropertyType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "TypeProperty",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"type_id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"data_type": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"required":  &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+				"type": &graphql.Field{
+					Type: typeType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						property := p.Source.(*utils.TypeProperty)
+						return loadersFrom(p.Context).types.load(p.Context, property.TypeID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	deviceType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Device",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":            &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"serial_number": &graphql.Field{Type: graphql.String},
+				"name":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"type_id":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"owner_id":      &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"location_id":   &graphql.Field{Type: graphql.ID},
+				"cost_center":   &graphql.Field{Type: graphql.String},
+				"purchase_cost": &graphql.Field{Type: graphql.Float},
+				"currency":      &graphql.Field{Type: graphql.String},
+				"vendor":        &graphql.Field{Type: graphql.String},
+				"po_number":     &graphql.Field{Type: graphql.String},
+				"invoice_ref":   &graphql.Field{Type: graphql.String},
+				"asset_tag":     &graphql.Field{Type: graphql.String},
+				"purchase_date": &graphql.Field{Type: graphql.DateTime},
+				"status":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"owner": &graphql.Field{
+					Type: ownerType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						device := p.Source.(*utils.Device)
+						return loadersFrom(p.Context).owners.load(p.Context, device.OwnerID), nil
+					},
+				},
+				"type": &graphql.Field{
+					Type: typeType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						device := p.Source.(*utils.Device)
+						return loadersFrom(p.Context).types.load(p.Context, device.TypeID), nil
+					},
+				},
+				"properties": &graphql.Field{
+					Type: graphql.NewList(devicePropertyType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						device := p.Source.(*utils.Device)
+						return loadersFrom(p.Context).propertiesByDevice.load(p.Context, device.ID), nil
+					},
+				},
+				"logs": &graphql.Field{
+					Type: graphql.NewList(deviceLogType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						device := p.Source.(*utils.Device)
+						return loadersFrom(p.Context).logsByDevice.load(p.Context, device.ID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	devicePropertyType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "DeviceProperty",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":               &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"device_id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"type_property_id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"value":            &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"type_property": &graphql.Field{
+					Type: typePropertyType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						property := p.Source.(*utils.DeviceProperty)
+						return loadersFrom(p.Context).typeProperties.load(p.Context, property.TypePropertyID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	deviceLogType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "DeviceLog",
+		Fields: graphql.Fields{
+			"id":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"device_id":  &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"log_type":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"note":       &graphql.Field{Type: graphql.String},
+			"created_at": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"created_by": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"owner": &graphql.Field{
+				Type: ownerType,
+				Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return s.owners.GetOwner(p.Context, p.Args["id"].(string))
+				},
+			},
+			"owners": &graphql.Field{
+				Type: graphql.NewList(ownerType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return s.owners.GetOwners(p.Context)
+				},
+			},
+			"type": &graphql.Field{
+				Type: typeType,
+				Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return s.types.GetType(p.Context, p.Args["id"].(string))
+				},
+			},
+			"types": &graphql.Field{
+				Type: graphql.NewList(typeType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return s.types.GetTypes(p.Context)
+				},
+			},
+			"device": &graphql.Field{
+				Type: deviceType,
+				Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return s.devices.GetDevice(p.Context, p.Args["id"].(string))
+				},
+			},
+			"devices": &graphql.Field{
+				Type: graphql.NewList(deviceType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return s.devices.GetDevices(p.Context)
+				},
+			},
+		},
+	})
+
+	ownerInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "OwnerInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":            &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"first_name":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"last_name":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"campus_id":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"department_id": &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"email":         &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"status":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	typeInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "TypeInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":                  &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"name":                &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"description":         &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"depreciation_method": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"useful_life_months":  &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"salvage_percent":     &graphql.InputObjectFieldConfig{Type: graphql.Float},
+			"declining_factor":    &graphql.InputObjectFieldConfig{Type: graphql.Float},
+			"asset_tag_prefix":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	typePropertyInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "TypePropertyInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":        &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"type_id":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+			"name":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"data_type": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"required":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+	deviceInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "DeviceInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":            &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"serial_number": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"name":          &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"type_id":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+			"owner_id":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+			"location_id":   &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"cost_center":   &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"purchase_cost": &graphql.InputObjectFieldConfig{Type: graphql.Float},
+			"currency":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"vendor":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"po_number":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"invoice_ref":   &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"purchase_date": &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+			"status":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	devicePropertyInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "DevicePropertyInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":               &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"device_id":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+			"type_property_id": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+			"value":            &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	deviceLogInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "DeviceLogInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":         &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"device_id":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+			"log_type":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"note":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"created_at": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.DateTime)},
+			"created_by": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	idArgs := graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}}
+	inputArgs := func(input *graphql.InputObject) graphql.FieldConfigArgument {
+		return graphql.FieldConfigArgument{"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(input)}}
+	}
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createOwner": &graphql.Field{
+				Type: ownerType,
+				Args: inputArgs(ownerInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var owner utils.Owner
+					if err := decodeInput(p.Args["input"], &owner); err != nil {
+						return nil, err
+					}
+					return &owner, s.owners.CreateOwner(p.Context, &owner)
+				},
+			},
+			"updateOwner": &graphql.Field{
+				Type: ownerType,
+				Args: inputArgs(ownerInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var owner utils.Owner
+					if err := decodeInput(p.Args["input"], &owner); err != nil {
+						return nil, err
+					}
+					return &owner, s.owners.UpdateOwner(p.Context, &owner)
+				},
+			},
+			"deleteOwner": &graphql.Field{
+				Type: graphql.Boolean,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return true, s.owners.DeleteOwner(p.Context, p.Args["id"].(string))
+				},
+			},
+			"createType": &graphql.Field{
+				Type: typeType,
+				Args: inputArgs(typeInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var t utils.Type
+					if err := decodeInput(p.Args["input"], &t); err != nil {
+						return nil, err
+					}
+					return &t, s.types.CreateType(p.Context, &t)
+				},
+			},
+			"updateType": &graphql.Field{
+				Type: typeType,
+				Args: inputArgs(typeInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var t utils.Type
+					if err := decodeInput(p.Args["input"], &t); err != nil {
+						return nil, err
+					}
+					return &t, s.types.UpdateType(p.Context, &t)
+				},
+			},
+			"deleteType": &graphql.Field{
+				Type: graphql.Boolean,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return true, s.types.DeleteType(p.Context, p.Args["id"].(string))
+				},
+			},
+			"createTypeProperty": &graphql.Field{
+				Type: typePropertyType,
+				Args: inputArgs(typePropertyInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var property utils.TypeProperty
+					if err := decodeInput(p.Args["input"], &property); err != nil {
+						return nil, err
+					}
+					return &property, s.typeProperties.CreateProperty(p.Context, &property)
+				},
+			},
+			"updateTypeProperty": &graphql.Field{
+				Type: typePropertyType,
+				Args: inputArgs(typePropertyInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var property utils.TypeProperty
+					if err := decodeInput(p.Args["input"], &property); err != nil {
+						return nil, err
+					}
+					return &property, s.typeProperties.UpdateProperty(p.Context, &property)
+				},
+			},
+			"deleteTypeProperty": &graphql.Field{
+				Type: graphql.Boolean,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return true, s.typeProperties.DeleteProperty(p.Context, p.Args["id"].(string))
+				},
+			},
+			"createDevice": &graphql.Field{
+				Type: deviceType,
+				Args: inputArgs(deviceInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var device utils.Device
+					if err := decodeInput(p.Args["input"], &device); err != nil {
+						return nil, err
+					}
+					return &device, s.devices.CreateDevice(p.Context, &device)
+				},
+			},
+			"updateDevice": &graphql.Field{
+				Type: deviceType,
+				Args: inputArgs(deviceInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var device utils.Device
+					if err := decodeInput(p.Args["input"], &device); err != nil {
+						return nil, err
+					}
+					return &device, s.devices.UpdateDevice(p.Context, &device)
+				},
+			},
+			"deleteDevice": &graphql.Field{
+				Type: graphql.Boolean,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return true, s.devices.DeleteDevice(p.Context, p.Args["id"].(string))
+				},
+			},
+			"createDeviceProperty": &graphql.Field{
+				Type: devicePropertyType,
+				Args: inputArgs(devicePropertyInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var property utils.DeviceProperty
+					if err := decodeInput(p.Args["input"], &property); err != nil {
+						return nil, err
+					}
+					return &property, s.deviceProperties.CreateProperty(p.Context, &property)
+				},
+			},
+			"updateDeviceProperty": &graphql.Field{
+				Type: devicePropertyType,
+				Args: inputArgs(devicePropertyInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var property utils.DeviceProperty
+					if err := decodeInput(p.Args["input"], &property); err != nil {
+						return nil, err
+					}
+					return &property, s.deviceProperties.UpdateProperty(p.Context, &property)
+				},
+			},
+			"deleteDeviceProperty": &graphql.Field{
+				Type: graphql.Boolean,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return true, s.deviceProperties.DeleteProperty(p.Context, p.Args["id"].(string))
+				},
+			},
+			"createDeviceLog": &graphql.Field{
+				Type: deviceLogType,
+				Args: inputArgs(deviceLogInput),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var logEntry utils.DeviceLog
+					if err := decodeInput(p.Args["input"], &logEntry); err != nil {
+						return nil, err
+					}
+					return &logEntry, s.deviceLogs.CreateLog(p.Context, &logEntry)
+				},
+			},
+			"deleteDeviceLog": &graphql.Field{
+				Type: graphql.Boolean,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return true, s.deviceLogs.DeleteLog(p.Context, p.Args["id"].(string))
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
+
+// decodeInput copies a GraphQL input object into one of the utils models.
+// Input field names match the models' JSON tags, so a JSON round trip is
+// enough to map them.
+func decodeInput(input interface{}, v interface{}) error {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
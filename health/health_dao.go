@@ -0,0 +1,76 @@
+package health
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Dao struct{}
+
+func NewDao() *Dao {
+	return &Dao{}
+}
+
+// GetMissingTables returns the tables from tables that do not exist in the
+// current search path.
+func (d *Dao) GetMissingTables(ctx context.Context, tx pgx.Tx, tables []string) ([]string, error) {
+	query := `SELECT t FROM unnest($1::text[]) AS t WHERE to_regclass(t) IS NULL`
+	rows, err := tx.Query(ctx, query, tables)
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not check tables: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var missing []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			utils.Log(ctx).Errorf("Could not scan table name: %v", err)
+			return nil, err
+		}
+		missing = append(missing, table)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error occurred while checking tables: %v", err)
+		return nil, err
+	}
+	return missing, nil
+}
+
+// GetMissingColumns returns the columns, given as table.column, that do not
+// exist. The tables themselves must exist; see GetMissingTables.
+func (d *Dao) GetMissingColumns(ctx context.Context, tx pgx.Tx, columns []string) ([]string, error) {
+	query := `SELECT c FROM unnest($1::text[]) WITH ORDINALITY AS u(c, n)
+	WHERE NOT EXISTS (
+		SELECT 1 FROM pg_attribute
+		WHERE attrelid = to_regclass(split_part(c, '.', 1))
+			AND attname = split_part(c, '.', 2)
+			AND attnum > 0
+			AND NOT attisdropped
+	)
+	ORDER BY n`
+	rows, err := tx.Query(ctx, query, columns)
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not check columns: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var missing []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			utils.Log(ctx).Errorf("Could not scan column name: %v", err)
+			return nil, err
+		}
+		missing = append(missing, column)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error occurred while checking columns: %v", err)
+		return nil, err
+	}
+	return missing, nil
+}
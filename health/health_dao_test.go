@@ -0,0 +1,90 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime"
+	"testing"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/spf13/viper"
+)
+
+var postgresURI string
+
+func init() {
+	viper.SetConfigName("app")
+	viper.AddConfigPath("../config")
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+	postgresURI = viper.GetString("postgres.dev") // Change to "postgres.dev" for development/local db
+	log.SetReportCaller(true)
+	log.SetFormatter(&log.TextFormatter{
+		ForceColors:     true,
+		FullTimestamp:   true,
+		TimestampFormat: "2006/01/02 15:04:05",
+		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
+			filename := path.Base(f.File)
+			return fmt.Sprintf("%s()", f.Function), fmt.Sprintf("\t%s:%d", filename, f.Line)
+		},
+	})
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestGetMissingTables(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+	t.Run("GetMissingTables", func(t *testing.T) {
+		missing, err := dao.GetMissingTables(ctx, tx, requiredTables)
+		if err != nil {
+			t.Fatalf("Error checking tables: %v", err)
+		}
+		if len(missing) != 0 {
+			t.Fatalf("Expected no missing tables, got %v", missing)
+		}
+	})
+	t.Run("GetMissingTablesUnknown", func(t *testing.T) {
+		missing, err := dao.GetMissingTables(ctx, tx, []string{"owners", "no_such_table"})
+		if err != nil {
+			t.Fatalf("Error checking tables: %v", err)
+		}
+		if len(missing) != 1 || missing[0] != "no_such_table" {
+			t.Fatalf("Expected [no_such_table], got %v", missing)
+		}
+	})
+	t.Run("GetMissingColumns", func(t *testing.T) {
+		missing, err := dao.GetMissingColumns(ctx, tx, qualifiedColumns())
+		if err != nil {
+			t.Fatalf("Error checking columns: %v", err)
+		}
+		if len(missing) != 0 {
+			t.Fatalf("Expected no missing columns, got %v", missing)
+		}
+	})
+	t.Run("GetMissingColumnsUnknown", func(t *testing.T) {
+		missing, err := dao.GetMissingColumns(ctx, tx, []string{"devices.asset_tag", "devices.no_such_column"})
+		if err != nil {
+			t.Fatalf("Error checking columns: %v", err)
+		}
+		if len(missing) != 1 || missing[0] != "devices.no_such_column" {
+			t.Fatalf("Expected [devices.no_such_column], got %v", missing)
+		}
+	})
+}
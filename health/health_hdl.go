@@ -0,0 +1,36 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+// Live reports that the process is up. It never touches the database, so a
+// database outage does not get the process restarted.
+func (h *Handler) Live(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(Report{Status: StatusOK})
+}
+
+func (h *Handler) Ready(w http.ResponseWriter, r *http.Request) {
+	report := h.svc.Ready(r.Context())
+	status := http.StatusOK
+	if report.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
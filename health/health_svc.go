@@ -0,0 +1,255 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+	// StatusDegraded is reported for components the API can work without,
+	// such as a lagging replica, and does not fail readiness.
+	StatusDegraded = "degraded"
+)
+
+const checkTimeout = 2 * time.Second
+
+// requiredTables are the tables inventory.sql creates. Readiness fails until
+// all of them exist, so an instance never serves against an unmigrated
+// database.
+var requiredTables = []string{
+	"departments",
+	"owners",
+	"types",
+	"type_properties",
+	"locations",
+	"devices",
+	"asset_tag_sequences",
+	"device_properties",
+	"device_photos",
+	"device_logs",
+	"device_components",
+	"repair_tickets",
+	"repair_comments",
+	"loaners",
+	"reservations",
+	"device_assignments",
+	"recovery_tasks",
+	"vendors",
+	"purchase_orders",
+	"purchase_order_lines",
+	"purchase_order_receipts",
+	"consumables",
+	"consumable_stock",
+	"consumable_movements",
+	"audits",
+	"audit_items",
+	"audit_scans",
+}
+
+// requiredColumns are the columns of each required table that the DAOs
+// read and write. inventory.sql has no upgrade path of its own, so a database
+// created from an older version has the tables but not the columns added
+// since; checking them keeps such a database from passing readiness.
+var requiredColumns = map[string][]string{
+	"departments":             {"id", "parent_id", "name", "cost_center"},
+	"owners":                  {"id", "first_name", "last_name", "campus_id", "email", "status", "department_id"},
+	"types":                   {"id", "name", "description", "depreciation_method", "useful_life_months", "salvage_percent", "declining_factor", "asset_tag_prefix"},
+	"type_properties":         {"id", "type_id", "name", "data_type", "required"},
+	"locations":               {"id", "parent_id", "name", "kind", "description"},
+	"devices":                 {"id", "serial_number", "name", "type_id", "owner_id", "location_id", "cost_center", "purchase_date", "purchase_cost", "currency", "vendor", "po_number", "invoice_ref", "status", "asset_tag"},
+	"asset_tag_sequences":     {"prefix", "last_value"},
+	"device_properties":       {"id", "device_id", "type_property_id", "value"},
+	"device_photos":           {"id", "device_id", "photo", "created_at"},
+	"device_logs":             {"id", "device_id", "log_type", "note", "created_at", "created_by"},
+	"device_components":       {"id", "parent_id", "child_id", "cascade_status", "note", "attached_at", "attached_by", "detached_at", "detached_by"},
+	"repair_tickets":          {"id", "device_id", "title", "description", "status", "assignee", "cost", "currency", "rma_number", "prior_status", "opened_at", "opened_by", "resolved_at", "resolved_by", "resolution"},
+	"repair_comments":         {"id", "ticket_id", "body", "created_at", "created_by"},
+	"loaners":                 {"device_id", "note", "added_at", "added_by"},
+	"reservations":            {"id", "device_id", "type_id", "owner_id", "starts_at", "ends_at", "status", "note", "created_at", "created_by"},
+	"device_assignments":      {"id", "device_id", "owner_id", "reservation_id", "assigned_at", "assigned_by", "due_at", "returned_at", "returned_by"},
+	"recovery_tasks":          {"id", "owner_id", "device_id", "status", "note", "created_at", "created_by", "resolved_at", "resolved_by"},
+	"vendors":                 {"id", "name", "email", "phone", "website", "notes"},
+	"purchase_orders":         {"id", "po_number", "vendor_id", "status", "currency", "note", "created_at", "created_by", "ordered_at"},
+	"purchase_order_lines":    {"id", "purchase_order_id", "type_id", "description", "quantity", "unit_cost", "received"},
+	"purchase_order_receipts": {"id", "purchase_order_id", "line_id", "quantity", "device_ids", "received_at", "received_by", "note"},
+	"consumables":             {"id", "name", "sku", "unit", "description", "reorder_threshold", "reorder_quantity"},
+	"consumable_stock":        {"consumable_id", "location_id", "quantity"},
+	"consumable_movements":    {"id", "consumable_id", "kind", "quantity", "from_location_id", "to_location_id", "owner_id", "note", "created_at", "created_by"},
+	"audits":                  {"id", "name", "location_id", "department_id", "status", "created_at", "created_by", "closed_at", "closed_by"},
+	"audit_items":             {"audit_id", "device_id"},
+	"audit_scans":             {"id", "audit_id", "code", "device_id", "location_id", "scanned_at", "scanned_by"},
+}
+
+type Component struct {
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Latency string `json:"latency,omitempty"`
+	// Lag is how far the replica was behind the primary at its last check.
+	Lag string `json:"lag,omitempty"`
+	// LastBeat is when a background worker last reported in.
+	LastBeat *time.Time `json:"last_beat,omitempty"`
+}
+
+type Report struct {
+	Status       string               `json:"status"`
+	ShuttingDown bool                 `json:"shutting_down"`
+	Components   map[string]Component `json:"components,omitempty"`
+}
+
+// Heartbeat is how a background worker reports that its loop is still
+// running. A worker that has not beaten within MaxAge fails readiness.
+type Heartbeat struct {
+	name   string
+	maxAge time.Duration
+	last   atomic.Int64
+}
+
+func (h *Heartbeat) Beat() {
+	h.last.Store(time.Now().UnixNano())
+}
+
+func (h *Heartbeat) check(now time.Time) Component {
+	last := time.Unix(0, h.last.Load())
+	component := Component{Status: StatusOK, LastBeat: &last}
+	if age := now.Sub(last); age > h.maxAge {
+		component.Status = StatusUnavailable
+		component.Error = fmt.Sprintf("no heartbeat for %s", age.Round(time.Second))
+	}
+	return component
+}
+
+type Service struct {
+	dao *Dao
+	pdb *utils.DB
+
+	mu           sync.Mutex
+	workers      []*Heartbeat
+	shuttingDown atomic.Bool
+}
+
+func NewService(dao *Dao, pdb *utils.DB) *Service {
+	return &Service{
+		dao: dao,
+		pdb: pdb,
+	}
+}
+
+// Worker registers a background worker and returns its heartbeat, already
+// beaten once.
+func (s *Service) Worker(name string, maxAge time.Duration) *Heartbeat {
+	h := &Heartbeat{name: name, maxAge: maxAge}
+	h.Beat()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workers = append(s.workers, h)
+	return h
+}
+
+// ShutDown makes readiness fail from now on so load balancers stop routing
+// here before the server itself stops.
+func (s *Service) ShutDown() {
+	s.shuttingDown.Store(true)
+}
+
+// Ready checks every component. It skips the checks once shutdown has begun.
+func (s *Service) Ready(ctx context.Context) *Report {
+	if s.shuttingDown.Load() {
+		return &Report{Status: StatusUnavailable, ShuttingDown: true}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	report := &Report{Status: StatusOK, Components: map[string]Component{}}
+	report.Components["database"] = s.checkDatabase(ctx)
+	if report.Components["database"].Status == StatusOK {
+		report.Components["schema"] = s.checkSchema(ctx)
+	}
+	if replica, ok := s.checkReplica(); ok {
+		report.Components["replica"] = replica
+	}
+	now := time.Now()
+	s.mu.Lock()
+	for _, worker := range s.workers {
+		report.Components["worker:"+worker.name] = worker.check(now)
+	}
+	s.mu.Unlock()
+
+	for _, component := range report.Components {
+		if component.Status == StatusUnavailable {
+			report.Status = StatusUnavailable
+		}
+	}
+	return report
+}
+
+func (s *Service) checkDatabase(ctx context.Context) Component {
+	start := time.Now()
+	if err := s.pdb.Ping(ctx); err != nil {
+		utils.Log(ctx).Errorf("Readiness database ping failed: %v", err)
+		return Component{Status: StatusUnavailable, Error: err.Error()}
+	}
+	return Component{Status: StatusOK, Latency: time.Since(start).String()}
+}
+
+// checkReplica reports the replica from the last routing check rather than
+// querying it, so readiness does not depend on it.
+func (s *Service) checkReplica() (Component, bool) {
+	configured, healthy, lag, err := s.pdb.ReplicaStatus()
+	if !configured {
+		return Component{}, false
+	}
+	component := Component{Status: StatusOK, Lag: lag.String()}
+	if !healthy {
+		component.Status = StatusDegraded
+		component.Error = "reading from primary"
+		if err != nil {
+			component.Error += ": " + err.Error()
+		}
+	}
+	return component, true
+}
+
+func (s *Service) checkSchema(ctx context.Context) Component {
+	var missingTables, missingColumns []string
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		missingTables, err = s.dao.GetMissingTables(ctx, tx, requiredTables)
+		if err != nil || len(missingTables) > 0 {
+			return err
+		}
+		missingColumns, err = s.dao.GetMissingColumns(ctx, tx, qualifiedColumns())
+		return err
+	})
+	if err != nil {
+		return Component{Status: StatusUnavailable, Error: err.Error()}
+	}
+	if len(missingTables) > 0 {
+		return Component{Status: StatusUnavailable, Error: "missing tables: " + strings.Join(missingTables, ", ")}
+	}
+	if len(missingColumns) > 0 {
+		return Component{Status: StatusUnavailable, Error: "missing columns: " + strings.Join(missingColumns, ", ")}
+	}
+	return Component{Status: StatusOK}
+}
+
+// qualifiedColumns lists requiredColumns as table.column in requiredTables
+// order.
+func qualifiedColumns() []string {
+	var columns []string
+	for _, table := range requiredTables {
+		for _, column := range requiredColumns[table] {
+			columns = append(columns, table+"."+column)
+		}
+	}
+	return columns
+}
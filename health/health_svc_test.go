@@ -0,0 +1,52 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHeartbeat(t *testing.T) {
+	h := &Heartbeat{name: "test", maxAge: time.Minute}
+	h.Beat()
+
+	if got := h.check(time.Now()); got.Status != StatusOK {
+		t.Fatalf("Expected fresh heartbeat to be %q, got %+v", StatusOK, got)
+	}
+	if got := h.check(time.Now().Add(2 * time.Minute)); got.Status != StatusUnavailable {
+		t.Fatalf("Expected stale heartbeat to be %q, got %+v", StatusUnavailable, got)
+	}
+}
+
+func TestReadyShuttingDown(t *testing.T) {
+	svc := NewService(NewDao(), nil)
+	svc.ShutDown()
+
+	report := svc.Ready(context.Background())
+	if report.Status != StatusUnavailable || !report.ShuttingDown {
+		t.Fatalf("Expected unavailable shutting down report, got %+v", report)
+	}
+
+	w := httptest.NewRecorder()
+	NewHandler(svc).Ready(w, httptest.NewRequest("GET", "/readyz", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
+
+func TestRequiredColumns(t *testing.T) {
+	for _, table := range requiredTables {
+		if len(requiredColumns[table]) == 0 {
+			t.Errorf("Expected required columns for table %s", table)
+		}
+	}
+	if len(requiredColumns) != len(requiredTables) {
+		t.Errorf("Expected columns for %d tables, got %d", len(requiredTables), len(requiredColumns))
+	}
+	columns := qualifiedColumns()
+	if columns[0] != "departments.id" {
+		t.Errorf("Expected departments.id first, got %s", columns[0])
+	}
+}
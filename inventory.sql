@@ -1,15 +1,40 @@
+-- btree_gist lets reservations combine device equality with range overlap in
+-- one exclusion constraint.
+create extension if not exists btree_gist;
+
+create table departments (
+    id varchar(50) primary key,
+    parent_id varchar(50),
+    name varchar(100) not null,
+    cost_center varchar(20) not null unique,
+    foreign key (parent_id) references departments(id)
+);
+
+create index idx_departments_parent_id on departments(parent_id);
+
 create table owners (
     id varchar(50) primary key,
     first_name varchar(50) not null,
     last_name varchar(50) not null,
     campus_id varchar(50),
-    email varchar(50) not null
+    email varchar(50) not null,
+    status varchar(20) not null default 'active',
+    department_id varchar(50),
+    foreign key (department_id) references departments(id)
 );
 
+create unique index idx_owners_campus_id on owners(campus_id) where campus_id is not null;
+create index idx_owners_department_id on owners(department_id);
+
 create table types (
     id varchar(50) primary key,
     name varchar(50) not null,
-    description text
+    description text,
+    depreciation_method varchar(20) not null default 'none',
+    useful_life_months int,
+    salvage_percent numeric(5, 2) not null default 0,
+    declining_factor numeric(4, 2),
+    asset_tag_prefix varchar(8)
 );
 
 create table type_properties (
@@ -23,20 +48,144 @@ create table type_properties (
 
 create index idx_type_properties_type_id on type_properties(type_id);
 
+create table locations (
+    id varchar(50) primary key,
+    parent_id varchar(50),
+    name varchar(100) not null,
+    kind varchar(20) not null,
+    description text,
+    foreign key (parent_id) references locations(id)
+);
+
+create index idx_locations_parent_id on locations(parent_id);
+
 create table devices (
     id varchar(50) primary key,
     serial_number varchar(50),
     name varchar(50) not null,
     type_id varchar(50) not null,
     owner_id varchar(50) not null,
+    location_id varchar(50),
+    cost_center varchar(20),
     purchase_date date,
+    purchase_cost numeric(12, 2),
+    currency char(3),
+    vendor varchar(100),
+    po_number varchar(50),
+    invoice_ref varchar(100),
     status varchar(50) not null,
+    asset_tag varchar(20) unique,
+    lost_at timestamp,
     foreign key (type_id) references types(id),
-    foreign key (owner_id) references owners(id) on delete cascade
+    foreign key (owner_id) references owners(id) on delete cascade,
+    foreign key (location_id) references locations(id),
+    foreign key (cost_center) references departments(cost_center) on update cascade
 );
 
 create index idx_devices_type_id on devices(type_id);
 create index idx_devices_owner_id on devices(owner_id);
+create index idx_devices_location_id on devices(location_id);
+create index idx_devices_cost_center on devices(cost_center);
+
+-- asset_tag_sequences holds the last asset tag number handed out per prefix.
+create table asset_tag_sequences (
+    prefix varchar(8) primary key,
+    last_value int not null
+);
+
+create table vendors (
+    id varchar(50) primary key,
+    name varchar(100) not null unique,
+    email varchar(100),
+    phone varchar(50),
+    website varchar(200),
+    notes text
+);
+
+create table purchase_orders (
+    id varchar(50) primary key,
+    po_number varchar(50) not null unique,
+    vendor_id varchar(50) not null,
+    status varchar(20) not null default 'draft',
+    currency char(3) not null,
+    note text not null default '',
+    created_at timestamp not null,
+    created_by varchar(50) not null,
+    ordered_at timestamp,
+    foreign key (vendor_id) references vendors(id)
+);
+
+create index idx_purchase_orders_vendor_id on purchase_orders(vendor_id);
+
+create table purchase_order_lines (
+    id varchar(50) primary key,
+    purchase_order_id varchar(50) not null,
+    type_id varchar(50) not null,
+    description text not null default '',
+    quantity int not null check (quantity > 0),
+    unit_cost numeric(12, 2) not null,
+    received int not null default 0 check (received between 0 and quantity),
+    foreign key (purchase_order_id) references purchase_orders(id) on delete cascade,
+    foreign key (type_id) references types(id)
+);
+
+create index idx_purchase_order_lines_purchase_order_id on purchase_order_lines(purchase_order_id);
+
+create table purchase_order_receipts (
+    id varchar(50) primary key,
+    purchase_order_id varchar(50) not null,
+    line_id varchar(50) not null,
+    quantity int not null,
+    device_ids varchar(50)[] not null,
+    received_at timestamp not null,
+    received_by varchar(50) not null,
+    note text not null default '',
+    foreign key (purchase_order_id) references purchase_orders(id) on delete cascade,
+    foreign key (line_id) references purchase_order_lines(id) on delete cascade
+);
+
+create index idx_purchase_order_receipts_purchase_order_id on purchase_order_receipts(purchase_order_id);
+
+create table consumables (
+    id varchar(50) primary key,
+    name varchar(100) not null unique,
+    sku varchar(50) unique,
+    unit varchar(20) not null default 'each',
+    description text,
+    reorder_threshold int not null default 0 check (reorder_threshold >= 0),
+    reorder_quantity int not null default 0 check (reorder_quantity >= 0)
+);
+
+create table consumable_stock (
+    consumable_id varchar(50) not null,
+    location_id varchar(50) not null,
+    quantity int not null check (quantity >= 0),
+    primary key (consumable_id, location_id),
+    foreign key (consumable_id) references consumables(id),
+    foreign key (location_id) references locations(id)
+);
+
+create index idx_consumable_stock_location_id on consumable_stock(location_id);
+
+create table consumable_movements (
+    id varchar(50) primary key,
+    consumable_id varchar(50) not null,
+    kind varchar(20) not null,
+    quantity int not null check (quantity > 0),
+    from_location_id varchar(50),
+    to_location_id varchar(50),
+    owner_id varchar(50),
+    note text not null default '',
+    created_at timestamp not null,
+    created_by varchar(50) not null,
+    check (from_location_id is not null or to_location_id is not null),
+    foreign key (consumable_id) references consumables(id),
+    foreign key (from_location_id) references locations(id),
+    foreign key (to_location_id) references locations(id),
+    foreign key (owner_id) references owners(id) on delete set null
+);
+
+create index idx_consumable_movements_consumable_id on consumable_movements(consumable_id, created_at);
 
 create table device_properties (
     id varchar(50) primary key,
@@ -72,37 +221,187 @@ create table device_logs (
 
 create index idx_device_logs_device_id on device_logs(device_id);
 
--- create table device_assignments (
---     id varchar(50) primary key,
---     device_id varchar(50) not null,
---     owner_id varchar(50) not null,
---     assigned_at timestamp not null,
---     returned_at timestamp,
---     foreign key (device_id) references devices(id) on delete cascade,
---     foreign key (owner_id) references owners(id) on delete cascade
--- );
-
--- create index idx_device_assignments_device_id on device_assignments(device_id);
--- create index idx_device_assignments_owner_id on device_assignments(owner_id);
-
--- create table device_assignment_history (
---     id varchar(50) primary key,
---     device_assignment_id varchar(50) not null,
---     status varchar(50) not null,
---     changed_at timestamp not null,
---     changed_by varchar(50) not null,
---     foreign key (device_assignment_id) references device_assignments(id) on delete cascade
--- );
-
--- create index idx_device_assignment_history_device_assignment_id on device_assignment_history(device_assignment_id);
-
--- drop table if exists device_assignment_history;
--- drop table if exists device_assignments;
+create table repair_tickets (
+    id varchar(50) primary key,
+    device_id varchar(50) not null,
+    title varchar(200) not null,
+    description text not null default '',
+    status varchar(20) not null default 'open',
+    assignee varchar(50),
+    cost numeric(12, 2),
+    currency char(3) not null,
+    rma_number varchar(50),
+    prior_status varchar(50) not null,
+    opened_at timestamp not null,
+    opened_by varchar(50) not null,
+    resolved_at timestamp,
+    resolved_by varchar(50),
+    resolution text,
+    foreign key (device_id) references devices(id) on delete cascade
+);
+
+create index idx_repair_tickets_device_id on repair_tickets(device_id);
+create unique index idx_repair_tickets_unresolved_device_id on repair_tickets(device_id) where status <> 'resolved';
+
+create table repair_comments (
+    id varchar(50) primary key,
+    ticket_id varchar(50) not null,
+    body text not null,
+    created_at timestamp not null,
+    created_by varchar(50) not null,
+    foreign key (ticket_id) references repair_tickets(id) on delete cascade
+);
+
+create index idx_repair_comments_ticket_id on repair_comments(ticket_id);
+
+create table loaners (
+    device_id varchar(50) primary key,
+    note text not null default '',
+    added_at timestamp not null,
+    added_by varchar(50) not null,
+    foreign key (device_id) references devices(id) on delete cascade
+);
+
+create table reservations (
+    id varchar(50) primary key,
+    device_id varchar(50) not null,
+    type_id varchar(50) not null,
+    owner_id varchar(50) not null,
+    starts_at timestamp not null,
+    ends_at timestamp not null,
+    status varchar(20) not null default 'reserved',
+    note text not null default '',
+    created_at timestamp not null,
+    created_by varchar(50) not null,
+    check (ends_at > starts_at),
+    exclude using gist (device_id with =, tsrange(starts_at, ends_at) with &&)
+        where (status in ('reserved', 'checked_out')),
+    foreign key (device_id) references devices(id) on delete cascade,
+    foreign key (type_id) references types(id),
+    foreign key (owner_id) references owners(id) on delete cascade
+);
+
+create index idx_reservations_owner_id on reservations(owner_id);
+create index idx_reservations_type_id on reservations(type_id);
+
+create table device_assignments (
+    id varchar(50) primary key,
+    device_id varchar(50) not null,
+    owner_id varchar(50) not null,
+    reservation_id varchar(50),
+    assigned_at timestamp not null,
+    assigned_by varchar(50) not null,
+    due_at timestamp,
+    returned_at timestamp,
+    returned_by varchar(50),
+    foreign key (device_id) references devices(id) on delete cascade,
+    foreign key (owner_id) references owners(id) on delete cascade,
+    foreign key (reservation_id) references reservations(id) on delete set null
+);
+
+create index idx_device_assignments_device_id on device_assignments(device_id);
+create index idx_device_assignments_owner_id on device_assignments(owner_id);
+create unique index idx_device_assignments_open_device_id on device_assignments(device_id) where returned_at is null;
+
+create table device_components (
+    id varchar(50) primary key,
+    parent_id varchar(50) not null,
+    child_id varchar(50) not null,
+    cascade_status boolean not null default false,
+    note text not null default '',
+    attached_at timestamp not null,
+    attached_by varchar(50) not null,
+    detached_at timestamp,
+    detached_by varchar(50),
+    check (parent_id <> child_id),
+    foreign key (parent_id) references devices(id) on delete cascade,
+    foreign key (child_id) references devices(id) on delete cascade
+);
+
+create index idx_device_components_parent_id on device_components(parent_id);
+create index idx_device_components_child_id on device_components(child_id);
+create unique index idx_device_components_attached_child_id on device_components(child_id) where detached_at is null;
+
+create table recovery_tasks (
+    id varchar(50) primary key,
+    owner_id varchar(50) not null,
+    device_id varchar(50) not null,
+    status varchar(20) not null default 'open',
+    note text not null default '',
+    created_at timestamp not null,
+    created_by varchar(50) not null,
+    resolved_at timestamp,
+    resolved_by varchar(50),
+    foreign key (owner_id) references owners(id) on delete cascade,
+    foreign key (device_id) references devices(id) on delete cascade
+);
+
+create index idx_recovery_tasks_owner_id on recovery_tasks(owner_id);
+create unique index idx_recovery_tasks_open_device_id on recovery_tasks(device_id) where status = 'open';
+
+create table audits (
+    id varchar(50) primary key,
+    name varchar(100) not null,
+    location_id varchar(50),
+    department_id varchar(50),
+    status varchar(20) not null default 'open',
+    created_at timestamp not null,
+    created_by varchar(50) not null,
+    closed_at timestamp,
+    closed_by varchar(50),
+    check ((location_id is null) <> (department_id is null)),
+    foreign key (location_id) references locations(id),
+    foreign key (department_id) references departments(id)
+);
+
+create table audit_items (
+    audit_id varchar(50) not null,
+    device_id varchar(50) not null,
+    primary key (audit_id, device_id),
+    foreign key (audit_id) references audits(id) on delete cascade,
+    foreign key (device_id) references devices(id) on delete cascade
+);
+
+create table audit_scans (
+    id varchar(50) primary key,
+    audit_id varchar(50) not null,
+    code varchar(100) not null,
+    device_id varchar(50),
+    location_id varchar(50),
+    scanned_at timestamp not null,
+    scanned_by varchar(50) not null,
+    foreign key (audit_id) references audits(id) on delete cascade,
+    foreign key (device_id) references devices(id) on delete set null,
+    foreign key (location_id) references locations(id)
+);
+
+create index idx_audit_scans_audit_id on audit_scans(audit_id, device_id);
+
 
+drop table if exists audit_scans;
+drop table if exists audit_items;
+drop table if exists audits;
+drop table if exists consumable_movements;
+drop table if exists consumable_stock;
+drop table if exists consumables;
+drop table if exists purchase_order_receipts;
+drop table if exists purchase_order_lines;
+drop table if exists purchase_orders;
+drop table if exists vendors;
+drop table if exists recovery_tasks;
+drop table if exists device_components;
+drop table if exists device_assignments;
+drop table if exists reservations;
+drop table if exists loaners;
+drop table if exists repair_comments;
+drop table if exists repair_tickets;
 drop table if exists device_logs;
 drop table if exists device_photos;
 drop table if exists device_properties;
 drop table if exists devices;
+drop table if exists asset_tag_sequences;
+drop table if exists locations;
 drop table if exists type_properties;
 drop table if exists types;
-drop table if exists owners;
\ No newline at end of file
+drop table if exists owners;
+drop table if exists departments;
\ No newline at end of file
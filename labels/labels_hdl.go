@@ -0,0 +1,83 @@
+package labels
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Handler struct {
+	svc *Service
+	// atz *authz.Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{
+		svc: svc,
+		// atz: atz,
+	}
+}
+
+// Batch is the body of a batch label request.
+type Batch struct {
+	DeviceIDs []string `json:"device_ids"`
+}
+
+// GetDeviceLabel renders one device's label, as ?format=png (the default) or
+// ?format=pdf.
+func (h *Handler) GetDeviceLabel(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = FormatPNG
+	}
+	body, contentType, err := h.svc.Render(r.Context(), []string{id}, format)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeFile(w, body, contentType, fmt.Sprintf("label-%s.%s", id, format))
+}
+
+// RenderBatch renders labels for every device in the body, as ?format=pdf
+// (the default) Letter sheets or ?format=png.
+func (h *Handler) RenderBatch(w http.ResponseWriter, r *http.Request) {
+	var batch Batch
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = FormatPDF
+	}
+	body, contentType, err := h.svc.Render(r.Context(), batch.DeviceIDs, format)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeFile(w, body, contentType, "labels."+format)
+}
+
+func writeFile(w http.ResponseWriter, body []byte, contentType string, name string) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", name))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrInvalidRequest):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
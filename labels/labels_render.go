@@ -0,0 +1,365 @@
+package labels
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"sync"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+	"github.com/jung-kurt/gofpdf"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Label sizes are in points (1/72 inch). A label is 2.25 x 1.25 inches, a
+// common thermal label size, and sheets are US Letter with 3 x 8 labels.
+const (
+	labelWidth  = 162
+	labelHeight = 90
+
+	sheetWidth   = 612
+	sheetHeight  = 792
+	sheetColumns = 3
+	sheetRows    = 8
+	sheetLeft    = 45
+	sheetTop     = 36
+	sheetGap     = 18
+
+	// pngScale is the PNG resolution in pixels per point, 288 dpi.
+	pngScale = 4
+	// pngGap separates labels in a batch PNG.
+	pngGap = 6
+)
+
+// Layout of a label, in points from its top-left corner.
+const (
+	qrLeft = 6
+	qrTop  = 6
+	qrSize = 46
+
+	textLeft  = 58
+	textRight = 6
+
+	barcodeTop    = 58
+	barcodeHeight = 24
+	// quietZone is the blank space on each side of the barcode, in modules.
+	quietZone = 10
+)
+
+// label is what gets printed for one device.
+type label struct {
+	// Tag is printed in bold and encoded in the Code 128 barcode.
+	Tag    string
+	Name   string
+	Serial string
+	// QR is encoded in the QR code, a link to the device page. The QR code is
+	// left out when it is empty.
+	QR string
+}
+
+// canvas is a drawing surface in points from the top-left corner.
+type canvas interface {
+	// rect fills a black rectangle.
+	rect(x float64, y float64, w float64, h float64)
+	// text draws s with its top-left corner at x, y.
+	text(x float64, y float64, size float64, bold bool, s string)
+	// width is how wide s is when drawn with text.
+	width(size float64, bold bool, s string) float64
+	// unit is the device pixel size in points, or 0 for vector output.
+	unit() float64
+}
+
+// drawLabel draws l with its top-left corner at x, y.
+func drawLabel(c canvas, x float64, y float64, l label) error {
+	if l.QR != "" {
+		code, err := qr.Encode(l.QR, qr.M, qr.Auto)
+		if err != nil {
+			return fmt.Errorf("qr code for %s: %w", l.Tag, err)
+		}
+		matrix := modules(code)
+		module := qrModule(c, len(matrix))
+		for row := range matrix {
+			drawRuns(c, matrix[row], x+qrLeft, y+qrTop+float64(row)*module, module, module)
+		}
+	}
+
+	left, width := x+textLeft, float64(labelWidth-textLeft-textRight)
+	c.text(left, y+8, 12, true, fit(c, l.Tag, 12, true, width))
+	c.text(left, y+26, 8, false, fit(c, l.Name, 8, false, width))
+	if l.Serial != "" {
+		c.text(left, y+39, 7, false, fit(c, "S/N "+l.Serial, 7, false, width))
+	}
+
+	code, err := code128.Encode(l.Tag)
+	if err != nil {
+		return fmt.Errorf("barcode for %s: %w", l.Tag, err)
+	}
+	bars := modules(code)[0]
+	start, module := barcodeLayout(c, len(bars))
+	drawRuns(c, bars, x+start, y+barcodeTop, module, barcodeHeight)
+	return nil
+}
+
+// modules reads the dark modules of b row by row.
+func modules(b barcode.Barcode) [][]bool {
+	bounds := b.Bounds()
+	rows := make([][]bool, bounds.Dy())
+	for y := range rows {
+		rows[y] = make([]bool, bounds.Dx())
+		for x := range rows[y] {
+			gray := color.GrayModel.Convert(b.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray)
+			rows[y][x] = gray.Y < 0x80
+		}
+	}
+	return rows
+}
+
+// qrModule is the module size of an n x n QR code.
+func qrModule(c canvas, n int) float64 {
+	return snap(c, qrSize/float64(n))
+}
+
+// barcodeLayout centres n barcode modules and their quiet zones across the
+// label, returning the left edge of the first module and the module size.
+func barcodeLayout(c canvas, n int) (float64, float64) {
+	module := snap(c, labelWidth/float64(n+2*quietZone))
+	return snap(c, (labelWidth-module*float64(n))/2), module
+}
+
+// drawRuns draws each run of dark modules as one rectangle so neighbouring
+// modules do not leave hairline gaps.
+func drawRuns(c canvas, modules []bool, x float64, y float64, module float64, height float64) {
+	for i := 0; i < len(modules); {
+		if !modules[i] {
+			i++
+			continue
+		}
+		j := i
+		for j < len(modules) && modules[j] {
+			j++
+		}
+		c.rect(x+float64(i)*module, y, float64(j-i)*module, height)
+		i = j
+	}
+}
+
+// snap rounds v down to whole device pixels, keeping at least one.
+func snap(c canvas, v float64) float64 {
+	u := c.unit()
+	if u == 0 {
+		return v
+	}
+	return math.Max(math.Floor(v/u), 1) * u
+}
+
+// fit truncates s with "..." so it is at most width points wide on c.
+func fit(c canvas, s string, size float64, bold bool, width float64) string {
+	if c.width(size, bold, s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for n := len(runes) - 1; n > 0; n-- {
+		if t := string(runes[:n]) + "..."; c.width(size, bold, t) <= width {
+			return t
+		}
+	}
+	return ""
+}
+
+// pngFonts are the Go fonts, parsed once.
+var pngFonts = sync.OnceValues(func() ([2]*opentype.Font, error) {
+	var fonts [2]*opentype.Font
+	for i, ttf := range [][]byte{goregular.TTF, gobold.TTF} {
+		f, err := opentype.Parse(ttf)
+		if err != nil {
+			return fonts, err
+		}
+		fonts[i] = f
+	}
+	return fonts, nil
+})
+
+type faceKey struct {
+	size float64
+	bold bool
+}
+
+type pngCanvas struct {
+	img   *image.Gray
+	fonts [2]*opentype.Font
+	faces map[faceKey]font.Face
+	err   error
+}
+
+func newPNGCanvas(width float64, height float64) (*pngCanvas, error) {
+	fonts, err := pngFonts()
+	if err != nil {
+		return nil, err
+	}
+	img := image.NewGray(image.Rect(0, 0, int(width*pngScale), int(height*pngScale)))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	return &pngCanvas{img: img, fonts: fonts, faces: map[faceKey]font.Face{}}, nil
+}
+
+func (p *pngCanvas) rect(x float64, y float64, w float64, h float64) {
+	x0, y0 := int(math.Round(x*pngScale)), int(math.Round(y*pngScale))
+	x1, y1 := int(math.Round((x+w)*pngScale)), int(math.Round((y+h)*pngScale))
+	for py := y0; py < y1; py++ {
+		for px := x0; px < x1; px++ {
+			p.img.SetGray(px, py, color.Gray{})
+		}
+	}
+}
+
+// face returns the Go font face for size points, keeping the first error
+// for renderPNG to report.
+func (p *pngCanvas) face(size float64, bold bool) font.Face {
+	key := faceKey{size: size, bold: bold}
+	if face, ok := p.faces[key]; ok {
+		return face
+	}
+	f := p.fonts[0]
+	if bold {
+		f = p.fonts[1]
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size * pngScale, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		if p.err == nil {
+			p.err = err
+		}
+		return nil
+	}
+	p.faces[key] = face
+	return face
+}
+
+func (p *pngCanvas) text(x float64, y float64, size float64, bold bool, s string) {
+	face := p.face(size, bold)
+	if face == nil {
+		return
+	}
+	// The baseline sits one cap height below the top of the text.
+	d := font.Drawer{Dst: p.img, Src: image.Black, Face: face}
+	d.Dot = fixed.Point26_6{
+		X: fixed.Int26_6(math.Round(x * pngScale * 64)),
+		Y: fixed.Int26_6(math.Round(y*pngScale*64)) + face.Metrics().CapHeight,
+	}
+	d.DrawString(s)
+}
+
+func (p *pngCanvas) width(size float64, bold bool, s string) float64 {
+	face := p.face(size, bold)
+	if face == nil {
+		return 0
+	}
+	return float64(font.MeasureString(face, s)) / 64 / pngScale
+}
+
+func (p *pngCanvas) unit() float64 {
+	return 1.0 / pngScale
+}
+
+// renderPNG draws labels in a grid of sheetColumns columns.
+func renderPNG(w io.Writer, labels []label) error {
+	columns := min(len(labels), sheetColumns)
+	rows := (len(labels) + sheetColumns - 1) / sheetColumns
+	p, err := newPNGCanvas(
+		float64(columns*labelWidth+(columns-1)*pngGap),
+		float64(rows*labelHeight+(rows-1)*pngGap),
+	)
+	if err != nil {
+		return err
+	}
+	for i, l := range labels {
+		x := float64(i%sheetColumns) * (labelWidth + pngGap)
+		y := float64(i/sheetColumns) * (labelHeight + pngGap)
+		if err := drawLabel(p, x, y, l); err != nil {
+			return err
+		}
+	}
+	if p.err != nil {
+		return p.err
+	}
+	return png.Encode(w, p.img)
+}
+
+// pdfCanvas draws with the standard Helvetica fonts, so text is limited to
+// the Windows-1252 characters; anything else is printed as '.'.
+type pdfCanvas struct {
+	pdf       *gofpdf.Fpdf
+	translate func(string) string
+}
+
+func newPDFCanvas(width float64, height float64) *pdfCanvas {
+	pdf := gofpdf.NewCustom(&gofpdf.InitType{UnitStr: "pt", Size: gofpdf.SizeType{Wd: width, Ht: height}})
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	return &pdfCanvas{pdf: pdf, translate: pdf.UnicodeTranslatorFromDescriptor("")}
+}
+
+func (p *pdfCanvas) rect(x float64, y float64, w float64, h float64) {
+	p.pdf.Rect(x, y, w, h, "F")
+}
+
+func (p *pdfCanvas) setFont(size float64, bold bool) {
+	style := ""
+	if bold {
+		style = "B"
+	}
+	p.pdf.SetFont("Helvetica", style, size)
+}
+
+func (p *pdfCanvas) text(x float64, y float64, size float64, bold bool, s string) {
+	p.setFont(size, bold)
+	// Helvetica capitals are about 0.72 em tall, so the baseline sits that
+	// far below the top of the text.
+	p.pdf.Text(x, y+0.72*size, p.translate(s))
+}
+
+func (p *pdfCanvas) width(size float64, bold bool, s string) float64 {
+	p.setFont(size, bold)
+	return p.pdf.GetStringWidth(p.translate(s))
+}
+
+func (p *pdfCanvas) unit() float64 {
+	return 0
+}
+
+// renderPDF puts a single label on a page of its own size and anything more
+// on Letter sheets.
+func renderPDF(w io.Writer, labels []label) error {
+	if len(labels) == 1 {
+		p := newPDFCanvas(labelWidth, labelHeight)
+		p.pdf.AddPage()
+		if err := drawLabel(p, 0, 0, labels[0]); err != nil {
+			return err
+		}
+		return p.pdf.Output(w)
+	}
+
+	p := newPDFCanvas(sheetWidth, sheetHeight)
+	perSheet := sheetColumns * sheetRows
+	for i, l := range labels {
+		if i%perSheet == 0 {
+			p.pdf.AddPage()
+		}
+		slot := i % perSheet
+		x := float64(sheetLeft + (slot%sheetColumns)*(labelWidth+sheetGap))
+		y := float64(sheetTop + (slot/sheetColumns)*labelHeight)
+		if err := drawLabel(p, x, y, l); err != nil {
+			return err
+		}
+	}
+	return p.pdf.Output(w)
+}
@@ -0,0 +1,192 @@
+package labels
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"image/png"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+)
+
+var testLabel = label{
+	Tag:    "LAP-000123",
+	Name:   "ThinkPad X1 Carbon Gen 11 (loaner)",
+	Serial: "PF3XK2ZQ",
+	QR:     "https://inventory.example.edu/devices/V1StGXR8_Z5jdHi6B-myT",
+}
+
+func TestRenderPNG(t *testing.T) {
+	for _, n := range []int{1, 2, 7} {
+		t.Run(fmt.Sprintf("%d labels", n), func(t *testing.T) {
+			labels := make([]label, n)
+			for i := range labels {
+				labels[i] = testLabel
+			}
+			var buf bytes.Buffer
+			if err := renderPNG(&buf, labels); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			img, err := png.Decode(&buf)
+			if err != nil {
+				t.Fatalf("Expected a valid PNG, got %v", err)
+			}
+			columns := min(n, sheetColumns)
+			rows := (n + sheetColumns - 1) / sheetColumns
+			width := (columns*labelWidth + (columns-1)*pngGap) * pngScale
+			height := (rows*labelHeight + (rows-1)*pngGap) * pngScale
+			if b := img.Bounds(); b.Dx() != width || b.Dy() != height {
+				t.Errorf("Expected %dx%d, got %dx%d", width, height, b.Dx(), b.Dy())
+			}
+		})
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	for _, tc := range []struct {
+		labels int
+		pages  int
+	}{
+		{labels: 1, pages: 1},
+		{labels: sheetColumns * sheetRows, pages: 1},
+		{labels: sheetColumns*sheetRows + 1, pages: 2},
+	} {
+		t.Run(fmt.Sprintf("%d labels", tc.labels), func(t *testing.T) {
+			labels := make([]label, tc.labels)
+			for i := range labels {
+				labels[i] = testLabel
+			}
+			labels[0].Name = `Ünï (a\b) ✓`
+			var buf bytes.Buffer
+			if err := renderPDF(&buf, labels); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			pdf := buf.Bytes()
+			if !bytes.HasPrefix(pdf, []byte("%PDF-")) || !bytes.HasSuffix(bytes.TrimSpace(pdf), []byte("%%EOF")) {
+				t.Fatalf("Expected a complete PDF document")
+			}
+			if got := len(regexp.MustCompile(`/Type /Page\n`).FindAll(pdf, -1)); got != tc.pages {
+				t.Errorf("Expected %d pages, got %d", tc.pages, got)
+			}
+		})
+	}
+}
+
+func TestPDFTranslate(t *testing.T) {
+	p := newPDFCanvas(labelWidth, labelHeight)
+	if got := p.translate(`Ünï (a\b) ✓`); got != "\xdcn\xef (a\\b) ." {
+		t.Errorf("Expected the name in Windows-1252, got %q", got)
+	}
+}
+
+// TestRenderRoundTrip reads the QR code and barcode back from the pixels of
+// a rendered label and compares them with what the encoders produce.
+func TestRenderRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := renderPNG(&buf, []label{testLabel}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Expected a valid PNG, got %v", err)
+	}
+	c := &pngCanvas{}
+	dark := func(x float64, y float64) bool {
+		gray := color.GrayModel.Convert(img.At(int(x*pngScale), int(y*pngScale))).(color.Gray)
+		return gray.Y < 0x80
+	}
+
+	t.Run("qr code", func(t *testing.T) {
+		code, err := qr.Encode(testLabel.QR, qr.M, qr.Auto)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		want := modules(code)
+		module := qrModule(c, len(want))
+		for row := range want {
+			for col := range want[row] {
+				got := dark(qrLeft+(float64(col)+0.5)*module, qrTop+(float64(row)+0.5)*module)
+				if got != want[row][col] {
+					t.Fatalf("Expected module %d,%d to be dark=%v", col, row, want[row][col])
+				}
+			}
+		}
+		// The quiet zone around the code must stay blank.
+		size := float64(len(want)) * module
+		for i := 0.5; i < size; i += module {
+			if dark(qrLeft+i, qrTop+size+module/2) || dark(qrLeft+size+module/2, qrTop+i) {
+				t.Fatalf("Expected a blank quiet zone around the QR code")
+			}
+		}
+	})
+
+	t.Run("barcode", func(t *testing.T) {
+		code, err := code128.Encode(testLabel.Tag)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		want := modules(code)[0]
+		start, module := barcodeLayout(c, len(want))
+		if start < quietZone*module {
+			t.Errorf("Expected a quiet zone of %d modules, got %.2f points", quietZone, start)
+		}
+		for i := -quietZone; i < len(want)+quietZone; i++ {
+			wantDark := i >= 0 && i < len(want) && want[i]
+			for _, y := range []float64{barcodeTop + 1, barcodeTop + barcodeHeight - 1} {
+				if got := dark(start+(float64(i)+0.5)*module, y); got != wantDark {
+					t.Fatalf("Expected module %d to be dark=%v", i, wantDark)
+				}
+			}
+		}
+	})
+}
+
+func TestRenderWithoutQR(t *testing.T) {
+	l := testLabel
+	l.QR = ""
+	var buf bytes.Buffer
+	if err := renderPNG(&buf, []label{l}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Expected a valid PNG, got %v", err)
+	}
+	for y := qrTop * pngScale; y < (qrTop+qrSize)*pngScale; y++ {
+		for x := qrLeft * pngScale; x < (qrLeft+qrSize)*pngScale; x++ {
+			if gray := color.GrayModel.Convert(img.At(x, y)).(color.Gray); gray.Y != 0xff {
+				t.Fatalf("Expected no QR code, got a dark pixel at %d,%d", x, y)
+			}
+		}
+	}
+}
+
+func TestFit(t *testing.T) {
+	p, err := newPNGCanvas(labelWidth, labelHeight)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	width := float64(labelWidth - textLeft - textRight)
+	for name, c := range map[string]canvas{"png": p, "pdf": newPDFCanvas(labelWidth, labelHeight)} {
+		t.Run(name, func(t *testing.T) {
+			if got := fit(c, testLabel.Tag, 12, true, width); got != testLabel.Tag {
+				t.Errorf("Expected the tag to fit, got %q", got)
+			}
+			got := fit(c, testLabel.Name, 8, false, width)
+			if got == testLabel.Name || !strings.HasSuffix(got, "...") {
+				t.Fatalf("Expected the name to be truncated, got %q", got)
+			}
+			if w := c.width(8, false, got); w > width {
+				t.Errorf("Expected %q to fit in %.0f points, got %.1f", got, width, w)
+			}
+			kept := len([]rune(strings.TrimSuffix(got, "...")))
+			if longer := string([]rune(testLabel.Name)[:kept+1]) + "..."; c.width(8, false, longer) <= width {
+				t.Errorf("Expected %q to keep as much of the name as fits", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package labels
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/devices"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+var ErrInvalidRequest = errors.New("invalid request")
+
+const (
+	FormatPNG = "png"
+	FormatPDF = "pdf"
+)
+
+// MaxBatch caps a batch at ten sheets of labels.
+const MaxBatch = 10 * sheetColumns * sheetRows
+
+type Config struct {
+	// DeviceURL is the device page linked from the QR code, with {id} and
+	// {tag} replaced by the device's ID and asset tag. When empty labels are
+	// printed without a QR code.
+	DeviceURL string `mapstructure:"device-url"`
+}
+
+type Service struct {
+	devicesDao *devices.Dao
+	pdb        *utils.DB
+	cfg        Config
+}
+
+func NewService(pdb *utils.DB, cfg Config) *Service {
+	return &Service{
+		devicesDao: devices.NewDao(),
+		pdb:        pdb,
+		cfg:        cfg,
+	}
+}
+
+// Render returns the labels for the given devices, in order, as a PNG image
+// or a PDF document, along with its content type.
+func (s *Service) Render(ctx context.Context, deviceIDs []string, format string) ([]byte, string, error) {
+	ctx, span := tracing.Start(ctx, "labels.Render")
+	defer span.End()
+
+	var contentType string
+	var render func(io.Writer, []label) error
+	switch format {
+	case FormatPNG:
+		contentType = "image/png"
+		render = renderPNG
+	case FormatPDF:
+		contentType = "application/pdf"
+		render = renderPDF
+	default:
+		return nil, "", fmt.Errorf("%w: format must be %s or %s", ErrInvalidRequest, FormatPNG, FormatPDF)
+	}
+	if len(deviceIDs) == 0 {
+		return nil, "", fmt.Errorf("%w: device_ids is required", ErrInvalidRequest)
+	}
+	if len(deviceIDs) > MaxBatch {
+		return nil, "", fmt.Errorf("%w: at most %d labels per batch", ErrInvalidRequest, MaxBatch)
+	}
+
+	var labels []label
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		for _, id := range deviceIDs {
+			device, err := s.devicesDao.GetDevice(ctx, tx, id)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return fmt.Errorf("device %s: %w", id, err)
+				}
+				return err
+			}
+			labels = append(labels, s.label(device))
+		}
+		return nil
+	})
+	if err != nil {
+		utils.Log(ctx).Errorf("Failed to load devices for labels: %v", err)
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	if err := render(&buf, labels); err != nil {
+		utils.Log(ctx).Errorf("Failed to render labels: %v", err)
+		return nil, "", err
+	}
+	return buf.Bytes(), contentType, nil
+}
+
+// label falls back to the device ID for devices created before asset tags.
+func (s *Service) label(device *utils.Device) label {
+	tag := device.ID
+	if device.AssetTag != nil {
+		tag = *device.AssetTag
+	}
+	l := label{Tag: tag, Name: device.Name, Serial: device.SerialNumber}
+	if s.cfg.DeviceURL != "" {
+		l.QR = strings.NewReplacer("{id}", device.ID, "{tag}", tag).Replace(s.cfg.DeviceURL)
+	}
+	return l
+}
@@ -0,0 +1,60 @@
+package limits
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+const ClassJSON = "json"
+
+type BodyConfig struct {
+	// Classes maps a route class to its maximum body size in bytes.
+	Classes map[string]int64 `mapstructure:"classes"`
+	// Routes assigns mux route templates to a class. Unlisted routes are
+	// treated as JSON.
+	Routes map[string]string `mapstructure:"routes"`
+}
+
+// BodyLimiter caps request bodies by route class. Handlers answer bodies
+// cut off by the limit with utils.DecodeStatus.
+type BodyLimiter struct {
+	cfg BodyConfig
+}
+
+func NewBodyLimiter(cfg BodyConfig) *BodyLimiter {
+	routes := map[string]string{}
+	for route, class := range cfg.Routes {
+		routes[strings.ToLower(route)] = class
+	}
+	cfg.Routes = routes
+	return &BodyLimiter{cfg: cfg}
+}
+
+func (l *BodyLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := l.limitFor(r)
+		if limit > 0 {
+			if r.ContentLength > limit {
+				http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			// Chunked bodies have no Content-Length, so also cap the reader.
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (l *BodyLimiter) limitFor(r *http.Request) int64 {
+	class := ClassJSON
+	if current := mux.CurrentRoute(r); current != nil {
+		if template, err := current.GetPathTemplate(); err == nil {
+			if routeClass, ok := l.cfg.Routes[strings.ToLower(template)]; ok {
+				class = routeClass
+			}
+		}
+	}
+	return l.cfg.Classes[class]
+}
@@ -0,0 +1,255 @@
+package limits
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rickCrz7/Inventory-API/utils"
+	"golang.org/x/time/rate"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// idleTTL is how long a client's bucket is kept after its last request.
+const idleTTL = 10 * time.Minute
+
+// defaultMaxClients caps the buckets kept when max-clients is not set.
+const defaultMaxClients = 10000
+
+type Rate struct {
+	PerSecond float64 `mapstructure:"per-second"`
+	Burst     int     `mapstructure:"burst"`
+}
+
+type RateConfig struct {
+	Default Rate `mapstructure:"default"`
+	// Routes overrides Default for mux route templates such as
+	// "/api/v1/owners/sync". Each overridden route gets its own buckets.
+	Routes map[string]Rate `mapstructure:"routes"`
+	// APIKeys are the X-API-Key values that get a bucket of their own.
+	// Requests with any other key are limited by address like anonymous
+	// ones, so made-up keys cannot dodge the limit.
+	APIKeys []string `mapstructure:"api-keys"`
+	// TrustedProxies are the addresses or CIDR ranges of reverse proxies
+	// whose X-Forwarded-For is believed. Without them a client is the
+	// connection's remote address.
+	TrustedProxies []string `mapstructure:"trusted-proxies"`
+	// Exempt lists route templates that are never limited, such as the
+	// health probes and the metrics scrape.
+	Exempt []string `mapstructure:"exempt"`
+	// MaxClients caps the buckets kept in memory. Once it is reached, new
+	// clients share one overflow bucket per scope until idle ones expire.
+	MaxClients int `mapstructure:"max-clients"`
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimiter applies a token bucket per client, keyed by API key when a
+// configured one is sent and by IP address otherwise.
+type RateLimiter struct {
+	cfg     RateConfig
+	apiKeys map[[sha256.Size]byte]string
+	proxies []netip.Prefix
+	exempt  map[string]bool
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewRateLimiter(cfg RateConfig) (*RateLimiter, error) {
+	proxies, err := ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	routes := map[string]Rate{}
+	for route, r := range cfg.Routes {
+		// Viper lower-cases map keys, so match templates case-insensitively.
+		routes[strings.ToLower(route)] = r
+	}
+	cfg.Routes = routes
+	if cfg.MaxClients <= 0 {
+		cfg.MaxClients = defaultMaxClients
+	}
+	// Keys are looked up by hash and named by position so the keys
+	// themselves never end up in bucket names or logs.
+	apiKeys := map[[sha256.Size]byte]string{}
+	for i, key := range cfg.APIKeys {
+		apiKeys[sha256.Sum256([]byte(key))] = fmt.Sprintf("key:%d", i)
+	}
+	exempt := map[string]bool{}
+	for _, route := range cfg.Exempt {
+		exempt[strings.ToLower(route)] = true
+	}
+	return &RateLimiter{
+		cfg:       cfg,
+		apiKeys:   apiKeys,
+		proxies:   proxies,
+		exempt:    exempt,
+		buckets:   map[string]*bucket{},
+		lastSweep: time.Now(),
+	}, nil
+}
+
+// ParseTrustedProxies parses addresses and CIDR ranges such as "10.0.0.1"
+// or "10.0.0.0/8".
+func ParseTrustedProxies(proxies []string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, proxy := range proxies {
+		if prefix, err := netip.ParsePrefix(proxy); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q", proxy)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return prefixes, nil
+}
+
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope, limit, exempt := l.limitFor(r)
+		if exempt || limit.PerSecond <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		client := l.clientKey(r)
+		reservation := l.bucket(scope+"|"+client, limit).Reserve()
+		if delay := reservation.Delay(); !reservation.OK() || delay > 0 {
+			reservation.Cancel()
+			retryAfter := int(math.Ceil(delay.Seconds()))
+			if !reservation.OK() || retryAfter < 1 {
+				retryAfter = 1
+			}
+			utils.Log(r.Context()).Warnf("Rate limit exceeded for %s on %s", client, scope)
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// limitFor returns the bucket scope and rate for the matched route, and
+// whether the route is exempt.
+func (l *RateLimiter) limitFor(r *http.Request) (string, Rate, bool) {
+	if current := mux.CurrentRoute(r); current != nil {
+		if template, err := current.GetPathTemplate(); err == nil {
+			template = strings.ToLower(template)
+			if l.exempt[template] {
+				return template, Rate{}, true
+			}
+			if limit, ok := l.cfg.Routes[template]; ok {
+				return template, limit, false
+			}
+		}
+	}
+	return "default", l.cfg.Default, false
+}
+
+func (l *RateLimiter) clientKey(r *http.Request) string {
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		if name, ok := l.apiKeys[sha256.Sum256([]byte(key))]; ok {
+			return name
+		}
+	}
+	return "ip:" + l.clientIP(r)
+}
+
+// clientIP is the connection's remote address, unless that is a trusted
+// proxy. Then X-Forwarded-For is walked from the right, skipping trusted
+// proxies, and the first other address is the client. A Unix socket peer is
+// always a local proxy and so trusted.
+func (l *RateLimiter) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err == nil {
+		addr = addr.Unmap()
+		if !l.trusted(addr) {
+			return addr.String()
+		}
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		addr = hop.Unmap()
+		if !l.trusted(addr) {
+			return addr.String()
+		}
+	}
+	if !addr.IsValid() {
+		return host
+	}
+	return addr.String()
+}
+
+func (l *RateLimiter) trusted(addr netip.Addr) bool {
+	for _, proxy := range l.proxies {
+		if proxy.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *RateLimiter) bucket(key string, limit Rate) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastSweep) > idleTTL {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok && len(l.buckets) >= l.cfg.MaxClients {
+		l.sweep(now)
+		if len(l.buckets) >= l.cfg.MaxClients {
+			scope, _, _ := strings.Cut(key, "|")
+			key = scope + "|overflow"
+			b, ok = l.buckets[key]
+		}
+	}
+	if !ok {
+		burst := limit.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.PerSecond), burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+	return b.limiter
+}
+
+func (l *RateLimiter) sweep(now time.Time) {
+	for k, b := range l.buckets {
+		if now.Sub(b.lastSeen) > idleTTL {
+			delete(l.buckets, k)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,185 @@
+package limits
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+func newRouter(middleware mux.MiddlewareFunc) *mux.Router {
+	r := mux.NewRouter()
+	r.Use(middleware)
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), utils.DecodeStatus(err))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	r.HandleFunc("/api/v1/owners/campus/{campusID}", ok)
+	r.HandleFunc("/api/v1/devices", ok)
+	r.HandleFunc("/api/v1/devices/{id}/photos", ok)
+	r.HandleFunc("/healthz", ok)
+	return r
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter, err := NewRateLimiter(RateConfig{
+		Default: Rate{PerSecond: 0.001, Burst: 2},
+		Routes: map[string]Rate{
+			// Lower-cased the way viper delivers it
+			"/api/v1/owners/campus/{campusid}": {PerSecond: 0.001, Burst: 1},
+		},
+		APIKeys: []string{"secret"},
+		Exempt:  []string{"/healthz"},
+	})
+	if err != nil {
+		t.Fatalf("Error creating rate limiter: %v", err)
+	}
+	router := newRouter(limiter.Middleware)
+
+	do := func(path, apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		if apiKey != "" {
+			req.Header.Set(apiKeyHeader, apiKey)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("DefaultBurst", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			if w := do("/api/v1/devices", ""); w.Code != http.StatusOK {
+				t.Fatalf("Expected request %d to pass, got %d", i, w.Code)
+			}
+		}
+		w := do("/api/v1/devices", "")
+		if w.Code != http.StatusTooManyRequests {
+			t.Fatalf("Expected %d, got %d", http.StatusTooManyRequests, w.Code)
+		}
+		if w.Header().Get("Retry-After") == "" {
+			t.Fatal("Expected Retry-After header")
+		}
+	})
+	t.Run("APIKeyHasOwnBucket", func(t *testing.T) {
+		if w := do("/api/v1/devices", "secret"); w.Code != http.StatusOK {
+			t.Fatalf("Expected API key client to pass, got %d", w.Code)
+		}
+	})
+	t.Run("UnknownAPIKeySharesAddressBucket", func(t *testing.T) {
+		if w := do("/api/v1/devices", "made-up"); w.Code != http.StatusTooManyRequests {
+			t.Fatalf("Expected %d, got %d", http.StatusTooManyRequests, w.Code)
+		}
+	})
+	t.Run("Exempt", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			if w := do("/healthz", ""); w.Code != http.StatusOK {
+				t.Fatalf("Expected exempt request %d to pass, got %d", i, w.Code)
+			}
+		}
+	})
+	t.Run("RouteOverride", func(t *testing.T) {
+		if w := do("/api/v1/owners/campus/1", ""); w.Code != http.StatusOK {
+			t.Fatalf("Expected first request to pass, got %d", w.Code)
+		}
+		if w := do("/api/v1/owners/campus/2", ""); w.Code != http.StatusTooManyRequests {
+			t.Fatalf("Expected %d, got %d", http.StatusTooManyRequests, w.Code)
+		}
+	})
+}
+
+func TestClientIP(t *testing.T) {
+	limiter, err := NewRateLimiter(RateConfig{TrustedProxies: []string{"10.0.0.0/8", "192.0.2.1"}})
+	if err != nil {
+		t.Fatalf("Error creating rate limiter: %v", err)
+	}
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		want       string
+	}{
+		{"Direct", "198.51.100.7:4000", "", "198.51.100.7"},
+		{"SpoofedHeaderIgnored", "198.51.100.7:4000", "203.0.113.9", "198.51.100.7"},
+		{"TrustedProxy", "10.1.2.3:4000", "203.0.113.9", "203.0.113.9"},
+		{"ProxyChain", "192.0.2.1:4000", "203.0.113.66, 203.0.113.9, 10.0.0.2", "203.0.113.9"},
+		{"OnlyProxies", "10.1.2.3:4000", "10.0.0.2", "10.0.0.2"},
+		{"UnixSocket", "@", "203.0.113.9", "203.0.113.9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/devices", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			if got := limiter.clientIP(req); got != tt.want {
+				t.Fatalf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := NewRateLimiter(RateConfig{TrustedProxies: []string{"proxy.example.edu"}}); err == nil {
+		t.Fatal("Expected error for a host name proxy, got nil")
+	}
+}
+
+func TestRateLimiterMaxClients(t *testing.T) {
+	limiter, err := NewRateLimiter(RateConfig{Default: Rate{PerSecond: 1, Burst: 1}, MaxClients: 2})
+	if err != nil {
+		t.Fatalf("Error creating rate limiter: %v", err)
+	}
+	for _, client := range []string{"a", "b", "c", "d"} {
+		limiter.bucket("default|ip:"+client, limiter.cfg.Default)
+	}
+	if len(limiter.buckets) != 3 {
+		t.Fatalf("Expected 2 clients and an overflow bucket, got %d buckets", len(limiter.buckets))
+	}
+	if _, ok := limiter.buckets["default|overflow"]; !ok {
+		t.Fatal("Expected later clients to share the overflow bucket")
+	}
+}
+
+func TestBodyLimiter(t *testing.T) {
+	limiter := NewBodyLimiter(BodyConfig{
+		Classes: map[string]int64{ClassJSON: 8, "large": 64},
+		Routes:  map[string]string{"/api/v1/devices/{id}/photos": "large"},
+	})
+	router := newRouter(limiter.Middleware)
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		want int
+	}{
+		{"JSONWithinLimit", "/api/v1/devices", `{"a":1}`, http.StatusOK},
+		{"JSONTooLarge", "/api/v1/devices", `{"name":"too long"}`, http.StatusRequestEntityTooLarge},
+		{"LargeWithinLimit", "/api/v1/devices/1/photos", strings.Repeat("x", 32), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("POST", tt.path, strings.NewReader(tt.body)))
+			if w.Code != tt.want {
+				t.Fatalf("Expected %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+
+	t.Run("ChunkedTooLarge", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/devices", strings.NewReader(`{"name":"too long"}`))
+		req.ContentLength = -1
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Fatalf("Expected %d once the body goes over the limit, got %d", http.StatusRequestEntityTooLarge, w.Code)
+		}
+	})
+}
@@ -0,0 +1,342 @@
+package loaners
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Dao struct{}
+
+func NewDao() *Dao {
+	return &Dao{}
+}
+
+func (d *Dao) GetLoaner(ctx context.Context, tx pgx.Tx, deviceID string) (*utils.Loaner, error) {
+	utils.Log(ctx).Printf("Fetching loaner with device ID: %s", deviceID)
+	query := `SELECT device_id, note, added_at, added_by FROM loaners WHERE device_id = $1`
+	var loaner utils.Loaner
+	err := tx.QueryRow(ctx, query, deviceID).Scan(&loaner.DeviceID, &loaner.Note, &loaner.AddedAt, &loaner.AddedBy)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching loaner with device ID %s: %v", deviceID, err)
+		return nil, err
+	}
+	return &loaner, nil
+}
+
+func (d *Dao) GetLoaners(ctx context.Context, tx pgx.Tx) ([]*utils.Loaner, error) {
+	utils.Log(ctx).Println("Fetching all loaners")
+	query := `SELECT device_id, note, added_at, added_by FROM loaners ORDER BY added_at`
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching loaners: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var loaners []*utils.Loaner
+	for rows.Next() {
+		var loaner utils.Loaner
+		if err := rows.Scan(&loaner.DeviceID, &loaner.Note, &loaner.AddedAt, &loaner.AddedBy); err != nil {
+			utils.Log(ctx).Errorf("Error scanning loaner row: %v", err)
+			return nil, err
+		}
+		loaners = append(loaners, &loaner)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over loaner rows: %v", err)
+		return nil, err
+	}
+	return loaners, nil
+}
+
+func (d *Dao) AddLoaner(ctx context.Context, tx pgx.Tx, loaner *utils.Loaner) error {
+	utils.Log(ctx).Printf("Adding device %s to the loaner pool", loaner.DeviceID)
+	query := `INSERT INTO loaners (device_id, note, added_at, added_by) VALUES ($1, $2, $3, $4)`
+	if _, err := tx.Exec(ctx, query, loaner.DeviceID, loaner.Note, loaner.AddedAt, loaner.AddedBy); err != nil {
+		utils.Log(ctx).Errorf("Error adding loaner: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (d *Dao) RemoveLoaner(ctx context.Context, tx pgx.Tx, deviceID string) error {
+	utils.Log(ctx).Printf("Removing device %s from the loaner pool", deviceID)
+	tag, err := tx.Exec(ctx, `DELETE FROM loaners WHERE device_id = $1`, deviceID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error removing loaner %s: %v", deviceID, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// GetDeviceType returns the type of a device, or pgx.ErrNoRows when it does
+// not exist.
+func (d *Dao) GetDeviceType(ctx context.Context, tx pgx.Tx, deviceID string) (string, error) {
+	utils.Log(ctx).Printf("Fetching type of device with ID: %s", deviceID)
+	var typeID string
+	if err := tx.QueryRow(ctx, `SELECT type_id FROM devices WHERE id = $1`, deviceID).Scan(&typeID); err != nil {
+		utils.Log(ctx).Errorf("Error fetching device with ID %s: %v", deviceID, err)
+		return "", err
+	}
+	return typeID, nil
+}
+
+// GetDeviceStatus returns the status of a device, or pgx.ErrNoRows when it
+// does not exist.
+func (d *Dao) GetDeviceStatus(ctx context.Context, tx pgx.Tx, deviceID string) (string, error) {
+	utils.Log(ctx).Printf("Fetching status of device with ID: %s", deviceID)
+	var status string
+	if err := tx.QueryRow(ctx, `SELECT status FROM devices WHERE id = $1`, deviceID).Scan(&status); err != nil {
+		utils.Log(ctx).Errorf("Error fetching device with ID %s: %v", deviceID, err)
+		return "", err
+	}
+	return status, nil
+}
+
+// CountUpcoming counts the reservations of a device that are booked or
+// checked out and have not ended.
+func (d *Dao) CountUpcoming(ctx context.Context, tx pgx.Tx, deviceID string, now time.Time) (int, error) {
+	utils.Log(ctx).Printf("Counting upcoming reservations of device with ID: %s", deviceID)
+	query := `SELECT count(*) FROM reservations
+		WHERE device_id = $1 AND (status = $2 AND ends_at > $4 OR status = $3)`
+	var count int
+	err := tx.QueryRow(ctx, query, deviceID, utils.ReservationReserved, utils.ReservationCheckedOut, now).Scan(&count)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error counting reservations of device %s: %v", deviceID, err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindFreeLoaner returns a loaner of the type that is in one of the given
+// statuses, has no booked or checked out reservation overlapping the window
+// and is not out with anyone past the start of the window, or pgx.ErrNoRows
+// when none is. A loaner that is out but due back before the window starts
+// counts as free unless it is already overdue.
+func (d *Dao) FindFreeLoaner(ctx context.Context, tx pgx.Tx, typeID string, statuses []string, startsAt time.Time, endsAt time.Time, now time.Time) (string, error) {
+	utils.Log(ctx).Printf("Finding a free loaner of type %s from %s to %s", typeID, startsAt, endsAt)
+	query := `SELECT l.device_id
+		FROM loaners l
+		JOIN devices dv ON dv.id = l.device_id
+		WHERE dv.type_id = $1 AND dv.status = ANY($4)
+		AND NOT EXISTS (
+			SELECT 1 FROM device_assignments a
+			WHERE a.device_id = l.device_id AND a.returned_at IS NULL
+			AND (a.due_at IS NULL OR a.due_at > $2 OR a.due_at <= $7)
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM reservations r
+			WHERE r.device_id = l.device_id
+			AND r.status IN ($5, $6)
+			AND tsrange(r.starts_at, r.ends_at) && tsrange($2, $3)
+		)
+		ORDER BY l.added_at, l.device_id
+		LIMIT 1`
+	var deviceID string
+	err := tx.QueryRow(ctx, query, typeID, startsAt, endsAt, statuses, utils.ReservationReserved, utils.ReservationCheckedOut, now).Scan(&deviceID)
+	if err != nil {
+		return "", err
+	}
+	return deviceID, nil
+}
+
+const reservationColumns = `id, device_id, type_id, owner_id, starts_at, ends_at, status, note, created_at, created_by`
+
+func scanReservation(row pgx.Row, reservation *utils.Reservation) error {
+	return row.Scan(&reservation.ID, &reservation.DeviceID, &reservation.TypeID, &reservation.OwnerID, &reservation.StartsAt,
+		&reservation.EndsAt, &reservation.Status, &reservation.Note, &reservation.CreatedAt, &reservation.CreatedBy)
+}
+
+func (d *Dao) GetReservation(ctx context.Context, tx pgx.Tx, id string) (*utils.Reservation, error) {
+	utils.Log(ctx).Printf("Fetching reservation with ID: %s", id)
+	query := `SELECT ` + reservationColumns + ` FROM reservations WHERE id = $1`
+	var reservation utils.Reservation
+	if err := scanReservation(tx.QueryRow(ctx, query, id), &reservation); err != nil {
+		utils.Log(ctx).Errorf("Error fetching reservation with ID %s: %v", id, err)
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// LockReservation fetches a reservation and locks it until the transaction
+// ends so concurrent checkouts and returns are serialized.
+func (d *Dao) LockReservation(ctx context.Context, tx pgx.Tx, id string) (*utils.Reservation, error) {
+	utils.Log(ctx).Printf("Locking reservation with ID: %s", id)
+	query := `SELECT ` + reservationColumns + ` FROM reservations WHERE id = $1 FOR UPDATE`
+	var reservation utils.Reservation
+	if err := scanReservation(tx.QueryRow(ctx, query, id), &reservation); err != nil {
+		utils.Log(ctx).Errorf("Error locking reservation with ID %s: %v", id, err)
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// Filter narrows a reservation listing. Empty fields are ignored; From and To
+// keep the reservations overlapping that window.
+type Filter struct {
+	DeviceID string
+	TypeID   string
+	OwnerID  string
+	Status   string
+	From     *time.Time
+	To       *time.Time
+}
+
+func (d *Dao) GetReservations(ctx context.Context, tx pgx.Tx, filter Filter) ([]*utils.Reservation, error) {
+	utils.Log(ctx).Printf("Fetching reservations: %+v", filter)
+	query := `SELECT ` + reservationColumns + ` FROM reservations
+	WHERE ($1 = '' OR device_id = $1) AND ($2 = '' OR type_id = $2) AND ($3 = '' OR owner_id = $3) AND ($4 = '' OR status = $4)
+	AND ($5::timestamp IS NULL OR ends_at > $5) AND ($6::timestamp IS NULL OR starts_at < $6)
+	ORDER BY starts_at, id`
+	rows, err := tx.Query(ctx, query, filter.DeviceID, filter.TypeID, filter.OwnerID, filter.Status, filter.From, filter.To)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching reservations: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reservations []*utils.Reservation
+	for rows.Next() {
+		var reservation utils.Reservation
+		if err := scanReservation(rows, &reservation); err != nil {
+			utils.Log(ctx).Errorf("Error scanning reservation row: %v", err)
+			return nil, err
+		}
+		reservations = append(reservations, &reservation)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over reservation rows: %v", err)
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// CreateReservation inserts a reservation. Overlapping bookings of the same
+// device are rejected by the reservations exclusion constraint.
+func (d *Dao) CreateReservation(ctx context.Context, tx pgx.Tx, reservation *utils.Reservation) error {
+	utils.Log(ctx).Printf("Creating reservation: %+v", reservation)
+	if reservation.ID == "" {
+		id, err := gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Error generating ID for new reservation: %v", err)
+			return err
+		}
+		reservation.ID = id
+	}
+	query := `INSERT INTO reservations (` + reservationColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	_, err := tx.Exec(ctx, query, reservation.ID, reservation.DeviceID, reservation.TypeID, reservation.OwnerID, reservation.StartsAt,
+		reservation.EndsAt, reservation.Status, reservation.Note, reservation.CreatedAt, reservation.CreatedBy)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error creating reservation: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (d *Dao) SetReservationStatus(ctx context.Context, tx pgx.Tx, id string, status string) error {
+	utils.Log(ctx).Printf("Setting status of reservation %s to %s", id, status)
+	tag, err := tx.Exec(ctx, `UPDATE reservations SET status = $2 WHERE id = $1`, id, status)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error updating reservation %s: %v", id, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+const assignmentColumns = `id, device_id, owner_id, reservation_id, assigned_at, assigned_by, due_at, returned_at, returned_by`
+
+func scanAssignment(row pgx.Row, assignment *utils.DeviceAssignment) error {
+	return row.Scan(&assignment.ID, &assignment.DeviceID, &assignment.OwnerID, &assignment.ReservationID, &assignment.AssignedAt,
+		&assignment.AssignedBy, &assignment.DueAt, &assignment.ReturnedAt, &assignment.ReturnedBy)
+}
+
+// GetOpenAssignment returns the assignment a device has not been returned
+// from, or pgx.ErrNoRows.
+func (d *Dao) GetOpenAssignment(ctx context.Context, tx pgx.Tx, deviceID string) (*utils.DeviceAssignment, error) {
+	utils.Log(ctx).Printf("Fetching open assignment of device with ID: %s", deviceID)
+	query := `SELECT ` + assignmentColumns + ` FROM device_assignments WHERE device_id = $1 AND returned_at IS NULL`
+	var assignment utils.DeviceAssignment
+	if err := scanAssignment(tx.QueryRow(ctx, query, deviceID), &assignment); err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (d *Dao) CreateAssignment(ctx context.Context, tx pgx.Tx, assignment *utils.DeviceAssignment) error {
+	utils.Log(ctx).Printf("Assigning device %s to owner %s", assignment.DeviceID, assignment.OwnerID)
+	if assignment.ID == "" {
+		id, err := gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Error generating ID for new assignment: %v", err)
+			return err
+		}
+		assignment.ID = id
+	}
+	query := `INSERT INTO device_assignments (` + assignmentColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := tx.Exec(ctx, query, assignment.ID, assignment.DeviceID, assignment.OwnerID, assignment.ReservationID, assignment.AssignedAt,
+		assignment.AssignedBy, assignment.DueAt, assignment.ReturnedAt, assignment.ReturnedBy)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error creating assignment: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (d *Dao) ReturnAssignment(ctx context.Context, tx pgx.Tx, id string, returnedAt time.Time, returnedBy string) error {
+	utils.Log(ctx).Printf("Returning assignment with ID: %s", id)
+	query := `UPDATE device_assignments SET returned_at = $2, returned_by = $3 WHERE id = $1 AND returned_at IS NULL`
+	tag, err := tx.Exec(ctx, query, id, returnedAt, returnedBy)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error returning assignment %s: %v", id, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// GetOverdue returns the open assignments due before now, most overdue first.
+func (d *Dao) GetOverdue(ctx context.Context, tx pgx.Tx, now time.Time) ([]*utils.OverdueLoan, error) {
+	utils.Log(ctx).Println("Fetching overdue loans")
+	query := `SELECT a.id, a.device_id, a.owner_id, a.reservation_id, a.assigned_at, a.assigned_by, a.due_at, a.returned_at, a.returned_by,
+		dv.name, o.first_name || ' ' || o.last_name, o.email
+		FROM device_assignments a
+		JOIN devices dv ON dv.id = a.device_id
+		JOIN owners o ON o.id = a.owner_id
+		WHERE a.returned_at IS NULL AND a.due_at < $1
+		ORDER BY a.due_at, a.id`
+	rows, err := tx.Query(ctx, query, now)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching overdue loans: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var loans []*utils.OverdueLoan
+	for rows.Next() {
+		var loan utils.OverdueLoan
+		err := rows.Scan(&loan.ID, &loan.DeviceID, &loan.OwnerID, &loan.ReservationID, &loan.AssignedAt, &loan.AssignedBy,
+			&loan.DueAt, &loan.ReturnedAt, &loan.ReturnedBy, &loan.DeviceName, &loan.OwnerName, &loan.OwnerEmail)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error scanning overdue loan row: %v", err)
+			return nil, err
+		}
+		loans = append(loans, &loan)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over overdue loan rows: %v", err)
+		return nil, err
+	}
+	return loans, nil
+}
@@ -0,0 +1,219 @@
+package loaners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+var postgresURI string
+
+func init() {
+	viper.SetConfigName("app")
+	viper.AddConfigPath("../config")
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+	postgresURI = viper.GetString("postgres.dev") // Change to "postgres.dev" for development/local db
+	log.SetReportCaller(true)
+	log.SetFormatter(&log.TextFormatter{
+		ForceColors:     true,
+		FullTimestamp:   true,
+		TimestampFormat: "2006/01/02 15:04:05",
+		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
+			filename := path.Base(f.File)
+			return fmt.Sprintf("%s()", f.Function), fmt.Sprintf("\t%s:%d", filename, f.Line)
+		},
+	})
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestReservations(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+	svc := NewService(dao, utils.NewDB(pdb, nil, 0))
+
+	// Mock Data
+	ownerID, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO owners (id, first_name, last_name, email)
+		VALUES ($1, $2, $3, $4)
+	`, ownerID, "John", "Doe", "john.doe@example.com")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	typeID, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO types (id, name, description) VALUES ($1, $2, $3)
+	`, typeID, "Test Loaner", "This is a test type")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	var devices []string
+	for i, status := range []string{utils.DeviceStatusActive, utils.DeviceStatusInRepair, utils.DeviceStatusLost} {
+		d_id, _ := gonanoid.New()
+		_, err = tx.Exec(ctx, `
+			INSERT INTO devices (id, serial_number, name, purchase_date, status, owner_id, type_id) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, d_id, fmt.Sprintf("SN-L%d", i), fmt.Sprintf("Test Loaner %d", i), "2023-01-01", status, ownerID, typeID)
+		if err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+		if err := dao.AddLoaner(ctx, tx, &utils.Loaner{DeviceID: d_id, AddedAt: time.Now(), AddedBy: "tester"}); err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+		devices = append(devices, d_id)
+	}
+	active := devices[0]
+	now := time.Now()
+	handover := &Handover{HandledBy: "helpdesk"}
+	reservation := func(deviceID string, startsAt time.Time, endsAt time.Time) *utils.Reservation {
+		return &utils.Reservation{
+			DeviceID:  deviceID,
+			TypeID:    typeID,
+			OwnerID:   ownerID,
+			StartsAt:  startsAt,
+			EndsAt:    endsAt,
+			Status:    utils.ReservationReserved,
+			CreatedAt: now,
+			CreatedBy: "helpdesk",
+		}
+	}
+
+	t.Run("FindFreeLoaner", func(t *testing.T) {
+		deviceID, err := dao.FindFreeLoaner(ctx, tx, typeID, availableStatuses, now.Add(24*time.Hour), now.Add(48*time.Hour), now)
+		if err != nil {
+			t.Fatalf("Error finding a free loaner: %v", err)
+		}
+		if deviceID != active {
+			t.Errorf("Expected the active loaner %s, got %s", active, deviceID)
+		}
+	})
+	current := reservation(active, now.Add(-time.Hour), now.Add(3*time.Hour))
+	t.Run("Reserve", func(t *testing.T) {
+		if err := svc.reserve(ctx, tx, current); err != nil {
+			t.Fatalf("Error reserving: %v", err)
+		}
+	})
+	t.Run("ReserveOverlap", func(t *testing.T) {
+		// The exclusion violation aborts the transaction, so run it in a
+		// savepoint.
+		sp, err := tx.Begin(ctx)
+		if err != nil {
+			t.Fatalf("Error beginning savepoint: %v", err)
+		}
+		defer sp.Rollback(ctx)
+		err = svc.reserve(ctx, sp, reservation(active, now.Add(time.Hour), now.Add(5*time.Hour)))
+		if !errors.Is(err, ErrUnavailable) {
+			t.Errorf("Expected ErrUnavailable, got %v", err)
+		}
+	})
+	t.Run("ReserveUnavailableDevice", func(t *testing.T) {
+		for _, deviceID := range devices[1:] {
+			err := svc.reserve(ctx, tx, reservation(deviceID, now.Add(time.Hour), now.Add(2*time.Hour)))
+			if !errors.Is(err, ErrUnavailable) {
+				t.Errorf("Expected ErrUnavailable for device %s, got %v", deviceID, err)
+			}
+		}
+	})
+	t.Run("ReserveTypeNoneFree", func(t *testing.T) {
+		err := svc.reserve(ctx, tx, reservation("", now.Add(time.Hour), now.Add(2*time.Hour)))
+		if !errors.Is(err, ErrUnavailable) {
+			t.Errorf("Expected ErrUnavailable, got %v", err)
+		}
+	})
+	t.Run("CheckoutEarly", func(t *testing.T) {
+		later := reservation(active, now.Add(24*time.Hour), now.Add(48*time.Hour))
+		if err := svc.reserve(ctx, tx, later); err != nil {
+			t.Fatalf("Error reserving: %v", err)
+		}
+		if _, err := svc.checkout(ctx, tx, later.ID, handover); !errors.Is(err, ErrWrongStatus) {
+			t.Errorf("Expected ErrWrongStatus, got %v", err)
+		}
+	})
+	t.Run("CheckoutUnavailableDevice", func(t *testing.T) {
+		setStatus := func(status string) {
+			if _, err := tx.Exec(ctx, `UPDATE devices SET status = $1 WHERE id = $2`, status, active); err != nil {
+				t.Fatalf("Error setting device status: %v", err)
+			}
+		}
+		setStatus(utils.DeviceStatusInRepair)
+		defer setStatus(utils.DeviceStatusActive)
+		if _, err := svc.checkout(ctx, tx, current.ID, handover); !errors.Is(err, ErrUnavailable) {
+			t.Errorf("Expected ErrUnavailable, got %v", err)
+		}
+	})
+	t.Run("Checkout", func(t *testing.T) {
+		assignment, err := svc.checkout(ctx, tx, current.ID, handover)
+		if err != nil {
+			t.Fatalf("Error checking out: %v", err)
+		}
+		if assignment.DueAt == nil || !assignment.DueAt.Equal(current.EndsAt) {
+			t.Errorf("Expected the assignment to be due at %s, got %v", current.EndsAt, assignment.DueAt)
+		}
+		got, err := dao.GetReservation(ctx, tx, current.ID)
+		if err != nil {
+			t.Fatalf("Error getting reservation: %v", err)
+		}
+		if got.Status != utils.ReservationCheckedOut {
+			t.Errorf("Expected %s, got %s", utils.ReservationCheckedOut, got.Status)
+		}
+	})
+	t.Run("FindFreeLoanerDueBack", func(t *testing.T) {
+		deviceID, err := dao.FindFreeLoaner(ctx, tx, typeID, availableStatuses, now.Add(72*time.Hour), now.Add(96*time.Hour), now)
+		if err != nil {
+			t.Fatalf("Error finding a free loaner: %v", err)
+		}
+		if deviceID != active {
+			t.Errorf("Expected the loaner due back before the window %s, got %s", active, deviceID)
+		}
+	})
+	t.Run("FindFreeLoanerOverdue", func(t *testing.T) {
+		// Ten hours on, the loaner due back after three is overdue.
+		_, err := dao.FindFreeLoaner(ctx, tx, typeID, availableStatuses, now.Add(72*time.Hour), now.Add(96*time.Hour), now.Add(10*time.Hour))
+		if !errors.Is(err, pgx.ErrNoRows) {
+			t.Errorf("Expected no free loaner while the active one is overdue, got %v", err)
+		}
+	})
+	t.Run("Return", func(t *testing.T) {
+		assignment, err := svc.returnLoan(ctx, tx, current.ID, handover)
+		if err != nil {
+			t.Fatalf("Error returning: %v", err)
+		}
+		if assignment.ReturnedAt == nil {
+			t.Errorf("Expected the assignment to be closed")
+		}
+		if _, err := dao.GetOpenAssignment(ctx, tx, active); !errors.Is(err, pgx.ErrNoRows) {
+			t.Errorf("Expected no open assignment, got %v", err)
+		}
+		deviceID, err := dao.FindFreeLoaner(ctx, tx, typeID, availableStatuses, now.Add(72*time.Hour), now.Add(96*time.Hour), now.Add(10*time.Hour))
+		if err != nil {
+			t.Fatalf("Error finding a free loaner: %v", err)
+		}
+		if deviceID != active {
+			t.Errorf("Expected the returned loaner %s, got %s", active, deviceID)
+		}
+	})
+}
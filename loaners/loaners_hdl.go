@@ -0,0 +1,199 @@
+package loaners
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Handler struct {
+	svc *Service
+	// atz *authz.Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{
+		svc: svc,
+		// atz: atz,
+	}
+}
+
+func (h *Handler) GetLoaners(w http.ResponseWriter, r *http.Request) {
+	loaners, err := h.svc.GetLoaners(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(loaners)
+}
+
+func (h *Handler) AddLoaner(w http.ResponseWriter, r *http.Request) {
+	var loaner utils.Loaner
+	if err := json.NewDecoder(r.Body).Decode(&loaner); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	if err := h.svc.AddLoaner(r.Context(), &loaner); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(loaner)
+}
+
+func (h *Handler) RemoveLoaner(w http.ResponseWriter, r *http.Request) {
+	deviceID := mux.Vars(r)["device_id"]
+	if err := h.svc.RemoveLoaner(r.Context(), deviceID); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetOverdue(w http.ResponseWriter, r *http.Request) {
+	loans, err := h.svc.GetOverdue(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(loans)
+}
+
+func (h *Handler) GetReservation(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	reservation, err := h.svc.GetReservation(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reservation)
+}
+
+// GetReservations lists reservations filtered by ?device_id=, ?type_id=,
+// ?owner_id= and ?status=. ?from= and ?to= (RFC 3339 or YYYY-MM-DD) keep the
+// ones overlapping that window, which gives a calendar view.
+func (h *Handler) GetReservations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := Filter{
+		DeviceID: query.Get("device_id"),
+		TypeID:   query.Get("type_id"),
+		OwnerID:  query.Get("owner_id"),
+		Status:   query.Get("status"),
+	}
+	var err error
+	if filter.From, err = parseTime("from", query.Get("from")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if filter.To, err = parseTime("to", query.Get("to")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	reservations, err := h.svc.GetReservations(r.Context(), filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reservations)
+}
+
+func parseTime(name string, raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("%s must be a time like 2006-01-02T15:04:05Z or a date like 2006-01-02", name)
+}
+
+func (h *Handler) Reserve(w http.ResponseWriter, r *http.Request) {
+	var reservation utils.Reservation
+	if err := json.NewDecoder(r.Body).Decode(&reservation); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	if err := h.svc.Reserve(r.Context(), &reservation); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(reservation)
+}
+
+func (h *Handler) CancelReservation(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	reservation, err := h.svc.CancelReservation(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(reservation)
+}
+
+func (h *Handler) Checkout(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var handover Handover
+	if err := json.NewDecoder(r.Body).Decode(&handover); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	assignment, err := h.svc.Checkout(r.Context(), id, &handover)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(assignment)
+}
+
+func (h *Handler) Return(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var handover Handover
+	if err := json.NewDecoder(r.Body).Decode(&handover); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	assignment, err := h.svc.Return(r.Context(), id, &handover)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(assignment)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrInvalidRequest):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrUnavailable), errors.Is(err, ErrWrongStatus), errors.Is(err, ErrLoanerInUse):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
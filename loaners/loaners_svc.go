@@ -0,0 +1,470 @@
+package loaners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rickCrz7/Inventory-API/devices/logs"
+	"github.com/rickCrz7/Inventory-API/owners"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+const (
+	logTypeCheckout = "checkout"
+	logTypeReturn   = "return"
+	// exclusionViolation is the SQLSTATE of a conflicting reservation.
+	exclusionViolation = "23P01"
+)
+
+var (
+	ErrInvalidRequest = errors.New("invalid loaner request")
+	// ErrUnavailable is returned when no loaner is free for the requested
+	// window, the device is not in an available status, or the reserved
+	// device is still out with someone else.
+	ErrUnavailable = errors.New("loaner is not available")
+	// ErrWrongStatus is returned when a reservation is not in a status that
+	// allows the change, e.g. returning one that was never checked out.
+	ErrWrongStatus = errors.New("reservation status does not allow this")
+	// ErrLoanerInUse is returned when adding a device that is already in the
+	// pool or removing one with upcoming reservations.
+	ErrLoanerInUse = errors.New("loaner is in use")
+)
+
+// availableStatuses are the device statuses a loaner can be given out in.
+// Loaners that are lost, in repair or in any other status are skipped when
+// picking one for a type.
+var availableStatuses = []string{utils.DeviceStatusActive}
+
+// checkAvailable returns ErrUnavailable unless the device is in one of the
+// availableStatuses.
+func (s *Service) checkAvailable(ctx context.Context, tx pgx.Tx, deviceID string) error {
+	status, err := s.dao.GetDeviceStatus(ctx, tx, deviceID)
+	if err != nil {
+		return err
+	}
+	for _, available := range availableStatuses {
+		if status == available {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: device %s is %s", ErrUnavailable, deviceID, status)
+}
+
+// Handover records who handed a loaner out or took it back.
+type Handover struct {
+	HandledBy string `json:"handled_by"`
+	Note      string `json:"note"`
+}
+
+func validateReservation(reservation *utils.Reservation, now time.Time) error {
+	if reservation.DeviceID == "" && reservation.TypeID == "" {
+		return fmt.Errorf("%w: device_id or type_id is required", ErrInvalidRequest)
+	}
+	if reservation.OwnerID == "" {
+		return fmt.Errorf("%w: owner_id is required", ErrInvalidRequest)
+	}
+	if reservation.CreatedBy == "" {
+		return fmt.Errorf("%w: created_by is required", ErrInvalidRequest)
+	}
+	if reservation.StartsAt.IsZero() || reservation.EndsAt.IsZero() {
+		return fmt.Errorf("%w: starts_at and ends_at are required", ErrInvalidRequest)
+	}
+	if !reservation.EndsAt.After(reservation.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidRequest)
+	}
+	if !reservation.EndsAt.After(now) {
+		return fmt.Errorf("%w: the reservation window has already passed", ErrInvalidRequest)
+	}
+	return nil
+}
+
+func isExclusionViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == exclusionViolation
+}
+
+type Service struct {
+	dao       *Dao
+	ownersDao *owners.Dao
+	logsDao   *logs.Dao
+	pdb       *utils.DB
+}
+
+func NewService(dao *Dao, pdb *utils.DB) *Service {
+	return &Service{
+		dao:       dao,
+		ownersDao: owners.NewDao(),
+		logsDao:   logs.NewDao(),
+		pdb:       pdb,
+	}
+}
+
+func (s *Service) GetLoaners(ctx context.Context) ([]*utils.Loaner, error) {
+	ctx, span := tracing.Start(ctx, "loaners.GetLoaners")
+	defer span.End()
+
+	var loaners []*utils.Loaner
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		loaners, err = s.dao.GetLoaners(ctx, tx)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get loaners: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loaners, nil
+}
+
+// AddLoaner puts a device in the loaner pool.
+func (s *Service) AddLoaner(ctx context.Context, loaner *utils.Loaner) error {
+	ctx, span := tracing.Start(ctx, "loaners.AddLoaner")
+	defer span.End()
+
+	if loaner.DeviceID == "" {
+		return fmt.Errorf("%w: device_id is required", ErrInvalidRequest)
+	}
+	if loaner.AddedBy == "" {
+		return fmt.Errorf("%w: added_by is required", ErrInvalidRequest)
+	}
+	loaner.AddedAt = time.Now()
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if _, err := s.dao.GetDeviceType(ctx, tx, loaner.DeviceID); errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: device %s does not exist", ErrInvalidRequest, loaner.DeviceID)
+		} else if err != nil {
+			return err
+		}
+		if _, err := s.dao.GetLoaner(ctx, tx, loaner.DeviceID); err == nil {
+			return fmt.Errorf("%w: device %s is already a loaner", ErrLoanerInUse, loaner.DeviceID)
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+		if err := s.dao.AddLoaner(ctx, tx, loaner); err != nil {
+			utils.Log(ctx).Errorf("Failed to add loaner: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+// RemoveLoaner takes a device out of the pool once it has no upcoming or
+// checked out reservations.
+func (s *Service) RemoveLoaner(ctx context.Context, deviceID string) error {
+	ctx, span := tracing.Start(ctx, "loaners.RemoveLoaner")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		count, err := s.dao.CountUpcoming(ctx, tx, deviceID, time.Now())
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: %d upcoming reservations", ErrLoanerInUse, count)
+		}
+		if err := s.dao.RemoveLoaner(ctx, tx, deviceID); err != nil {
+			utils.Log(ctx).Errorf("Failed to remove loaner: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+func (s *Service) GetReservation(ctx context.Context, id string) (*utils.Reservation, error) {
+	ctx, span := tracing.Start(ctx, "loaners.GetReservation")
+	defer span.End()
+
+	var reservation *utils.Reservation
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		reservation, err = s.dao.GetReservation(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get reservation: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// GetReservations lists reservations in start order. With From and To set it
+// is the calendar of bookings overlapping that window.
+func (s *Service) GetReservations(ctx context.Context, filter Filter) ([]*utils.Reservation, error) {
+	ctx, span := tracing.Start(ctx, "loaners.GetReservations")
+	defer span.End()
+
+	if filter.From != nil && filter.To != nil && !filter.To.After(*filter.From) {
+		return nil, fmt.Errorf("%w: to must be after from", ErrInvalidRequest)
+	}
+	var reservations []*utils.Reservation
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		reservations, err = s.dao.GetReservations(ctx, tx, filter)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get reservations: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// Reserve books a loaner for an owner. A reservation for a device needs that
+// device to be in the pool; one for a type is given the first loaner of the
+// type that is free for the whole window. Overlaps are rejected by the
+// database, so two concurrent bookings cannot both win.
+func (s *Service) Reserve(ctx context.Context, reservation *utils.Reservation) error {
+	ctx, span := tracing.Start(ctx, "loaners.Reserve")
+	defer span.End()
+
+	now := time.Now()
+	if err := validateReservation(reservation, now); err != nil {
+		return err
+	}
+	reservation.ID = ""
+	reservation.Status = utils.ReservationReserved
+	reservation.CreatedAt = now
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		return s.reserve(ctx, tx, reservation)
+	})
+}
+
+// CancelReservation cancels a reservation that has not been checked out,
+// freeing its window.
+func (s *Service) CancelReservation(ctx context.Context, id string) (*utils.Reservation, error) {
+	ctx, span := tracing.Start(ctx, "loaners.CancelReservation")
+	defer span.End()
+
+	var reservation *utils.Reservation
+	err := s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		reservation, err = s.dao.LockReservation(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if reservation.Status != utils.ReservationReserved {
+			return fmt.Errorf("%w: reservations that are %s cannot be cancelled", ErrWrongStatus, reservation.Status)
+		}
+		reservation.Status = utils.ReservationCancelled
+		return s.dao.SetReservationStatus(ctx, tx, id, reservation.Status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// Checkout hands the reserved device to the owner during the reservation,
+// opening an assignment due back at its end.
+func (s *Service) Checkout(ctx context.Context, id string, handover *Handover) (*utils.DeviceAssignment, error) {
+	ctx, span := tracing.Start(ctx, "loaners.Checkout")
+	defer span.End()
+
+	if handover.HandledBy == "" {
+		return nil, fmt.Errorf("%w: handled_by is required", ErrInvalidRequest)
+	}
+	var assignment *utils.DeviceAssignment
+	err := s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		assignment, err = s.checkout(ctx, tx, id, handover)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// Return closes the assignment of a checked out reservation.
+func (s *Service) Return(ctx context.Context, id string, handover *Handover) (*utils.DeviceAssignment, error) {
+	ctx, span := tracing.Start(ctx, "loaners.Return")
+	defer span.End()
+
+	if handover.HandledBy == "" {
+		return nil, fmt.Errorf("%w: handled_by is required", ErrInvalidRequest)
+	}
+	var assignment *utils.DeviceAssignment
+	err := s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		assignment, err = s.returnLoan(ctx, tx, id, handover)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// reserve does the work of Reserve inside tx.
+func (s *Service) reserve(ctx context.Context, tx pgx.Tx, reservation *utils.Reservation) error {
+	if _, err := s.ownersDao.GetOwner(ctx, tx, reservation.OwnerID); errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: owner %s does not exist", ErrInvalidRequest, reservation.OwnerID)
+	} else if err != nil {
+		return err
+	}
+	if reservation.DeviceID != "" {
+		if _, err := s.dao.GetLoaner(ctx, tx, reservation.DeviceID); errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: device %s is not in the loaner pool", ErrInvalidRequest, reservation.DeviceID)
+		} else if err != nil {
+			return err
+		}
+		typeID, err := s.dao.GetDeviceType(ctx, tx, reservation.DeviceID)
+		if err != nil {
+			return err
+		}
+		if reservation.TypeID != "" && reservation.TypeID != typeID {
+			return fmt.Errorf("%w: device %s is not of type %s", ErrInvalidRequest, reservation.DeviceID, reservation.TypeID)
+		}
+		reservation.TypeID = typeID
+		if err := s.checkAvailable(ctx, tx, reservation.DeviceID); err != nil {
+			return err
+		}
+	} else {
+		deviceID, err := s.dao.FindFreeLoaner(ctx, tx, reservation.TypeID, availableStatuses, reservation.StartsAt, reservation.EndsAt, time.Now())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: no loaner of type %s is free for the whole window", ErrUnavailable, reservation.TypeID)
+		} else if err != nil {
+			return err
+		}
+		reservation.DeviceID = deviceID
+	}
+	err := s.dao.CreateReservation(ctx, tx, reservation)
+	if isExclusionViolation(err) {
+		return fmt.Errorf("%w: device %s is already booked during that window", ErrUnavailable, reservation.DeviceID)
+	}
+	return err
+}
+
+// checkout does the work of Checkout inside tx.
+func (s *Service) checkout(ctx context.Context, tx pgx.Tx, id string, handover *Handover) (*utils.DeviceAssignment, error) {
+	reservation, err := s.dao.LockReservation(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.Status != utils.ReservationReserved {
+		return nil, fmt.Errorf("%w: reservations that are %s cannot be checked out", ErrWrongStatus, reservation.Status)
+	}
+	now := time.Now()
+	if now.Before(reservation.StartsAt) {
+		return nil, fmt.Errorf("%w: the reservation starts at %s", ErrWrongStatus, reservation.StartsAt.Format(time.RFC3339))
+	}
+	if !reservation.EndsAt.After(now) {
+		return nil, fmt.Errorf("%w: the reservation ended at %s", ErrWrongStatus, reservation.EndsAt.Format(time.RFC3339))
+	}
+	if err := s.checkAvailable(ctx, tx, reservation.DeviceID); err != nil {
+		return nil, err
+	}
+	if open, err := s.dao.GetOpenAssignment(ctx, tx, reservation.DeviceID); err == nil {
+		return nil, fmt.Errorf("%w: device %s has not been returned by owner %s", ErrUnavailable, reservation.DeviceID, open.OwnerID)
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	assignment := &utils.DeviceAssignment{
+		DeviceID:      reservation.DeviceID,
+		OwnerID:       reservation.OwnerID,
+		ReservationID: &reservation.ID,
+		AssignedAt:    now,
+		AssignedBy:    handover.HandledBy,
+		DueAt:         &reservation.EndsAt,
+	}
+	if err := s.dao.CreateAssignment(ctx, tx, assignment); err != nil {
+		utils.Log(ctx).Errorf("Failed to create assignment: %v", err)
+		return nil, err
+	}
+	if err := s.dao.SetReservationStatus(ctx, tx, id, utils.ReservationCheckedOut); err != nil {
+		return nil, err
+	}
+	note := fmt.Sprintf("Checked out to owner %s until %s", reservation.OwnerID, reservation.EndsAt.Format(time.RFC3339))
+	if err := s.log(ctx, tx, reservation.DeviceID, logTypeCheckout, note, handover, now); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// returnLoan does the work of Return inside tx.
+func (s *Service) returnLoan(ctx context.Context, tx pgx.Tx, id string, handover *Handover) (*utils.DeviceAssignment, error) {
+	reservation, err := s.dao.LockReservation(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.Status != utils.ReservationCheckedOut {
+		return nil, fmt.Errorf("%w: reservations that are %s cannot be returned", ErrWrongStatus, reservation.Status)
+	}
+	assignment, err := s.dao.GetOpenAssignment(ctx, tx, reservation.DeviceID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Failed to get open assignment: %v", err)
+		return nil, err
+	}
+	now := time.Now()
+	if err := s.dao.ReturnAssignment(ctx, tx, assignment.ID, now, handover.HandledBy); err != nil {
+		return nil, err
+	}
+	assignment.ReturnedAt = &now
+	assignment.ReturnedBy = &handover.HandledBy
+	if err := s.dao.SetReservationStatus(ctx, tx, id, utils.ReservationReturned); err != nil {
+		return nil, err
+	}
+	note := fmt.Sprintf("Returned by owner %s", reservation.OwnerID)
+	if now.After(reservation.EndsAt) {
+		note += fmt.Sprintf(", %s late", now.Sub(reservation.EndsAt).Round(time.Minute))
+	}
+	if err := s.log(ctx, tx, reservation.DeviceID, logTypeReturn, note, handover, now); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func (s *Service) log(ctx context.Context, tx pgx.Tx, deviceID string, logType string, note string, handover *Handover, at time.Time) error {
+	if handover.Note != "" {
+		note += ": " + handover.Note
+	}
+	entry := &utils.DeviceLog{
+		DeviceID:  deviceID,
+		LogType:   logType,
+		Note:      note,
+		CreatedAt: at,
+		CreatedBy: handover.HandledBy,
+	}
+	if err := s.logsDao.CreateLog(ctx, tx, entry); err != nil {
+		utils.Log(ctx).Errorf("Failed to write %s log: %v", logType, err)
+		return err
+	}
+	return nil
+}
+
+// GetOverdue lists loans that are still out past the end of their
+// reservation.
+func (s *Service) GetOverdue(ctx context.Context) ([]*utils.OverdueLoan, error) {
+	ctx, span := tracing.Start(ctx, "loaners.GetOverdue")
+	defer span.End()
+
+	var loans []*utils.OverdueLoan
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		loans, err = s.dao.GetOverdue(ctx, tx, time.Now())
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get overdue loans: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loans, nil
+}
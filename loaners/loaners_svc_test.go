@@ -0,0 +1,49 @@
+package loaners
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+func TestValidateReservation(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	reservation := func() *utils.Reservation {
+		return &utils.Reservation{
+			TypeID:    "laptop",
+			OwnerID:   "owner1",
+			StartsAt:  now.Add(24 * time.Hour),
+			EndsAt:    now.Add(72 * time.Hour),
+			CreatedBy: "helpdesk",
+		}
+	}
+	t.Run("Validate", func(t *testing.T) {
+		if err := validateReservation(reservation(), now); err != nil {
+			t.Fatalf("Expected reservation to be valid, got %v", err)
+		}
+	})
+	t.Run("ValidateNoDeviceOrType", func(t *testing.T) {
+		r := reservation()
+		r.TypeID = ""
+		if err := validateReservation(r, now); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+	t.Run("ValidateEndsBeforeStart", func(t *testing.T) {
+		r := reservation()
+		r.EndsAt = r.StartsAt
+		if err := validateReservation(r, now); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+	t.Run("ValidatePast", func(t *testing.T) {
+		r := reservation()
+		r.StartsAt = now.Add(-72 * time.Hour)
+		r.EndsAt = now.Add(-time.Hour)
+		if err := validateReservation(r, now); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+}
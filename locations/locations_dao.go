@@ -0,0 +1,227 @@
+package locations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Dao struct{}
+
+func NewDao() *Dao {
+	return &Dao{}
+}
+
+func scanLocations(ctx context.Context, rows pgx.Rows) ([]*utils.Location, error) {
+	defer rows.Close()
+	var locations []*utils.Location
+	for rows.Next() {
+		var location utils.Location
+		if err := rows.Scan(&location.ID, &location.ParentID, &location.Name, &location.Kind, &location.Description); err != nil {
+			utils.Log(ctx).Errorf("Error scanning location row: %v", err)
+			return nil, err
+		}
+		locations = append(locations, &location)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over location rows: %v", err)
+		return nil, err
+	}
+	return locations, nil
+}
+
+func (d *Dao) GetLocation(ctx context.Context, tx pgx.Tx, id string) (*utils.Location, error) {
+	utils.Log(ctx).Printf("Fetching location with ID: %s", id)
+	query := `SELECT id, parent_id, name, kind, description FROM locations WHERE id = $1`
+	var location utils.Location
+	err := tx.QueryRow(ctx, query, id).Scan(&location.ID, &location.ParentID, &location.Name, &location.Kind, &location.Description)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching location with ID %s: %v", id, err)
+		return nil, err
+	}
+	return &location, nil
+}
+
+func (d *Dao) GetLocations(ctx context.Context, tx pgx.Tx) ([]*utils.Location, error) {
+	utils.Log(ctx).Println("Fetching all locations")
+	query := `SELECT id, parent_id, name, kind, description FROM locations ORDER BY name`
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching locations: %v", err)
+		return nil, err
+	}
+	return scanLocations(ctx, rows)
+}
+
+// GetPath returns the location and its ancestors, starting at the top.
+func (d *Dao) GetPath(ctx context.Context, tx pgx.Tx, id string) ([]*utils.Location, error) {
+	utils.Log(ctx).Printf("Fetching path of location with ID: %s", id)
+	query := `WITH RECURSIVE path AS (
+		SELECT id, parent_id, name, kind, description, 0 AS depth
+		FROM locations
+		WHERE id = $1
+		UNION
+		SELECT l.id, l.parent_id, l.name, l.kind, l.description, p.depth + 1
+		FROM locations l
+		JOIN path p ON l.id = p.parent_id
+	)
+	SELECT id, parent_id, name, kind, description FROM path ORDER BY depth DESC`
+	rows, err := tx.Query(ctx, query, id)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching path of location with ID %s: %v", id, err)
+		return nil, err
+	}
+	return scanLocations(ctx, rows)
+}
+
+// IsWithin reports whether id is ancestorID or one of its sub-locations.
+func (d *Dao) IsWithin(ctx context.Context, tx pgx.Tx, id string, ancestorID string) (bool, error) {
+	utils.Log(ctx).Printf("Checking whether location %s is within %s", id, ancestorID)
+	query := `WITH RECURSIVE ancestors AS (
+		SELECT id, parent_id FROM locations WHERE id = $1
+		UNION
+		SELECT l.id, l.parent_id
+		FROM locations l
+		JOIN ancestors a ON l.id = a.parent_id
+	)
+	SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2)`
+	var within bool
+	if err := tx.QueryRow(ctx, query, id, ancestorID).Scan(&within); err != nil {
+		utils.Log(ctx).Errorf("Error checking ancestors of location %s: %v", id, err)
+		return false, err
+	}
+	return within, nil
+}
+
+func (d *Dao) CreateLocation(ctx context.Context, tx pgx.Tx, location *utils.Location) error {
+	utils.Log(ctx).Printf("Creating location: %+v", location)
+	if location.ID == "" {
+		id, err := gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Error generating ID for new location: %v", err)
+			return err
+		}
+		location.ID = id
+	}
+	query := `INSERT INTO locations (id, parent_id, name, kind, description) VALUES ($1, $2, $3, $4, $5)`
+	_, err := tx.Exec(ctx, query, location.ID, location.ParentID, location.Name, location.Kind, location.Description)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error creating location: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (d *Dao) UpdateLocation(ctx context.Context, tx pgx.Tx, location *utils.Location) error {
+	utils.Log(ctx).Printf("Updating location: %+v", location)
+	query := `UPDATE locations SET parent_id = $2, name = $3, kind = $4, description = $5 WHERE id = $1`
+	tag, err := tx.Exec(ctx, query, location.ID, location.ParentID, location.Name, location.Kind, location.Description)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error updating location with ID %s: %v", location.ID, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (d *Dao) DeleteLocation(ctx context.Context, tx pgx.Tx, id string) error {
+	utils.Log(ctx).Printf("Deleting location with ID: %s", id)
+	query := `DELETE FROM locations WHERE id = $1`
+	tag, err := tx.Exec(ctx, query, id)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error deleting location with ID %s: %v", id, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// CountUsage counts the direct sub-locations and devices of a location, the
+// consumable stock movements in or out of it and the audits scoped to it or
+// with scans recorded in it.
+func (d *Dao) CountUsage(ctx context.Context, tx pgx.Tx, id string) (int, int, int, int, error) {
+	utils.Log(ctx).Printf("Counting usage of location with ID: %s", id)
+	query := `SELECT
+		(SELECT count(*) FROM locations WHERE parent_id = $1),
+		(SELECT count(*) FROM devices WHERE location_id = $1),
+		(SELECT count(*) FROM consumable_movements WHERE from_location_id = $1 OR to_location_id = $1),
+		(SELECT count(*) FROM audits WHERE location_id = $1
+			OR id IN (SELECT audit_id FROM audit_scans WHERE location_id = $1))`
+	var children, devices, movements, audits int
+	if err := tx.QueryRow(ctx, query, id).Scan(&children, &devices, &movements, &audits); err != nil {
+		utils.Log(ctx).Errorf("Error counting usage of location %s: %v", id, err)
+		return 0, 0, 0, 0, err
+	}
+	return children, devices, movements, audits, nil
+}
+
+// GetDevices returns the devices in a location and, when recursive is set,
+// in all of its sub-locations.
+func (d *Dao) GetDevices(ctx context.Context, tx pgx.Tx, id string, recursive bool) ([]*utils.Device, error) {
+	utils.Log(ctx).Printf("Fetching devices in location %s (recursive: %t)", id, recursive)
+	query := `WITH RECURSIVE tree AS (
+		SELECT id FROM locations WHERE id = $1
+		UNION
+		SELECT l.id
+		FROM locations l
+		JOIN tree t ON l.parent_id = t.id
+		WHERE $2
+	)
+	SELECT id, serial_number, name, type_id, owner_id, location_id, cost_center, purchase_date, purchase_cost, currency, vendor, po_number, invoice_ref, status, asset_tag
+	FROM devices
+	WHERE location_id IN (SELECT id FROM tree)
+	ORDER BY name`
+	rows, err := tx.Query(ctx, query, id, recursive)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching devices in location %s: %v", id, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var devices []*utils.Device
+	for rows.Next() {
+		var device utils.Device
+		if err := rows.Scan(&device.ID, &device.SerialNumber, &device.Name, &device.TypeID, &device.OwnerID, &device.LocationID, &device.CostCenter, &device.PurchaseDate, &device.PurchaseCost, &device.Currency, &device.Vendor, &device.PONumber, &device.InvoiceRef, &device.Status, &device.AssetTag); err != nil {
+			utils.Log(ctx).Errorf("Error scanning device row: %v", err)
+			return nil, err
+		}
+		devices = append(devices, &device)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over device rows: %v", err)
+		return nil, err
+	}
+	return devices, nil
+}
+
+// GetDeviceLocation locks the device row and returns its current location.
+func (d *Dao) GetDeviceLocation(ctx context.Context, tx pgx.Tx, deviceID string) (*string, error) {
+	utils.Log(ctx).Printf("Fetching location of device with ID: %s", deviceID)
+	query := `SELECT location_id FROM devices WHERE id = $1 FOR UPDATE`
+	var locationID *string
+	if err := tx.QueryRow(ctx, query, deviceID).Scan(&locationID); err != nil {
+		utils.Log(ctx).Errorf("Error fetching location of device %s: %v", deviceID, err)
+		return nil, err
+	}
+	return locationID, nil
+}
+
+func (d *Dao) SetDeviceLocation(ctx context.Context, tx pgx.Tx, deviceID string, locationID *string) error {
+	utils.Log(ctx).Printf("Moving device %s to location %v", deviceID, locationID)
+	query := `UPDATE devices SET location_id = $2 WHERE id = $1`
+	tag, err := tx.Exec(ctx, query, deviceID, locationID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error moving device %s: %v", deviceID, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package locations
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+var postgresURI string
+
+func init() {
+	viper.SetConfigName("app")
+	viper.AddConfigPath("../config")
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+	postgresURI = viper.GetString("postgres.dev") // Change to "postgres.dev" for development/local db
+	log.SetReportCaller(true)
+	log.SetFormatter(&log.TextFormatter{
+		ForceColors:     true,
+		FullTimestamp:   true,
+		TimestampFormat: "2006/01/02 15:04:05",
+		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
+			filename := path.Base(f.File)
+			return fmt.Sprintf("%s()", f.Function), fmt.Sprintf("\t%s:%d", filename, f.Line)
+		},
+	})
+	log.SetLevel(log.DebugLevel)
+}
+
+// insertTree inserts a building with a floor and a room on that floor, and
+// returns their IDs top down.
+func insertTree(t *testing.T, ctx context.Context, tx pgx.Tx) (string, string, string) {
+	t.Helper()
+	dao := NewDao()
+	building := &utils.Location{Name: "Test Building", Kind: utils.LocationKindBuilding}
+	if err := dao.CreateLocation(ctx, tx, building); err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	floor := &utils.Location{ParentID: &building.ID, Name: "Test Floor", Kind: utils.LocationKindFloor}
+	if err := dao.CreateLocation(ctx, tx, floor); err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	room := &utils.Location{ParentID: &floor.ID, Name: "Test Room", Kind: utils.LocationKindRoom}
+	if err := dao.CreateLocation(ctx, tx, room); err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	return building.ID, floor.ID, room.ID
+}
+
+func TestGetPath(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+	buildingID, floorID, roomID := insertTree(t, ctx, tx)
+
+	t.Run("GetPath", func(t *testing.T) {
+		path, err := dao.GetPath(ctx, tx, roomID)
+		if err != nil {
+			t.Fatalf("Error getting path: %v", err)
+		}
+		if len(path) != 3 {
+			t.Fatalf("Expected 3 locations, got %d", len(path))
+		}
+		if path[0].ID != buildingID || path[1].ID != floorID || path[2].ID != roomID {
+			t.Errorf("Expected path building, floor, room, got %s, %s, %s", path[0].ID, path[1].ID, path[2].ID)
+		}
+	})
+	t.Run("IsWithin", func(t *testing.T) {
+		within, err := dao.IsWithin(ctx, tx, roomID, buildingID)
+		if err != nil {
+			t.Fatalf("Error checking ancestors: %v", err)
+		}
+		if !within {
+			t.Error("Expected room to be within building")
+		}
+		within, err = dao.IsWithin(ctx, tx, buildingID, roomID)
+		if err != nil {
+			t.Fatalf("Error checking ancestors: %v", err)
+		}
+		if within {
+			t.Error("Expected building not to be within room")
+		}
+	})
+}
+
+func TestGetDevices(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+	buildingID, _, roomID := insertTree(t, ctx, tx)
+
+	// Mock Data
+	id, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO owners (id, first_name, last_name, email)
+		VALUES ($1, $2, $3, $4)
+	`, id, "John", "Doe", "john.doe@example.com")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	t_id, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO types (id, name, description) VALUES ($1, $2, $3)
+	`, t_id, "Test Type", "This is a test type")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	d_id, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO devices (id, serial_number, name, purchase_date, status, owner_id, type_id, location_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, d_id, "SN123456", "Test Device", "2023-01-01", "active", id, t_id, roomID)
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+
+	t.Run("GetDevicesRecursive", func(t *testing.T) {
+		devices, err := dao.GetDevices(ctx, tx, buildingID, true)
+		if err != nil {
+			t.Fatalf("Error getting devices: %v", err)
+		}
+		if len(devices) != 1 || devices[0].ID != d_id {
+			t.Fatalf("Expected device %s in building, got %d devices", d_id, len(devices))
+		}
+	})
+	t.Run("GetDevicesDirect", func(t *testing.T) {
+		devices, err := dao.GetDevices(ctx, tx, buildingID, false)
+		if err != nil {
+			t.Fatalf("Error getting devices: %v", err)
+		}
+		if len(devices) != 0 {
+			t.Fatalf("Expected no devices directly in building, got %d", len(devices))
+		}
+	})
+	t.Run("CountUsage", func(t *testing.T) {
+		children, devices, movements, audits, err := dao.CountUsage(ctx, tx, roomID)
+		if err != nil {
+			t.Fatalf("Error counting usage: %v", err)
+		}
+		if children != 0 || devices != 1 || movements != 0 || audits != 0 {
+			t.Errorf("Expected 0 sub-locations, 1 device, 0 movements and 0 audits, got %d, %d, %d and %d", children, devices, movements, audits)
+		}
+	})
+	t.Run("CountUsageAudits", func(t *testing.T) {
+		auditID, _ := gonanoid.New()
+		_, err := tx.Exec(ctx, `
+			INSERT INTO audits (id, name, location_id, created_at, created_by) VALUES ($1, $2, $3, now(), $4)
+		`, auditID, "Test Audit", buildingID, "tester")
+		if err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+		scanID, _ := gonanoid.New()
+		_, err = tx.Exec(ctx, `
+			INSERT INTO audit_scans (id, audit_id, code, location_id, scanned_at, scanned_by) VALUES ($1, $2, $3, $4, now(), $5)
+		`, scanID, auditID, "SN123456", roomID, "tester")
+		if err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+		for _, id := range []string{buildingID, roomID} {
+			_, _, _, audits, err := dao.CountUsage(ctx, tx, id)
+			if err != nil {
+				t.Fatalf("Error counting usage: %v", err)
+			}
+			if audits != 1 {
+				t.Errorf("Expected location %s to be used by 1 audit, got %d", id, audits)
+			}
+		}
+	})
+	t.Run("SetDeviceLocation", func(t *testing.T) {
+		if err := dao.SetDeviceLocation(ctx, tx, d_id, nil); err != nil {
+			t.Fatalf("Error moving device: %v", err)
+		}
+		locationID, err := dao.GetDeviceLocation(ctx, tx, d_id)
+		if err != nil {
+			t.Fatalf("Error getting device location: %v", err)
+		}
+		if locationID != nil {
+			t.Errorf("Expected device to have no location, got %s", *locationID)
+		}
+	})
+}
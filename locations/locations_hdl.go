@@ -0,0 +1,152 @@
+package locations
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Handler struct {
+	svc *Service
+	// atz *authz.Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{
+		svc: svc,
+		// atz: atz,
+	}
+}
+
+func (h *Handler) GetLocation(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	location, err := h.svc.GetLocation(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(location)
+}
+
+func (h *Handler) GetLocations(w http.ResponseWriter, r *http.Request) {
+	locations, err := h.svc.GetLocations(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(locations)
+}
+
+func (h *Handler) GetPath(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	path, err := h.svc.GetPath(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(path)
+}
+
+func (h *Handler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	var location utils.Location
+	if err := json.NewDecoder(r.Body).Decode(&location); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	if err := h.svc.CreateLocation(r.Context(), &location); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(location)
+}
+
+func (h *Handler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	var location utils.Location
+	if err := json.NewDecoder(r.Body).Decode(&location); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	location.ID = mux.Vars(r)["id"]
+	if err := h.svc.UpdateLocation(r.Context(), &location); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(location)
+}
+
+func (h *Handler) DeleteLocation(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if err := h.svc.DeleteLocation(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDevices lists the devices in a location and its sub-locations, or only
+// in the location itself with ?recursive=false.
+func (h *Handler) GetDevices(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	recursive := true
+	if raw := r.URL.Query().Get("recursive"); raw != "" {
+		var err error
+		recursive, err = strconv.ParseBool(raw)
+		if err != nil {
+			http.Error(w, "recursive must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+	devices, err := h.svc.GetDevices(r.Context(), id, recursive)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(devices)
+}
+
+func (h *Handler) MoveDevice(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var move Move
+	if err := json.NewDecoder(r.Body).Decode(&move); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	entry, err := h.svc.MoveDevice(r.Context(), id, &move)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(entry)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrInvalidRequest):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrLocationInUse):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
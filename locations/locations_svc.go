@@ -0,0 +1,328 @@
+package locations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/devices/logs"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+const logTypeMove = "move"
+
+var (
+	ErrInvalidRequest = errors.New("invalid location request")
+	// ErrLocationInUse is returned when deleting a location that still has
+	// sub-locations or devices, or that appears in the consumable stock
+	// ledger or an audit.
+	ErrLocationInUse = errors.New("location is still in use")
+)
+
+var kinds = []string{
+	utils.LocationKindCampus,
+	utils.LocationKindBuilding,
+	utils.LocationKindFloor,
+	utils.LocationKindRoom,
+	utils.LocationKindOther,
+}
+
+func validate(location *utils.Location) error {
+	if strings.TrimSpace(location.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRequest)
+	}
+	for _, kind := range kinds {
+		if location.Kind == kind {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: kind must be one of %s", ErrInvalidRequest, strings.Join(kinds, ", "))
+}
+
+// Move puts a device in a location, or takes it out of any location when
+// LocationID is nil.
+type Move struct {
+	LocationID *string `json:"location_id"`
+	MovedBy    string  `json:"moved_by"`
+	Note       string  `json:"note"`
+}
+
+type Service struct {
+	dao     *Dao
+	logsDao *logs.Dao
+	pdb     *utils.DB
+}
+
+func NewService(dao *Dao, pdb *utils.DB) *Service {
+	return &Service{
+		dao:     dao,
+		logsDao: logs.NewDao(),
+		pdb:     pdb,
+	}
+}
+
+func (s *Service) GetLocation(ctx context.Context, id string) (*utils.Location, error) {
+	ctx, span := tracing.Start(ctx, "locations.GetLocation")
+	defer span.End()
+
+	var location *utils.Location
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		location, err = s.dao.GetLocation(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get location: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return location, nil
+}
+
+func (s *Service) GetLocations(ctx context.Context) ([]*utils.Location, error) {
+	ctx, span := tracing.Start(ctx, "locations.GetLocations")
+	defer span.End()
+
+	var locations []*utils.Location
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		locations, err = s.dao.GetLocations(ctx, tx)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get locations: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return locations, nil
+}
+
+// GetPath returns the location and its ancestors, starting at the top, e.g.
+// campus, building, floor, room.
+func (s *Service) GetPath(ctx context.Context, id string) ([]*utils.Location, error) {
+	ctx, span := tracing.Start(ctx, "locations.GetPath")
+	defer span.End()
+
+	var path []*utils.Location
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		path, err = s.dao.GetPath(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get location path: %v", err)
+			return err
+		}
+		if len(path) == 0 {
+			return pgx.ErrNoRows
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return path, nil
+}
+
+func (s *Service) CreateLocation(ctx context.Context, location *utils.Location) error {
+	ctx, span := tracing.Start(ctx, "locations.CreateLocation")
+	defer span.End()
+
+	if err := validate(location); err != nil {
+		return err
+	}
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.checkParent(ctx, tx, location); err != nil {
+			return err
+		}
+		if err := s.dao.CreateLocation(ctx, tx, location); err != nil {
+			utils.Log(ctx).Errorf("Failed to create location: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+func (s *Service) UpdateLocation(ctx context.Context, location *utils.Location) error {
+	ctx, span := tracing.Start(ctx, "locations.UpdateLocation")
+	defer span.End()
+
+	if err := validate(location); err != nil {
+		return err
+	}
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.checkParent(ctx, tx, location); err != nil {
+			return err
+		}
+		if err := s.dao.UpdateLocation(ctx, tx, location); err != nil {
+			utils.Log(ctx).Errorf("Failed to update location: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+// checkParent makes sure the parent exists and is not the location itself or
+// one of its sub-locations, which would turn the tree into a cycle.
+func (s *Service) checkParent(ctx context.Context, tx pgx.Tx, location *utils.Location) error {
+	if location.ParentID == nil {
+		return nil
+	}
+	parentID := *location.ParentID
+	if _, err := s.dao.GetLocation(ctx, tx, parentID); errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: parent location %s does not exist", ErrInvalidRequest, parentID)
+	} else if err != nil {
+		return err
+	}
+	if location.ID == "" {
+		return nil
+	}
+	within, err := s.dao.IsWithin(ctx, tx, parentID, location.ID)
+	if err != nil {
+		return err
+	}
+	if within {
+		return fmt.Errorf("%w: a location cannot be moved under itself or its sub-locations", ErrInvalidRequest)
+	}
+	return nil
+}
+
+func (s *Service) DeleteLocation(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "locations.DeleteLocation")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		children, devices, movements, audits, err := s.dao.CountUsage(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to count location usage: %v", err)
+			return err
+		}
+		if children > 0 || devices > 0 || movements > 0 || audits > 0 {
+			return fmt.Errorf("%w: %d sub-locations, %d devices, %d consumable movements, %d audits", ErrLocationInUse, children, devices, movements, audits)
+		}
+		if err := s.dao.DeleteLocation(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Failed to delete location: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+// GetDevices lists the devices in a location, including those in its
+// sub-locations when recursive is set.
+func (s *Service) GetDevices(ctx context.Context, id string, recursive bool) ([]*utils.Device, error) {
+	ctx, span := tracing.Start(ctx, "locations.GetDevices")
+	defer span.End()
+
+	var devices []*utils.Device
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		if _, err := s.dao.GetLocation(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Failed to get location: %v", err)
+			return err
+		}
+		var err error
+		devices, err = s.dao.GetDevices(ctx, tx, id, recursive)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get devices in location: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
+
+// MoveDevice changes the location of a device and records the move in the
+// device log, which it returns.
+func (s *Service) MoveDevice(ctx context.Context, deviceID string, move *Move) (*utils.DeviceLog, error) {
+	ctx, span := tracing.Start(ctx, "locations.MoveDevice")
+	defer span.End()
+
+	if move.MovedBy == "" {
+		return nil, fmt.Errorf("%w: moved_by is required", ErrInvalidRequest)
+	}
+
+	var entry *utils.DeviceLog
+	err := s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		from, err := s.dao.GetDeviceLocation(ctx, tx, deviceID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get device location: %v", err)
+			return err
+		}
+		if sameLocation(from, move.LocationID) {
+			return fmt.Errorf("%w: device is already there", ErrInvalidRequest)
+		}
+
+		fromName, err := s.describe(ctx, tx, from)
+		if err != nil {
+			return err
+		}
+		toName, err := s.describe(ctx, tx, move.LocationID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: location %s does not exist", ErrInvalidRequest, *move.LocationID)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := s.dao.SetDeviceLocation(ctx, tx, deviceID, move.LocationID); err != nil {
+			utils.Log(ctx).Errorf("Failed to move device: %v", err)
+			return err
+		}
+		note := fmt.Sprintf("Moved from %s to %s", fromName, toName)
+		if move.Note != "" {
+			note += ": " + move.Note
+		}
+		entry = &utils.DeviceLog{
+			DeviceID:  deviceID,
+			LogType:   logTypeMove,
+			Note:      note,
+			CreatedAt: time.Now(),
+			CreatedBy: move.MovedBy,
+		}
+		if err := s.logsDao.CreateLog(ctx, tx, entry); err != nil {
+			utils.Log(ctx).Errorf("Failed to write move log: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// describe names a location by its full path, e.g. "Main / Science Hall /
+// 2 / 204".
+func (s *Service) describe(ctx context.Context, tx pgx.Tx, id *string) (string, error) {
+	if id == nil {
+		return "no location", nil
+	}
+	path, err := s.dao.GetPath(ctx, tx, *id)
+	if err != nil {
+		return "", err
+	}
+	if len(path) == 0 {
+		return "", pgx.ErrNoRows
+	}
+	names := make([]string, len(path))
+	for i, location := range path {
+		names[i] = location.Name
+	}
+	return strings.Join(names, " / "), nil
+}
+
+func sameLocation(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,51 @@
+package locations
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("Validate", func(t *testing.T) {
+		location := &utils.Location{Name: "Science Hall", Kind: utils.LocationKindBuilding}
+		if err := validate(location); err != nil {
+			t.Fatalf("Expected location to be valid, got %v", err)
+		}
+	})
+	t.Run("ValidateMissingName", func(t *testing.T) {
+		location := &utils.Location{Name: " ", Kind: utils.LocationKindRoom}
+		if err := validate(location); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+	t.Run("ValidateUnknownKind", func(t *testing.T) {
+		location := &utils.Location{Name: "Closet", Kind: "cupboard"}
+		if err := validate(location); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+}
+
+func TestSameLocation(t *testing.T) {
+	a, b := "a", "b"
+	other := "a"
+	cases := []struct {
+		name string
+		x, y *string
+		want bool
+	}{
+		{"BothNil", nil, nil, true},
+		{"OneNil", &a, nil, false},
+		{"Equal", &a, &other, true},
+		{"Different", &a, &b, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := sameLocation(c.x, c.y); got != c.want {
+				t.Errorf("Expected %t, got %t", c.want, got)
+			}
+		})
+	}
+}
@@ -11,49 +11,88 @@ import (
 	"os/signal"
 	"path"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5/pgxpool"
+	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/natefinch/lumberjack"
+	"github.com/rickCrz7/Inventory-API/audits"
+	"github.com/rickCrz7/Inventory-API/config"
+	"github.com/rickCrz7/Inventory-API/consumables"
+	"github.com/rickCrz7/Inventory-API/departments"
 	"github.com/rickCrz7/Inventory-API/devices"
+	"github.com/rickCrz7/Inventory-API/devices/components"
 	"github.com/rickCrz7/Inventory-API/devices/logs"
 	dev_properties "github.com/rickCrz7/Inventory-API/devices/properties"
+	"github.com/rickCrz7/Inventory-API/financials"
+	"github.com/rickCrz7/Inventory-API/graph"
+	"github.com/rickCrz7/Inventory-API/health"
+	"github.com/rickCrz7/Inventory-API/labels"
+	"github.com/rickCrz7/Inventory-API/limits"
+	"github.com/rickCrz7/Inventory-API/loaners"
+	"github.com/rickCrz7/Inventory-API/locations"
+	"github.com/rickCrz7/Inventory-API/metrics"
 	"github.com/rickCrz7/Inventory-API/owners"
+	"github.com/rickCrz7/Inventory-API/owners/directory"
+	"github.com/rickCrz7/Inventory-API/owners/offboarding"
+	"github.com/rickCrz7/Inventory-API/purchaseorders"
+	"github.com/rickCrz7/Inventory-API/repairs"
+	"github.com/rickCrz7/Inventory-API/rpc"
+	"github.com/rickCrz7/Inventory-API/rpc/inventorypb"
+	"github.com/rickCrz7/Inventory-API/server"
+	"github.com/rickCrz7/Inventory-API/tracing"
 	"github.com/rickCrz7/Inventory-API/types"
 	"github.com/rickCrz7/Inventory-API/types/properties"
+	"github.com/rickCrz7/Inventory-API/vendors"
 
 	"github.com/rickCrz7/Inventory-API/utils"
 	log "github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
+	"google.golang.org/grpc"
 )
 
 func main() {
 	devFlag := flag.Bool("dev", false, "is it running in development mode")
+	configPath := flag.String("config", "", "path to the configuration file (default config/app.yaml)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [config print]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
-	// Load configuration from config file
-	viper.SetConfigName("app")
-	viper.AddConfigPath("config")
-	err := viper.ReadInConfig()
+	// Load configuration from config file and environment
+	cfg, err := config.Load(*configPath)
 	if err != nil {
-		log.Panicf("Could not load app.yml configuration file: %v", err)
+		log.Fatalf("Could not load configuration: %v", err)
+	}
+	cfg.Dev = cfg.Dev || *devFlag
+
+	if args := flag.Args(); len(args) > 0 {
+		if len(args) == 2 && args[0] == "config" && args[1] == "print" {
+			os.Exit(printConfig(cfg))
+		}
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
 	}
 
 	// Setup logger
 	lumberjackLogrotate := &lumberjack.Logger{
-		Filename:   viper.GetString("log.file"),
-		MaxSize:    viper.GetInt("log.max-size"),    // Max megabytes before log is rotated
-		MaxBackups: viper.GetInt("log.max-backups"), // Max number of old log files to keep
-		MaxAge:     viper.GetInt("log.max-age"),     // Max number of days to retain log files
+		Filename:   cfg.Log.File,
+		MaxSize:    cfg.Log.MaxSize,    // Max megabytes before log is rotated
+		MaxBackups: cfg.Log.MaxBackups, // Max number of old log files to keep
+		MaxAge:     cfg.Log.MaxAge,     // Max number of days to retain log files
 		Compress:   false,
 	}
 
 	mode := "production"
-	postgresURI := viper.GetString("postgres.prod")
-	setLimits := true
-	if *devFlag {
+	if cfg.Dev {
 		log.SetReportCaller(true)
 		log.SetFormatter(&log.TextFormatter{
 			ForceColors:     true,
@@ -65,15 +104,13 @@ func main() {
 			},
 		})
 		mode = "development"
-		postgresURI = viper.GetString("postgres.dev")
-		setLimits = false
 	} else {
 		log.SetFormatter(&log.JSONFormatter{})
 	}
 
 	logMultiWriter := io.MultiWriter(os.Stdout, lumberjackLogrotate)
 	log.SetOutput(logMultiWriter)
-	switch viper.GetString("log.level") {
+	switch strings.ToUpper(cfg.Log.Level) {
 	case "INFO":
 		log.SetLevel(log.InfoLevel)
 	case "DEBUG":
@@ -88,14 +125,42 @@ func main() {
 		"Runtime Version": runtime.Version(),
 		"Number of CPUs":  runtime.NumCPU(),
 		"Arch":            runtime.GOARCH,
-	}).Infof("Starting %s", viper.GetString("app.name"))
+	}).Infof("Starting %s", cfg.App.Name)
+
+	// Setup tracing
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Could not setup tracing: %v", err)
+	}
+	log.AddHook(&tracing.LogHook{})
 
 	// Setup Postgres connection
 	log.Infof("connecting to Postgres: %s", mode)
-	pdb, err := utils.OpenDB(postgresURI, setLimits)
+	primary, err := utils.OpenDB(cfg.ConnString(), cfg.PoolConfig(), metrics.NewQueryTracer(), tracing.NewQueryTracer())
 	if err != nil {
 		log.Fatalf("Could not connect to Postgres: %v", err)
 	}
+	// A replica that is down at startup is skipped rather than fatal, since
+	// the primary can serve reads too.
+	var replica *pgxpool.Pool
+	if replicaConfig := cfg.Postgres.Replica; replicaConfig.URI != "" {
+		replica, err = utils.OpenDB(replicaConfig.URI, replicaConfig.Pool, metrics.NewQueryTracer(), tracing.NewQueryTracer())
+		if err != nil {
+			log.Errorf("Could not connect to Postgres replica, reading from primary: %v", err)
+		} else {
+			log.Printf("Connected to Postgres replica")
+		}
+	}
+	pdb := utils.NewDB(primary, replica, cfg.Postgres.Replica.MaxLag)
+	pdb.Retry = cfg.Postgres.Retry
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go pdb.Run(workersCtx, cfg.Postgres.Replica.CheckInterval)
+
+	if err := metrics.RegisterPool(pdb); err != nil {
+		log.Fatalf("Could not register pool metrics: %v", err)
+	}
 	err = pdb.Ping(context.Background())
 	if err != nil {
 		log.Fatalf("Could not ping Postgres: %v", err)
@@ -104,7 +169,25 @@ func main() {
 
 	// Setup router
 	r := mux.NewRouter()
+	r.Use(tracing.Middleware)
+	r.Use(requestIDMiddleware)
+	r.Use(readYourWritesMiddleware)
 	r.Use(loggingMiddleware)
+	r.Use(metrics.Middleware)
+	r.Handle("/metrics", metrics.Handler()).Methods("GET")
+
+	rateLimiter, err := limits.NewRateLimiter(cfg.Limits.Rate)
+	if err != nil {
+		log.Fatalf("Could not set up rate limits: %v", err)
+	}
+	r.Use(rateLimiter.Middleware)
+	r.Use(limits.NewBodyLimiter(cfg.Limits.Body).Middleware)
+
+	healthDao := health.NewDao()
+	healthService := health.NewService(healthDao, pdb)
+	healthHandler := health.NewHandler(healthService)
+	r.HandleFunc("/healthz", healthHandler.Live).Methods("GET")
+	r.HandleFunc("/readyz", healthHandler.Ready).Methods("GET")
 
 	// Register handlers
 	ownersDao := owners.NewDao()
@@ -118,6 +201,53 @@ func main() {
 	r.HandleFunc("/api/v1/owners", ownersHandler.UpdateOwner).Methods("PUT")
 	r.HandleFunc("/api/v1/owners/{id}", ownersHandler.DeleteOwner).Methods("DELETE")
 
+	offboardingDao := offboarding.NewDao()
+	offboardingService := offboarding.NewService(offboardingDao, pdb)
+	offboardingHandler := offboarding.NewHandler(offboardingService)
+	r.HandleFunc("/api/v1/owners/{id}/offboarding", offboardingHandler.GetReport).Methods("GET")
+	r.HandleFunc("/api/v1/owners/{id}/offboarding", offboardingHandler.Offboard).Methods("POST")
+	r.HandleFunc("/api/v1/recovery-tasks/{id}/resolve", offboardingHandler.ResolveTask).Methods("POST")
+
+	var directorySources []directory.Source
+	if dir := cfg.Owners.Sync.CSV.Dir; dir != "" {
+		directorySources = append(directorySources, directory.NewCSVSource(dir))
+	}
+	if cfg.Owners.Sync.LDAP.URL != "" {
+		directorySources = append(directorySources, directory.NewLDAPSource(cfg.Owners.Sync.LDAP))
+	}
+	// The configured source goes first so it is the default
+	sort.SliceStable(directorySources, func(i, j int) bool {
+		return directorySources[i].Name() == cfg.Owners.Sync.Source
+	})
+	directoryDao := directory.NewDao()
+	directoryService := directory.NewService(directoryDao, pdb, directorySources...)
+	directoryHandler := directory.NewHandler(directoryService)
+	r.HandleFunc("/api/v1/owners/sync", directoryHandler.Sync).Methods("POST")
+
+	if interval := cfg.Owners.Sync.Interval; interval > 0 && len(directorySources) > 0 {
+		heartbeat := healthService.Worker("owners-sync", 2*interval)
+		go directoryService.Run(workersCtx, interval, heartbeat.Beat)
+		log.Printf("Owner directory sync scheduled every %s", interval)
+	}
+
+	departmentsDao := departments.NewDao()
+	departmentsService := departments.NewService(departmentsDao, pdb, cfg.Financials.Currency)
+	departmentsHandler := departments.NewHandler(departmentsService)
+	r.HandleFunc("/api/v1/departments/report", departmentsHandler.GetReports).Methods("GET")
+	r.HandleFunc("/api/v1/departments/{id}", departmentsHandler.GetDepartment).Methods("GET")
+	r.HandleFunc("/api/v1/departments", departmentsHandler.GetDepartments).Methods("GET")
+	r.HandleFunc("/api/v1/departments", departmentsHandler.CreateDepartment).Methods("POST")
+	r.HandleFunc("/api/v1/departments/{id}", departmentsHandler.UpdateDepartment).Methods("PUT")
+	r.HandleFunc("/api/v1/departments/{id}", departmentsHandler.DeleteDepartment).Methods("DELETE")
+	r.HandleFunc("/api/v1/departments/{id}/owners", departmentsHandler.GetOwners).Methods("GET")
+	r.HandleFunc("/api/v1/departments/{id}/report", departmentsHandler.GetReport).Methods("GET")
+
+	financialsDao := financials.NewDao()
+	financialsService := financials.NewService(financialsDao, pdb, cfg.Financials)
+	financialsHandler := financials.NewHandler(financialsService)
+	r.HandleFunc("/api/v1/financials/book-values", financialsHandler.GetBookValues).Methods("GET")
+	r.HandleFunc("/api/v1/financials/totals", financialsHandler.GetTotals).Methods("GET")
+
 	typesDao := types.NewDao()
 	typesService := types.NewService(typesDao, pdb)
 	typesHandler := types.NewHandler(typesService)
@@ -138,6 +268,7 @@ func main() {
 	devicesDao := devices.NewDao()
 	devicesService := devices.NewService(devicesDao, pdb)
 	devicesHandler := devices.NewHandler(devicesService)
+	r.HandleFunc("/api/v1/devices/tag/{tag}", devicesHandler.GetDeviceByTag).Methods("GET")
 	r.HandleFunc("/api/v1/devices/{id}", devicesHandler.GetDevice).Methods("GET")
 	r.HandleFunc("/api/v1/devices", devicesHandler.GetDevices).Methods("GET")
 	r.HandleFunc("/api/v1/devices", devicesHandler.CreateDevice).Methods("POST")
@@ -159,23 +290,154 @@ func main() {
 	r.HandleFunc("/api/v1/devices/{device_id}/logs", deviceLogsHandler.CreateLog).Methods("POST")
 	r.HandleFunc("/api/v1/devices/{device_id}/logs/{id}", deviceLogsHandler.DeleteLog).Methods("DELETE")
 
-	srv := &http.Server{
-		Handler: r,
-		Addr:    viper.GetString("app.addr"),
+	deviceComponentsDao := components.NewDao()
+	deviceComponentsService := components.NewService(deviceComponentsDao, pdb)
+	deviceComponentsHandler := components.NewHandler(deviceComponentsService)
+	r.HandleFunc("/api/v1/devices/{id}/components", devicesHandler.GetComponents).Methods("GET")
+	r.HandleFunc("/api/v1/devices/{id}/components", deviceComponentsHandler.Attach).Methods("POST")
+	r.HandleFunc("/api/v1/devices/{id}/components/history", deviceComponentsHandler.GetHistory).Methods("GET")
+	r.HandleFunc("/api/v1/devices/{id}/components/{child_id}/detach", deviceComponentsHandler.Detach).Methods("POST")
+
+	locationsDao := locations.NewDao()
+	locationsService := locations.NewService(locationsDao, pdb)
+	locationsHandler := locations.NewHandler(locationsService)
+	r.HandleFunc("/api/v1/locations/{id}", locationsHandler.GetLocation).Methods("GET")
+	r.HandleFunc("/api/v1/locations", locationsHandler.GetLocations).Methods("GET")
+	r.HandleFunc("/api/v1/locations", locationsHandler.CreateLocation).Methods("POST")
+	r.HandleFunc("/api/v1/locations/{id}", locationsHandler.UpdateLocation).Methods("PUT")
+	r.HandleFunc("/api/v1/locations/{id}", locationsHandler.DeleteLocation).Methods("DELETE")
+	r.HandleFunc("/api/v1/locations/{id}/path", locationsHandler.GetPath).Methods("GET")
+	r.HandleFunc("/api/v1/locations/{id}/devices", locationsHandler.GetDevices).Methods("GET")
+	r.HandleFunc("/api/v1/devices/{id}/move", locationsHandler.MoveDevice).Methods("POST")
+
+	vendorsDao := vendors.NewDao()
+	vendorsService := vendors.NewService(vendorsDao, pdb)
+	vendorsHandler := vendors.NewHandler(vendorsService)
+	r.HandleFunc("/api/v1/vendors/{id}", vendorsHandler.GetVendor).Methods("GET")
+	r.HandleFunc("/api/v1/vendors", vendorsHandler.GetVendors).Methods("GET")
+	r.HandleFunc("/api/v1/vendors", vendorsHandler.CreateVendor).Methods("POST")
+	r.HandleFunc("/api/v1/vendors/{id}", vendorsHandler.UpdateVendor).Methods("PUT")
+	r.HandleFunc("/api/v1/vendors/{id}", vendorsHandler.DeleteVendor).Methods("DELETE")
+
+	purchaseOrdersDao := purchaseorders.NewDao()
+	purchaseOrdersService := purchaseorders.NewService(purchaseOrdersDao, pdb, cfg.Financials.Currency)
+	purchaseOrdersHandler := purchaseorders.NewHandler(purchaseOrdersService)
+	r.HandleFunc("/api/v1/purchase-orders/{id}", purchaseOrdersHandler.GetOrder).Methods("GET")
+	r.HandleFunc("/api/v1/purchase-orders", purchaseOrdersHandler.GetOrders).Methods("GET")
+	r.HandleFunc("/api/v1/purchase-orders", purchaseOrdersHandler.CreateOrder).Methods("POST")
+	r.HandleFunc("/api/v1/purchase-orders/{id}", purchaseOrdersHandler.UpdateOrder).Methods("PUT")
+	r.HandleFunc("/api/v1/purchase-orders/{id}", purchaseOrdersHandler.DeleteOrder).Methods("DELETE")
+	r.HandleFunc("/api/v1/purchase-orders/{id}/order", purchaseOrdersHandler.PlaceOrder).Methods("POST")
+	r.HandleFunc("/api/v1/purchase-orders/{id}/cancel", purchaseOrdersHandler.CancelOrder).Methods("POST")
+	r.HandleFunc("/api/v1/purchase-orders/{id}/lines/{line_id}/receive", purchaseOrdersHandler.ReceiveLine).Methods("POST")
+	r.HandleFunc("/api/v1/purchase-orders/{id}/receipts", purchaseOrdersHandler.GetReceipts).Methods("GET")
+
+	consumablesDao := consumables.NewDao()
+	consumablesService := consumables.NewService(consumablesDao, pdb)
+	consumablesHandler := consumables.NewHandler(consumablesService)
+	r.HandleFunc("/api/v1/consumables/low-stock", consumablesHandler.GetLowStock).Methods("GET")
+	r.HandleFunc("/api/v1/consumables/{id}", consumablesHandler.GetConsumable).Methods("GET")
+	r.HandleFunc("/api/v1/consumables", consumablesHandler.GetConsumables).Methods("GET")
+	r.HandleFunc("/api/v1/consumables", consumablesHandler.CreateConsumable).Methods("POST")
+	r.HandleFunc("/api/v1/consumables/{id}", consumablesHandler.UpdateConsumable).Methods("PUT")
+	r.HandleFunc("/api/v1/consumables/{id}", consumablesHandler.DeleteConsumable).Methods("DELETE")
+	r.HandleFunc("/api/v1/consumables/{id}/stock", consumablesHandler.GetStock).Methods("GET")
+	r.HandleFunc("/api/v1/consumables/{id}/movements", consumablesHandler.GetMovements).Methods("GET")
+	r.HandleFunc("/api/v1/consumables/{id}/movements", consumablesHandler.RecordMovement).Methods("POST")
+
+	loanersDao := loaners.NewDao()
+	loanersService := loaners.NewService(loanersDao, pdb)
+	loanersHandler := loaners.NewHandler(loanersService)
+	r.HandleFunc("/api/v1/loaners", loanersHandler.GetLoaners).Methods("GET")
+	r.HandleFunc("/api/v1/loaners", loanersHandler.AddLoaner).Methods("POST")
+	r.HandleFunc("/api/v1/loaners/overdue", loanersHandler.GetOverdue).Methods("GET")
+	r.HandleFunc("/api/v1/loaners/{device_id}", loanersHandler.RemoveLoaner).Methods("DELETE")
+	r.HandleFunc("/api/v1/reservations/{id}", loanersHandler.GetReservation).Methods("GET")
+	r.HandleFunc("/api/v1/reservations", loanersHandler.GetReservations).Methods("GET")
+	r.HandleFunc("/api/v1/reservations", loanersHandler.Reserve).Methods("POST")
+	r.HandleFunc("/api/v1/reservations/{id}/cancel", loanersHandler.CancelReservation).Methods("POST")
+	r.HandleFunc("/api/v1/reservations/{id}/checkout", loanersHandler.Checkout).Methods("POST")
+	r.HandleFunc("/api/v1/reservations/{id}/return", loanersHandler.Return).Methods("POST")
+
+	repairsDao := repairs.NewDao()
+	repairsService := repairs.NewService(repairsDao, pdb, cfg.Financials.Currency)
+	repairsHandler := repairs.NewHandler(repairsService)
+	r.HandleFunc("/api/v1/repairs/{id}", repairsHandler.GetTicket).Methods("GET")
+	r.HandleFunc("/api/v1/repairs", repairsHandler.GetTickets).Methods("GET")
+	r.HandleFunc("/api/v1/repairs", repairsHandler.OpenTicket).Methods("POST")
+	r.HandleFunc("/api/v1/repairs/{id}", repairsHandler.UpdateTicket).Methods("PUT")
+	r.HandleFunc("/api/v1/repairs/{id}/resolve", repairsHandler.ResolveTicket).Methods("POST")
+	r.HandleFunc("/api/v1/repairs/{id}/comments", repairsHandler.GetComments).Methods("GET")
+	r.HandleFunc("/api/v1/repairs/{id}/comments", repairsHandler.AddComment).Methods("POST")
+
+	auditsDao := audits.NewDao()
+	auditsService := audits.NewService(auditsDao, pdb)
+	auditsHandler := audits.NewHandler(auditsService)
+	r.HandleFunc("/api/v1/audits/{id}", auditsHandler.GetAudit).Methods("GET")
+	r.HandleFunc("/api/v1/audits", auditsHandler.GetAudits).Methods("GET")
+	r.HandleFunc("/api/v1/audits", auditsHandler.CreateAudit).Methods("POST")
+	r.HandleFunc("/api/v1/audits/{id}/close", auditsHandler.CloseAudit).Methods("POST")
+	r.HandleFunc("/api/v1/audits/{id}/expected", auditsHandler.GetExpected).Methods("GET")
+	r.HandleFunc("/api/v1/audits/{id}/scans", auditsHandler.GetScans).Methods("GET")
+	r.HandleFunc("/api/v1/audits/{id}/scans", auditsHandler.Scan).Methods("POST")
+	r.HandleFunc("/api/v1/audits/{id}/report", auditsHandler.GetReport).Methods("GET")
+	r.HandleFunc("/api/v1/audits/{id}/corrections", auditsHandler.ApplyCorrections).Methods("POST")
+
+	labelsService := labels.NewService(pdb, cfg.Labels)
+	labelsHandler := labels.NewHandler(labelsService)
+	r.HandleFunc("/api/v1/devices/{id}/label", labelsHandler.GetDeviceLabel).Methods("GET")
+	r.HandleFunc("/api/v1/labels", labelsHandler.RenderBatch).Methods("POST")
+
+	graphService, err := graph.NewService(ownersService, typesService, typePropertiesService, devicesService, devicePropertiesService, deviceLogsService)
+	if err != nil {
+		log.Fatalf("Could not build GraphQL schema: %v", err)
+	}
+	graphHandler := graph.NewHandler(graphService)
+	r.HandleFunc("/api/v1/graphql", graphHandler.Query).Methods("GET", "POST")
+
+	// Setup gRPC server
+	grpcServer := grpc.NewServer()
+	rpcHandler := rpc.NewHandler(ownersService, typesService, typePropertiesService, devicesService, devicePropertiesService, deviceLogsService, cfg.GRPC.TailInterval)
+	inventorypb.RegisterInventoryServer(grpcServer, rpcHandler)
+
+	srv := server.New(cfg.App.Config, r)
+	listener, listenAddr, err := server.Listen(cfg.App.Config)
+	if err != nil {
+		log.Fatalf("Could not listen: %v", err)
 	}
 	go func() {
-		err := srv.ListenAndServe()
+		err := srv.Serve(listener)
 		if err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Server has stopped: %v", err)
 		}
 	}()
-	log.Printf("Server for %s started on %s", viper.GetString("app.name"), viper.GetString("app.addr"))
+	log.Printf("Server for %s started on %s", cfg.App.Name, listenAddr)
+
+	grpcListener, err := net.Listen("tcp", cfg.GRPC.Addr)
+	if err != nil {
+		log.Fatalf("Could not listen on %s: %v", cfg.GRPC.Addr, err)
+	}
+	go func() {
+		err := grpcServer.Serve(grpcListener)
+		if err != nil && err != grpc.ErrServerStopped {
+			log.Fatalf("gRPC server has stopped: %v", err)
+		}
+	}()
+	log.Printf("gRPC server for %s started on %s", cfg.App.Name, cfg.GRPC.Addr)
 
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT)
 
 	<-done
-	log.Printf("Shutting down server for %s", viper.GetString("app.name"))
+	log.Printf("Shutting down server for %s", cfg.App.Name)
+	// Fail readiness first and give load balancers time to notice before we
+	// stop accepting connections.
+	healthService.ShutDown()
+	if drain := cfg.App.DrainDelay; drain > 0 {
+		log.Printf("Draining for %s", drain)
+		time.Sleep(drain)
+	}
+	stopWorkers()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer func() {
@@ -193,22 +455,122 @@ func main() {
 		log.Fatalf("Server Shutdown Failed: %v", err)
 	}
 	log.Print("Server shutdown gracefully")
+
+	// GracefulStop waits for open streams such as log tails, so fall back to
+	// Stop once the shutdown deadline passes.
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+		log.Print("gRPC server shutdown gracefully")
+	case <-ctx.Done():
+		grpcServer.Stop()
+		log.Print("gRPC server stopped after shutdown timeout")
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		log.Errorf("Could not flush traces: %v", err)
+	}
+}
+
+// printConfig writes the effective configuration with secrets redacted,
+// followed by any validation errors, and returns the exit code.
+func printConfig(cfg *config.Config) int {
+	if err := cfg.Print(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Could not print configuration: %v\n", err)
+		return 1
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration:\n%v\n", err)
+		return 1
+	}
+	return 0
+}
+
+const (
+	requestIDHeader = "X-Request-ID"
+	// userHeader is set by the authenticating proxy in front of the API.
+	userHeader = "X-Remote-User"
+	// readYourWritesHeader sends every read of the request to the primary,
+	// for clients that must see a write they made in an earlier request.
+	readYourWritesHeader = "X-Read-Your-Writes"
+)
+
+// requestIDMiddleware accepts the caller's X-Request-ID or generates one,
+// echoes it back, and stores a log entry carrying it in the request context
+// for services and DAOs to log through.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		requestID := request.Header.Get(requestIDHeader)
+		if !validRequestID(requestID) {
+			var err error
+			requestID, err = gonanoid.New()
+			if err != nil {
+				log.Errorf("Could not generate request ID: %v", err)
+			}
+		}
+		response.Header().Set(requestIDHeader, requestID)
+
+		route := request.URL.Path
+		if current := mux.CurrentRoute(request); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+		fields := log.Fields{
+			"RequestID": requestID,
+			"Method":    request.Method,
+			"Route":     route,
+		}
+		if user := request.Header.Get(userHeader); user != "" {
+			fields["User"] = user
+		}
+		ctx := utils.WithLogEntry(request.Context(), log.WithFields(fields))
+		next.ServeHTTP(response, request.WithContext(ctx))
+	})
+}
+
+// readYourWritesMiddleware keeps reads on the primary once the request has
+// written, or for the whole request when the client asks for it.
+func readYourWritesMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		always, _ := strconv.ParseBool(request.Header.Get(readYourWritesHeader))
+		ctx := utils.WithReadYourWrites(request.Context(), always)
+		next.ServeHTTP(response, request.WithContext(ctx))
+	})
+}
+
+// validRequestID keeps client supplied IDs short and free of characters
+// that could forge extra log fields or headers.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("-_.:", c)) {
+			return false
+		}
+	}
+	return true
 }
 
 func loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
-		// skip logging for health check
-		if request.URL.Path == "/api/v1/purchase/api/v1/healthz" {
+		// skip logging for health checks
+		if request.URL.Path == "/healthz" || request.URL.Path == "/readyz" {
 			next.ServeHTTP(response, request)
 			return
 		}
 		start := time.Now()
-		next.ServeHTTP(response, request)
-		// log.Printf("%s %s %s %s", getIPAddress(request), request.Method, request.RequestURI, time.Since(start).String())
-		log.WithFields(log.Fields{
+		recorder := utils.NewStatusRecorder(response)
+		next.ServeHTTP(recorder, request)
+		utils.Log(request.Context()).WithFields(log.Fields{
 			"IP":     getIPAddress(request),
-			"Method": request.Method,
 			"URI":    request.RequestURI,
+			"Status": recorder.Status,
 			"Cost":   time.Since(start).String(),
 		}).Info("Handler called")
 	})
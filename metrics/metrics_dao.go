@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type deviceCount struct {
+	Status string
+	Type   string
+	Count  int64
+}
+
+type Dao struct{}
+
+func NewDao() *Dao {
+	return &Dao{}
+}
+
+func (d *Dao) GetDeviceCounts(ctx context.Context, tx pgx.Tx) ([]deviceCount, error) {
+	utils.Log(ctx).Debug("Counting devices by status and type")
+	query := `SELECT d.status, t.name, count(*)
+	FROM devices d
+	JOIN types t ON t.id = d.type_id
+	GROUP BY d.status, t.name`
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not count devices: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []deviceCount
+	for rows.Next() {
+		var count deviceCount
+		if err := rows.Scan(&count.Status, &count.Type, &count.Count); err != nil {
+			utils.Log(ctx).Errorf("Could not scan device count: %v", err)
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error occurred while counting devices: %v", err)
+		return nil, err
+	}
+	return counts, nil
+}
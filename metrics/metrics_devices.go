@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+const deviceCountTimeout = 5 * time.Second
+
+// deviceCollector counts devices by status and type on every scrape.
+type deviceCollector struct {
+	dao     *Dao
+	pdb     *utils.DB
+	devices *prometheus.Desc
+}
+
+func newDeviceCollector(dao *Dao, pdb *utils.DB) *deviceCollector {
+	return &deviceCollector{
+		dao: dao,
+		pdb: pdb,
+		devices: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "devices"),
+			"Devices by status and type.", []string{"status", "type"}, nil),
+	}
+}
+
+func (c *deviceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.devices
+}
+
+func (c *deviceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), deviceCountTimeout)
+	defer cancel()
+
+	var counts []deviceCount
+	err := c.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		counts, err = c.dao.GetDeviceCounts(ctx, tx)
+		return err
+	})
+	if err != nil {
+		log.Errorf("Failed to count devices: %v", err)
+		ch <- prometheus.NewInvalidMetric(c.devices, err)
+		return
+	}
+	for _, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.devices, prometheus.GaugeValue, float64(count.Count), count.Status, count.Type)
+	}
+}
+
+// RegisterPool exposes pool statistics for the primary and any replica, plus
+// device gauges for pdb.
+func RegisterPool(pdb *utils.DB) error {
+	if err := registry.Register(newPoolCollector("primary", pdb.Primary)); err != nil {
+		return err
+	}
+	if pdb.Replica != nil {
+		if err := registry.Register(newPoolCollector("replica", pdb.Replica)); err != nil {
+			return err
+		}
+		if err := registry.Register(newReplicaCollector(pdb)); err != nil {
+			return err
+		}
+	}
+	return registry.Register(newDeviceCollector(NewDao(), pdb))
+}
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+// poolCollector reads pgxpool.Stat on every scrape.
+type poolCollector struct {
+	pdb *pgxpool.Pool
+
+	acquiredConns     *prometheus.Desc
+	idleConns         *prometheus.Desc
+	constructingConns *prometheus.Desc
+	totalConns        *prometheus.Desc
+	maxConns          *prometheus.Desc
+	minConns          *prometheus.Desc
+	maxConnLifetime   *prometheus.Desc
+	maxConnIdleTime   *prometheus.Desc
+	acquires          *prometheus.Desc
+	acquireDuration   *prometheus.Desc
+	emptyAcquires     *prometheus.Desc
+	emptyAcquireWait  *prometheus.Desc
+	canceledAcquires  *prometheus.Desc
+	newConns          *prometheus.Desc
+}
+
+// newPoolCollector labels every series with pool, "primary" or "replica".
+func newPoolCollector(pool string, pdb *pgxpool.Pool) *poolCollector {
+	labels := prometheus.Labels{"pool": pool}
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, labels)
+	}
+	return &poolCollector{
+		pdb:               pdb,
+		acquiredConns:     desc("acquired_conns", "Connections currently checked out of the pool."),
+		idleConns:         desc("idle_conns", "Idle connections in the pool."),
+		constructingConns: desc("constructing_conns", "Connections currently being established."),
+		totalConns:        desc("total_conns", "Total connections in the pool."),
+		maxConns:          desc("max_conns", "Maximum size of the pool."),
+		minConns:          desc("min_conns", "Minimum size of the pool."),
+		maxConnLifetime:   desc("max_conn_lifetime_seconds", "Configured maximum lifetime of a connection."),
+		maxConnIdleTime:   desc("max_conn_idle_time_seconds", "Configured maximum idle time of a connection."),
+		acquires:          desc("acquires_total", "Successful connection acquires."),
+		acquireDuration:   desc("acquire_duration_seconds_total", "Total time spent acquiring connections."),
+		emptyAcquires:     desc("empty_acquires_total", "Acquires that had to wait because the pool was empty."),
+		emptyAcquireWait:  desc("empty_acquire_wait_seconds_total", "Total time acquires spent waiting on an empty pool."),
+		canceledAcquires:  desc("canceled_acquires_total", "Acquires canceled by their context."),
+		newConns:          desc("new_conns_total", "Connections opened since the pool was created."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pdb.Stat()
+	config := c.pdb.Config()
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructingConns, prometheus.GaugeValue, float64(stat.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.minConns, prometheus.GaugeValue, float64(config.MinConns))
+	ch <- prometheus.MustNewConstMetric(c.maxConnLifetime, prometheus.GaugeValue, config.MaxConnLifetime.Seconds())
+	ch <- prometheus.MustNewConstMetric(c.maxConnIdleTime, prometheus.GaugeValue, config.MaxConnIdleTime.Seconds())
+	ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireWait, prometheus.CounterValue, stat.EmptyAcquireWaitTime().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquires, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.newConns, prometheus.CounterValue, float64(stat.NewConnsCount()))
+}
+
+// replicaCollector reports whether reads are routed to the replica and how
+// far behind it was at the last check.
+type replicaCollector struct {
+	db      *utils.DB
+	healthy *prometheus.Desc
+	lag     *prometheus.Desc
+}
+
+func newReplicaCollector(db *utils.DB) *replicaCollector {
+	return &replicaCollector{
+		db: db,
+		healthy: prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_replica", "healthy"),
+			"1 while read-only transactions are routed to the replica.", nil, nil),
+		lag: prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_replica", "lag_seconds"),
+			"Replica replay lag at the last check.", nil, nil),
+	}
+}
+
+func (c *replicaCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *replicaCollector) Collect(ch chan<- prometheus.Metric) {
+	_, healthy, lag, _ := c.db.ReplicaStatus()
+	value := 0.0
+	if healthy {
+		value = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.healthy, prometheus.GaugeValue, value)
+	ch <- prometheus.MustNewConstMetric(c.lag, prometheus.GaugeValue, lag.Seconds())
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var queryDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "db",
+	Name:      "query_duration_seconds",
+	Help:      "Query latency by the DAO method that issued it and whether it failed.",
+	Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+}, []string{"dao", "method", "outcome"})
+
+// otherCaller labels queries not issued from a DAO method, such as the
+// BEGIN and COMMIT statements sent by the services.
+const otherCaller = "other"
+
+// modulePrefix is the import path of the module with a trailing slash,
+// taken from this package's path so DAO labels are relative to the module.
+var modulePrefix = strings.TrimSuffix(reflect.TypeOf(QueryTracer{}).PkgPath(), "metrics")
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	at     time.Time
+	dao    string
+	method string
+}
+
+// QueryTracer times every query and labels it with the DAO method on the
+// call stack. Pass it to utils.OpenDB.
+type QueryTracer struct{}
+
+func NewQueryTracer() *QueryTracer {
+	return &QueryTracer{}
+}
+
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	dao, method := daoCaller()
+	return context.WithValue(ctx, queryStartKey{}, queryStart{at: time.Now(), dao: dao, method: method})
+}
+
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	outcome := "ok"
+	if data.Err != nil {
+		outcome = "error"
+	}
+	queryDuration.WithLabelValues(start.dao, start.method, outcome).Observe(time.Since(start.at).Seconds())
+}
+
+// daoCaller walks the stack for the nearest (*Dao) method and returns its
+// package name and method name.
+func daoCaller() (string, string) {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if dao, method, ok := parseDaoFunc(frame.Function); ok {
+			return dao, method
+		}
+		if !more {
+			return otherCaller, otherCaller
+		}
+	}
+}
+
+// parseDaoFunc splits a function name such as
+// "github.com/rickCrz7/Inventory-API/devices/logs.(*Dao).GetLogs" into
+// "devices/logs" and "GetLogs". The package keeps its path within the module
+// so devices/properties and types/properties get labels of their own.
+func parseDaoFunc(function string) (string, string, bool) {
+	pkg, method, ok := strings.Cut(function, ".(*Dao).")
+	if !ok {
+		return "", "", false
+	}
+	pkg = strings.TrimPrefix(pkg, modulePrefix)
+	// Strip the suffix of closures declared inside the method.
+	if i := strings.Index(method, "."); i >= 0 {
+		method = method[:i]
+	}
+	return pkg, method, true
+}
@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMiddleware(t *testing.T) {
+	r := mux.NewRouter()
+	r.Use(Middleware)
+	r.HandleFunc("/api/v1/devices/{id}", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}).Methods("GET")
+
+	for _, id := range []string{"a", "b"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/v1/devices/"+id, nil))
+	}
+
+	got := testutil.ToFloat64(httpRequests.WithLabelValues("/api/v1/devices/{id}", "GET", "404"))
+	if got != 2 {
+		t.Fatalf("Expected 2 requests for route template, got %v", got)
+	}
+}
+
+func TestParseDaoFunc(t *testing.T) {
+	tests := []struct {
+		function string
+		dao      string
+		method   string
+		ok       bool
+	}{
+		{"github.com/rickCrz7/Inventory-API/devices/logs.(*Dao).GetLogs", "devices/logs", "GetLogs", true},
+		{"github.com/rickCrz7/Inventory-API/devices/properties.(*Dao).GetProperties", "devices/properties", "GetProperties", true},
+		{"github.com/rickCrz7/Inventory-API/types/properties.(*Dao).GetProperties", "types/properties", "GetProperties", true},
+		{"github.com/rickCrz7/Inventory-API/owners.(*Dao).GetOwners.func1", "owners", "GetOwners", true},
+		{"github.com/rickCrz7/Inventory-API/owners.(*Service).GetOwners", "", "", false},
+		{"runtime.goexit", "", "", false},
+	}
+	for _, tt := range tests {
+		dao, method, ok := parseDaoFunc(tt.function)
+		if dao != tt.dao || method != tt.method || ok != tt.ok {
+			t.Fatalf("parseDaoFunc(%q) = %q, %q, %v; want %q, %q, %v", tt.function, dao, method, ok, tt.dao, tt.method, tt.ok)
+		}
+	}
+}
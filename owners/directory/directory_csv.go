@@ -0,0 +1,102 @@
+package directory
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+// CSVSource reads the newest *.csv file dropped into Dir by HR. The file must
+// have a header row naming the campus_id, first_name, last_name and email
+// columns, in any order.
+type CSVSource struct {
+	Dir string
+}
+
+func NewCSVSource(dir string) *CSVSource {
+	return &CSVSource{Dir: dir}
+}
+
+func (s *CSVSource) Name() string {
+	return "csv"
+}
+
+func (s *CSVSource) Entries(ctx context.Context) ([]Entry, error) {
+	path, err := s.latestFile()
+	if err != nil {
+		return nil, err
+	}
+	utils.Log(ctx).Printf("Reading owner directory feed from %s", path)
+	f, err := os.Open(path)
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not open owner directory feed %s: %v", path, err)
+		return nil, err
+	}
+	defer f.Close()
+	return readCSV(f)
+}
+
+func (s *CSVSource) latestFile() (string, error) {
+	paths, err := filepath.Glob(filepath.Join(s.Dir, "*.csv"))
+	if err != nil {
+		return "", err
+	}
+	var latest string
+	var latestMod int64
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		if latest == "" || info.ModTime().UnixNano() > latestMod {
+			latest = path
+			latestMod = info.ModTime().UnixNano()
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no csv files in %s", s.Dir)
+	}
+	return latest, nil
+}
+
+func readCSV(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading csv header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"campus_id", "first_name", "last_name", "email"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("csv header is missing column %q", name)
+		}
+	}
+
+	var entries []Entry
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading csv: %w", err)
+		}
+		entries = append(entries, Entry{
+			CampusID:  strings.TrimSpace(record[columns["campus_id"]]),
+			FirstName: strings.TrimSpace(record[columns["first_name"]]),
+			LastName:  strings.TrimSpace(record[columns["last_name"]]),
+			Email:     strings.TrimSpace(record[columns["email"]]),
+		})
+	}
+	return entries, nil
+}
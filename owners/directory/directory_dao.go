@@ -0,0 +1,91 @@
+package directory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+const (
+	upsertCreated   = "created"
+	upsertUpdated   = "updated"
+	upsertUnchanged = "unchanged"
+)
+
+type Dao struct{}
+
+func NewDao() *Dao {
+	return &Dao{}
+}
+
+// UpsertOwner inserts or updates the owner with the entry's campus ID and
+// reports which of the two happened. Inactive owners found in the feed again
+// are reactivated; other statuses are left alone.
+func (d *Dao) UpsertOwner(ctx context.Context, tx pgx.Tx, entry Entry) (string, error) {
+	utils.Log(ctx).Printf("Upserting owner with Campus ID: %s", entry.CampusID)
+	id, err := gonanoid.New()
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not generate owner ID: %v", err)
+		return "", err
+	}
+	query := `INSERT INTO owners (id, first_name, last_name, email, campus_id, status)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (campus_id) WHERE campus_id IS NOT NULL DO UPDATE
+	SET first_name = EXCLUDED.first_name,
+		last_name = EXCLUDED.last_name,
+		email = EXCLUDED.email,
+		status = CASE WHEN owners.status = $7 THEN $6 ELSE owners.status END
+	WHERE owners.first_name IS DISTINCT FROM EXCLUDED.first_name
+		OR owners.last_name IS DISTINCT FROM EXCLUDED.last_name
+		OR owners.email IS DISTINCT FROM EXCLUDED.email
+		OR owners.status = $7
+	RETURNING (xmax = 0) AS inserted`
+	var inserted bool
+	err = tx.QueryRow(ctx, query, id, entry.FirstName, entry.LastName, entry.Email, entry.CampusID,
+		utils.OwnerStatusActive, utils.OwnerStatusInactive).Scan(&inserted)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return upsertUnchanged, nil
+	}
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not upsert owner %s: %v", entry.CampusID, err)
+		return "", err
+	}
+	if inserted {
+		return upsertCreated, nil
+	}
+	return upsertUpdated, nil
+}
+
+// DeactivateMissing flags active owners whose campus ID is not in campusIDs
+// as inactive and returns their campus IDs. Owners are never deleted here
+// because deleting an owner cascades to their devices.
+func (d *Dao) DeactivateMissing(ctx context.Context, tx pgx.Tx, campusIDs []string) ([]string, error) {
+	utils.Log(ctx).Printf("Deactivating owners missing from directory feed of %d entries", len(campusIDs))
+	query := `UPDATE owners SET status = $1
+	WHERE campus_id IS NOT NULL AND status = $2 AND NOT (campus_id = ANY($3))
+	RETURNING campus_id`
+	rows, err := tx.Query(ctx, query, utils.OwnerStatusInactive, utils.OwnerStatusActive, campusIDs)
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not deactivate owners: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deactivated []string
+	for rows.Next() {
+		var campusID string
+		if err := rows.Scan(&campusID); err != nil {
+			utils.Log(ctx).Errorf("Could not scan campus ID: %v", err)
+			return nil, err
+		}
+		deactivated = append(deactivated, campusID)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error occurred while deactivating owners: %v", err)
+		return nil, err
+	}
+	return deactivated, nil
+}
@@ -0,0 +1,187 @@
+package directory
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime"
+	"testing"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/spf13/viper"
+)
+
+var postgresURI string
+
+func init() {
+	viper.SetConfigName("app")
+	viper.AddConfigPath("../../config")
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+	postgresURI = viper.GetString("postgres.dev") // Change to "postgres.dev" for development/local db
+	log.SetReportCaller(true)
+	log.SetFormatter(&log.TextFormatter{
+		ForceColors:     true,
+		FullTimestamp:   true,
+		TimestampFormat: "2006/01/02 15:04:05",
+		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
+			filename := path.Base(f.File)
+			return fmt.Sprintf("%s()", f.Function), fmt.Sprintf("\t%s:%d", filename, f.Line)
+		},
+	})
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestUpsertOwner(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+	campusID, _ := gonanoid.New()
+	entry := Entry{CampusID: campusID, FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"}
+
+	t.Run("UpsertOwnerCreated", func(t *testing.T) {
+		result, err := dao.UpsertOwner(ctx, tx, entry)
+		if err != nil {
+			t.Fatalf("Error upserting owner: %v", err)
+		}
+		if result != upsertCreated {
+			t.Fatalf("Expected %q, got %q", upsertCreated, result)
+		}
+	})
+	t.Run("UpsertOwnerUnchanged", func(t *testing.T) {
+		result, err := dao.UpsertOwner(ctx, tx, entry)
+		if err != nil {
+			t.Fatalf("Error upserting owner: %v", err)
+		}
+		if result != upsertUnchanged {
+			t.Fatalf("Expected %q, got %q", upsertUnchanged, result)
+		}
+	})
+	t.Run("UpsertOwnerUpdated", func(t *testing.T) {
+		entry.Email = "jdoe@example.com"
+		result, err := dao.UpsertOwner(ctx, tx, entry)
+		if err != nil {
+			t.Fatalf("Error upserting owner: %v", err)
+		}
+		if result != upsertUpdated {
+			t.Fatalf("Expected %q, got %q", upsertUpdated, result)
+		}
+		var email string
+		err = tx.QueryRow(ctx, `SELECT email FROM owners WHERE campus_id = $1`, campusID).Scan(&email)
+		if err != nil {
+			t.Fatalf("Error getting owner: %v", err)
+		}
+		if email != "jdoe@example.com" {
+			t.Fatalf("Expected email jdoe@example.com, got %q", email)
+		}
+	})
+	t.Run("UpsertOwnerReactivated", func(t *testing.T) {
+		_, err := tx.Exec(ctx, `UPDATE owners SET status = 'inactive' WHERE campus_id = $1`, campusID)
+		if err != nil {
+			t.Fatalf("Error deactivating owner: %v", err)
+		}
+		result, err := dao.UpsertOwner(ctx, tx, entry)
+		if err != nil {
+			t.Fatalf("Error upserting owner: %v", err)
+		}
+		if result != upsertUpdated {
+			t.Fatalf("Expected %q, got %q", upsertUpdated, result)
+		}
+		var status string
+		err = tx.QueryRow(ctx, `SELECT status FROM owners WHERE campus_id = $1`, campusID).Scan(&status)
+		if err != nil {
+			t.Fatalf("Error getting owner: %v", err)
+		}
+		if status != utils.OwnerStatusActive {
+			t.Fatalf("Expected status %q, got %q", utils.OwnerStatusActive, status)
+		}
+	})
+}
+
+func TestDeactivateMissing(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+	// Mock Data
+	var campusIDs []string
+	for i := 0; i < 3; i++ {
+		id, _ := gonanoid.New()
+		campusID, _ := gonanoid.New()
+		_, err = tx.Exec(ctx, `
+			INSERT INTO owners (id, first_name, last_name, email, campus_id)
+			VALUES ($1, $2, $3, $4, $5)
+		`, id, "John", "Doe", "john.doe@example.com", campusID)
+		if err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+		campusIDs = append(campusIDs, campusID)
+	}
+	manualID, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO owners (id, first_name, last_name, email)
+		VALUES ($1, $2, $3, $4)
+	`, manualID, "Jane", "Roe", "jane.roe@example.com")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+
+	t.Run("DeactivateMissing", func(t *testing.T) {
+		// Keep every owner already in the database except the mock ones.
+		var keep []string
+		rows, err := tx.Query(ctx, `SELECT campus_id FROM owners WHERE campus_id IS NOT NULL AND NOT (campus_id = ANY($1))`, campusIDs[1:])
+		if err != nil {
+			t.Fatalf("Error getting campus IDs: %v", err)
+		}
+		for rows.Next() {
+			var campusID string
+			if err := rows.Scan(&campusID); err != nil {
+				t.Fatalf("Error scanning campus ID: %v", err)
+			}
+			keep = append(keep, campusID)
+		}
+		rows.Close()
+
+		deactivated, err := dao.DeactivateMissing(ctx, tx, keep)
+		if err != nil {
+			t.Fatalf("Error deactivating owners: %v", err)
+		}
+		if len(deactivated) != 2 {
+			t.Fatalf("Expected 2 deactivated owners, got %v", deactivated)
+		}
+		var status string
+		err = tx.QueryRow(ctx, `SELECT status FROM owners WHERE id = $1`, manualID).Scan(&status)
+		if err != nil {
+			t.Fatalf("Error getting owner: %v", err)
+		}
+		if status != utils.OwnerStatusActive {
+			t.Fatalf("Expected owner without campus ID to stay %q, got %q", utils.OwnerStatusActive, status)
+		}
+	})
+}
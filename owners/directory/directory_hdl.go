@@ -0,0 +1,38 @@
+package directory
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+type Handler struct {
+	svc *Service
+	// atz *authz.Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{
+		svc: svc,
+		// atz: atz,
+	}
+}
+
+func (h *Handler) Sync(w http.ResponseWriter, r *http.Request) {
+	report, err := h.svc.Sync(r.Context(), r.URL.Query().Get("source"))
+	if errors.Is(err, ErrUnknownSource) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, ErrEmptyFeed) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,116 @@
+package directory
+
+import (
+	"context"
+	"net"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type LDAPConfig struct {
+	URL          string `mapstructure:"url"`
+	BindDN       string `mapstructure:"bind-dn"`
+	BindPassword string `mapstructure:"bind-password"`
+	BaseDN       string `mapstructure:"base-dn"`
+	Filter       string `mapstructure:"filter"`
+	// Attribute names holding each owner field.
+	CampusIDAttr  string `mapstructure:"campus-id-attr"`
+	FirstNameAttr string `mapstructure:"first-name-attr"`
+	LastNameAttr  string `mapstructure:"last-name-attr"`
+	EmailAttr     string `mapstructure:"email-attr"`
+}
+
+// ldapConn is the part of *ldap.Conn used by LDAPSource, so tests can swap
+// in a stub server.
+type ldapConn interface {
+	Bind(username, password string) error
+	SearchWithPaging(searchRequest *ldap.SearchRequest, pagingSize uint32) (*ldap.SearchResult, error)
+	Close() error
+}
+
+type LDAPSource struct {
+	cfg  LDAPConfig
+	dial func(ctx context.Context, url string) (ldapConn, error)
+}
+
+func NewLDAPSource(cfg LDAPConfig) *LDAPSource {
+	if cfg.Filter == "" {
+		cfg.Filter = "(objectClass=person)"
+	}
+	if cfg.CampusIDAttr == "" {
+		cfg.CampusIDAttr = "employeeNumber"
+	}
+	if cfg.FirstNameAttr == "" {
+		cfg.FirstNameAttr = "givenName"
+	}
+	if cfg.LastNameAttr == "" {
+		cfg.LastNameAttr = "sn"
+	}
+	if cfg.EmailAttr == "" {
+		cfg.EmailAttr = "mail"
+	}
+	return &LDAPSource{
+		cfg: cfg,
+		dial: func(ctx context.Context, url string) (ldapConn, error) {
+			dialer := &net.Dialer{}
+			if deadline, ok := ctx.Deadline(); ok {
+				dialer.Deadline = deadline
+			}
+			return ldap.DialURL(url, ldap.DialWithDialer(dialer))
+		},
+	}
+}
+
+func (s *LDAPSource) Name() string {
+	return "ldap"
+}
+
+// Entries searches the directory. Cancelling ctx closes the connection,
+// which aborts a bind or search in progress.
+func (s *LDAPSource) Entries(ctx context.Context) ([]Entry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	utils.Log(ctx).Printf("Searching owner directory at %s", s.cfg.URL)
+	conn, err := s.dial(ctx, s.cfg.URL)
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not connect to LDAP server %s: %v", s.cfg.URL, err)
+		return nil, err
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if s.cfg.BindDN != "" {
+		if err := conn.Bind(s.cfg.BindDN, s.cfg.BindPassword); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			utils.Log(ctx).Errorf("Could not bind to LDAP server as %s: %v", s.cfg.BindDN, err)
+			return nil, err
+		}
+	}
+
+	attrs := []string{s.cfg.CampusIDAttr, s.cfg.FirstNameAttr, s.cfg.LastNameAttr, s.cfg.EmailAttr}
+	req := ldap.NewSearchRequest(s.cfg.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false, s.cfg.Filter, attrs, nil)
+	result, err := conn.SearchWithPaging(req, 500)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		utils.Log(ctx).Errorf("Could not search LDAP directory: %v", err)
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(result.Entries))
+	for _, e := range result.Entries {
+		entries = append(entries, Entry{
+			CampusID:  e.GetAttributeValue(s.cfg.CampusIDAttr),
+			FirstName: e.GetAttributeValue(s.cfg.FirstNameAttr),
+			LastName:  e.GetAttributeValue(s.cfg.LastNameAttr),
+			Email:     e.GetAttributeValue(s.cfg.EmailAttr),
+		})
+	}
+	return entries, nil
+}
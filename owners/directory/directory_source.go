@@ -0,0 +1,18 @@
+package directory
+
+import "context"
+
+// Entry is one person as published by the university directory.
+type Entry struct {
+	CampusID  string
+	FirstName string
+	LastName  string
+	Email     string
+}
+
+// Source is a feed of directory entries. A source returns the complete
+// population on every call; owners missing from it are flagged inactive.
+type Source interface {
+	Name() string
+	Entries(ctx context.Context) ([]Entry, error)
+}
@@ -0,0 +1,153 @@
+package directory
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+func TestCSVSource(t *testing.T) {
+	t.Run("Entries", func(t *testing.T) {
+		entries, err := NewCSVSource("testdata").Entries(context.Background())
+		if err != nil {
+			t.Fatalf("Error reading entries: %v", err)
+		}
+		if len(entries) != 3 {
+			t.Fatalf("Expected 3 entries, got %d", len(entries))
+		}
+		want := Entry{CampusID: "C1002", FirstName: "Jane", LastName: "Roe", Email: "jane.roe@example.com"}
+		if entries[1] != want {
+			t.Fatalf("Expected %+v, got %+v", want, entries[1])
+		}
+	})
+	t.Run("EntriesLatestFile", func(t *testing.T) {
+		dir := t.TempDir()
+		older := filepath.Join(dir, "older.csv")
+		newer := filepath.Join(dir, "newer.csv")
+		if err := os.WriteFile(older, []byte("campus_id,first_name,last_name,email\nC1,A,B,a@example.com\n"), 0o644); err != nil {
+			t.Fatalf("Error writing file: %v", err)
+		}
+		if err := os.WriteFile(newer, []byte("campus_id,first_name,last_name,email\nC2,C,D,c@example.com\n"), 0o644); err != nil {
+			t.Fatalf("Error writing file: %v", err)
+		}
+		past := time.Now().Add(-time.Hour)
+		if err := os.Chtimes(older, past, past); err != nil {
+			t.Fatalf("Error setting file time: %v", err)
+		}
+		entries, err := NewCSVSource(dir).Entries(context.Background())
+		if err != nil {
+			t.Fatalf("Error reading entries: %v", err)
+		}
+		if len(entries) != 1 || entries[0].CampusID != "C2" {
+			t.Fatalf("Expected entries from newer.csv, got %+v", entries)
+		}
+	})
+	t.Run("EntriesMissingColumn", func(t *testing.T) {
+		if _, err := readCSV(strings.NewReader("campus_id,first_name,email\n")); err == nil {
+			t.Fatal("Expected error for missing column, got nil")
+		}
+	})
+	t.Run("EntriesEmptyDir", func(t *testing.T) {
+		if _, err := NewCSVSource(t.TempDir()).Entries(context.Background()); err == nil {
+			t.Fatal("Expected error for empty directory, got nil")
+		}
+	})
+}
+
+// stubLDAP answers searches from a fixed set of entries.
+type stubLDAP struct {
+	bindErr error
+	bound   string
+	entries []*ldap.Entry
+	filter  string
+	closed  bool
+}
+
+func (s *stubLDAP) Bind(username, password string) error {
+	s.bound = username
+	return s.bindErr
+}
+
+func (s *stubLDAP) SearchWithPaging(req *ldap.SearchRequest, pagingSize uint32) (*ldap.SearchResult, error) {
+	s.filter = req.Filter
+	if s.closed {
+		return nil, errors.New("connection closed")
+	}
+	return &ldap.SearchResult{Entries: s.entries}, nil
+}
+
+func (s *stubLDAP) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestLDAPSource(t *testing.T) {
+	stub := &stubLDAP{
+		entries: []*ldap.Entry{
+			ldap.NewEntry("uid=jdoe,ou=people,dc=example,dc=edu", map[string][]string{
+				"employeeNumber": {"C1001"},
+				"givenName":      {"John"},
+				"sn":             {"Doe"},
+				"mail":           {"john.doe@example.com"},
+			}),
+		},
+	}
+	source := NewLDAPSource(LDAPConfig{URL: "ldap://localhost", BindDN: "cn=inventory,dc=example,dc=edu", BaseDN: "ou=people,dc=example,dc=edu"})
+	source.dial = func(ctx context.Context, url string) (ldapConn, error) {
+		return stub, nil
+	}
+
+	t.Run("Entries", func(t *testing.T) {
+		entries, err := source.Entries(context.Background())
+		if err != nil {
+			t.Fatalf("Error reading entries: %v", err)
+		}
+		want := Entry{CampusID: "C1001", FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"}
+		if len(entries) != 1 || entries[0] != want {
+			t.Fatalf("Expected [%+v], got %+v", want, entries)
+		}
+		if stub.bound != "cn=inventory,dc=example,dc=edu" {
+			t.Fatalf("Expected bind as configured DN, got %q", stub.bound)
+		}
+		if stub.filter != "(objectClass=person)" {
+			t.Fatalf("Expected default filter, got %q", stub.filter)
+		}
+	})
+	t.Run("EntriesBindError", func(t *testing.T) {
+		stub.bindErr = errors.New("invalid credentials")
+		defer func() { stub.bindErr = nil }()
+		if _, err := source.Entries(context.Background()); err == nil {
+			t.Fatal("Expected bind error, got nil")
+		}
+	})
+	t.Run("EntriesCancelled", func(t *testing.T) {
+		stub.closed = false
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := source.Entries(ctx); !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected %v, got %v", context.Canceled, err)
+		}
+	})
+}
+
+func TestFilterEntries(t *testing.T) {
+	report := &Report{}
+	valid := filterEntries([]Entry{
+		{CampusID: "C1", FirstName: "A", LastName: "B", Email: "a@example.com"},
+		{CampusID: "", FirstName: "A", LastName: "B", Email: "a@example.com"},
+		{CampusID: "C2", FirstName: "A", LastName: "B", Email: ""},
+		{CampusID: "C1", FirstName: "A", LastName: "B", Email: "a@example.com"},
+	}, report)
+	if len(valid) != 1 {
+		t.Fatalf("Expected 1 valid entry, got %d", len(valid))
+	}
+	if len(report.Skipped) != 3 {
+		t.Fatalf("Expected 3 skipped entries, got %d", len(report.Skipped))
+	}
+}
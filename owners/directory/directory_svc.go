@@ -0,0 +1,175 @@
+package directory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	ErrUnknownSource = errors.New("unknown directory source")
+	// ErrEmptyFeed is returned instead of deactivating every owner when a
+	// source yields no usable entries, which almost always means the feed is
+	// broken rather than that everyone left.
+	ErrEmptyFeed = errors.New("directory feed has no usable entries")
+)
+
+// Report summarizes one sync run.
+type Report struct {
+	Source      string         `json:"source"`
+	StartedAt   time.Time      `json:"started_at"`
+	FinishedAt  time.Time      `json:"finished_at"`
+	Created     int            `json:"created"`
+	Updated     int            `json:"updated"`
+	Unchanged   int            `json:"unchanged"`
+	Deactivated []string       `json:"deactivated"`
+	Skipped     []SkippedEntry `json:"skipped"`
+}
+
+type SkippedEntry struct {
+	Entry  Entry  `json:"entry"`
+	Reason string `json:"reason"`
+}
+
+type Service struct {
+	dao           *Dao
+	pdb           *utils.DB
+	sources       map[string]Source
+	defaultSource string
+}
+
+// NewService registers the given sources by name. The first source is used
+// when a sync does not name one.
+func NewService(dao *Dao, pdb *utils.DB, sources ...Source) *Service {
+	s := &Service{
+		dao:     dao,
+		pdb:     pdb,
+		sources: map[string]Source{},
+	}
+	for _, source := range sources {
+		if s.defaultSource == "" {
+			s.defaultSource = source.Name()
+		}
+		s.sources[source.Name()] = source
+	}
+	return s
+}
+
+func (s *Service) Sync(ctx context.Context, sourceName string) (*Report, error) {
+	ctx, span := tracing.Start(ctx, "directory.Sync")
+	defer span.End()
+
+	if sourceName == "" {
+		sourceName = s.defaultSource
+	}
+	source, ok := s.sources[sourceName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSource, sourceName)
+	}
+
+	report := &Report{Source: sourceName, StartedAt: time.Now()}
+	entries, err := source.Entries(ctx)
+	if err != nil {
+		utils.Log(ctx).Errorf("Failed to read directory source %s: %v", sourceName, err)
+		return nil, err
+	}
+	valid := filterEntries(entries, report)
+	if len(valid) == 0 {
+		utils.Log(ctx).Errorf("Directory source %s returned no usable entries", sourceName)
+		return nil, ErrEmptyFeed
+	}
+
+	// Serializable so two overlapping syncs cannot interleave upserts and
+	// deactivations; the loser is retried against the winner's result.
+	err = s.pdb.InTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.Serializable,
+		AccessMode: pgx.ReadWrite,
+	}, func(tx pgx.Tx) error {
+		report.Created, report.Updated, report.Unchanged = 0, 0, 0
+		campusIDs := make([]string, 0, len(valid))
+		for _, entry := range valid {
+			result, err := s.dao.UpsertOwner(ctx, tx, entry)
+			if err != nil {
+				utils.Log(ctx).Errorf("Failed to upsert owner %s: %v", entry.CampusID, err)
+				return err
+			}
+			switch result {
+			case upsertCreated:
+				report.Created++
+			case upsertUpdated:
+				report.Updated++
+			default:
+				report.Unchanged++
+			}
+			campusIDs = append(campusIDs, entry.CampusID)
+		}
+
+		var err error
+		report.Deactivated, err = s.dao.DeactivateMissing(ctx, tx, campusIDs)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to deactivate missing owners: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.FinishedAt = time.Now()
+
+	utils.Log(ctx).WithFields(log.Fields{
+		"Source":      report.Source,
+		"Created":     report.Created,
+		"Updated":     report.Updated,
+		"Unchanged":   report.Unchanged,
+		"Deactivated": len(report.Deactivated),
+		"Skipped":     len(report.Skipped),
+	}).Info("Owner directory sync finished")
+	return report, nil
+}
+
+// Run syncs from the default source every interval until ctx is done. beat
+// is called after every attempt so health checks can tell the loop is alive.
+func (s *Service) Run(ctx context.Context, interval time.Duration, beat func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.Sync(ctx, ""); err != nil {
+			utils.Log(ctx).Errorf("Scheduled owner directory sync failed: %v", err)
+		}
+		beat()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// filterEntries drops entries that cannot be upserted and records why.
+func filterEntries(entries []Entry, report *Report) []Entry {
+	seen := map[string]bool{}
+	var valid []Entry
+	for _, entry := range entries {
+		switch {
+		case entry.CampusID == "":
+			report.Skipped = append(report.Skipped, SkippedEntry{Entry: entry, Reason: "missing campus_id"})
+		case entry.Email == "":
+			report.Skipped = append(report.Skipped, SkippedEntry{Entry: entry, Reason: "missing email"})
+		case entry.FirstName == "" || entry.LastName == "":
+			report.Skipped = append(report.Skipped, SkippedEntry{Entry: entry, Reason: "missing name"})
+		case seen[entry.CampusID]:
+			report.Skipped = append(report.Skipped, SkippedEntry{Entry: entry, Reason: "duplicate campus_id"})
+		default:
+			seen[entry.CampusID] = true
+			valid = append(valid, entry)
+		}
+	}
+	return valid
+}
@@ -0,0 +1,161 @@
+package offboarding
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Dao struct{}
+
+func NewDao() *Dao {
+	return &Dao{}
+}
+
+func (d *Dao) SetOwnerStatus(ctx context.Context, tx pgx.Tx, ownerID string, status string) error {
+	utils.Log(ctx).Printf("Setting status of owner %s to %s", ownerID, status)
+	query := `UPDATE owners SET status = $2 WHERE id = $1`
+	tag, err := tx.Exec(ctx, query, ownerID, status)
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not set status of owner %s: %v", ownerID, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (d *Dao) GetDevices(ctx context.Context, tx pgx.Tx, ownerID string) ([]*utils.Device, error) {
+	utils.Log(ctx).Printf("Fetching devices for owner with ID: %s", ownerID)
+	query := `SELECT id, serial_number, name, type_id, owner_id, location_id, cost_center, purchase_date, purchase_cost, currency, vendor, po_number, invoice_ref, status, asset_tag
+	FROM devices
+	WHERE owner_id = $1
+	ORDER BY name`
+	rows, err := tx.Query(ctx, query, ownerID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not fetch devices for owner %s: %v", ownerID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var devices []*utils.Device
+	for rows.Next() {
+		var device utils.Device
+		if err := rows.Scan(&device.ID, &device.SerialNumber, &device.Name, &device.TypeID, &device.OwnerID, &device.LocationID, &device.CostCenter, &device.PurchaseDate, &device.PurchaseCost, &device.Currency, &device.Vendor, &device.PONumber, &device.InvoiceRef, &device.Status, &device.AssetTag); err != nil {
+			utils.Log(ctx).Errorf("Could not scan device: %v", err)
+			return nil, err
+		}
+		devices = append(devices, &device)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error occurred while fetching devices: %v", err)
+		return nil, err
+	}
+	return devices, nil
+}
+
+func (d *Dao) ReassignDevice(ctx context.Context, tx pgx.Tx, deviceID string, ownerID string) error {
+	utils.Log(ctx).Printf("Reassigning device %s to owner %s", deviceID, ownerID)
+	query := `UPDATE devices SET owner_id = $2 WHERE id = $1`
+	if _, err := tx.Exec(ctx, query, deviceID, ownerID); err != nil {
+		utils.Log(ctx).Errorf("Could not reassign device %s: %v", deviceID, err)
+		return err
+	}
+	return nil
+}
+
+// LockDeviceOwner returns the device's current owner and locks the device
+// row until the transaction ends.
+func (d *Dao) LockDeviceOwner(ctx context.Context, tx pgx.Tx, deviceID string) (string, error) {
+	utils.Log(ctx).Printf("Locking device with ID: %s", deviceID)
+	query := `SELECT owner_id FROM devices WHERE id = $1 FOR UPDATE`
+	var ownerID string
+	if err := tx.QueryRow(ctx, query, deviceID).Scan(&ownerID); err != nil {
+		utils.Log(ctx).Errorf("Could not lock device %s: %v", deviceID, err)
+		return "", err
+	}
+	return ownerID, nil
+}
+
+func (d *Dao) GetTask(ctx context.Context, tx pgx.Tx, id string) (*utils.RecoveryTask, error) {
+	utils.Log(ctx).Printf("Fetching recovery task with ID: %s", id)
+	query := `SELECT id, owner_id, device_id, status, note, created_at, created_by, resolved_at, resolved_by
+	FROM recovery_tasks
+	WHERE id = $1
+	FOR UPDATE`
+	var task utils.RecoveryTask
+	err := tx.QueryRow(ctx, query, id).Scan(&task.ID, &task.OwnerID, &task.DeviceID, &task.Status, &task.Note,
+		&task.CreatedAt, &task.CreatedBy, &task.ResolvedAt, &task.ResolvedBy)
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not fetch recovery task %s: %v", id, err)
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (d *Dao) GetTasks(ctx context.Context, tx pgx.Tx, ownerID string) ([]*utils.RecoveryTask, error) {
+	utils.Log(ctx).Printf("Fetching recovery tasks for owner with ID: %s", ownerID)
+	query := `SELECT id, owner_id, device_id, status, note, created_at, created_by, resolved_at, resolved_by
+	FROM recovery_tasks
+	WHERE owner_id = $1
+	ORDER BY created_at, id`
+	rows, err := tx.Query(ctx, query, ownerID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not fetch recovery tasks for owner %s: %v", ownerID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*utils.RecoveryTask
+	for rows.Next() {
+		var task utils.RecoveryTask
+		if err := rows.Scan(&task.ID, &task.OwnerID, &task.DeviceID, &task.Status, &task.Note,
+			&task.CreatedAt, &task.CreatedBy, &task.ResolvedAt, &task.ResolvedBy); err != nil {
+			utils.Log(ctx).Errorf("Could not scan recovery task: %v", err)
+			return nil, err
+		}
+		tasks = append(tasks, &task)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error occurred while fetching recovery tasks: %v", err)
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (d *Dao) CreateTask(ctx context.Context, tx pgx.Tx, task *utils.RecoveryTask) error {
+	utils.Log(ctx).Printf("Creating recovery task for device %s", task.DeviceID)
+	if task.ID == "" {
+		var err error
+		task.ID, err = gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Could not generate recovery task ID: %v", err)
+			return err
+		}
+	}
+	if task.Status == "" {
+		task.Status = utils.RecoveryTaskOpen
+	}
+	query := `INSERT INTO recovery_tasks (id, owner_id, device_id, status, note, created_at, created_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := tx.Exec(ctx, query, task.ID, task.OwnerID, task.DeviceID, task.Status, task.Note, task.CreatedAt, task.CreatedBy)
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not create recovery task: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (d *Dao) ResolveTask(ctx context.Context, tx pgx.Tx, task *utils.RecoveryTask) error {
+	utils.Log(ctx).Printf("Resolving recovery task %s as %s", task.ID, task.Status)
+	query := `UPDATE recovery_tasks SET status = $2, note = $3, resolved_at = $4, resolved_by = $5 WHERE id = $1`
+	_, err := tx.Exec(ctx, query, task.ID, task.Status, task.Note, task.ResolvedAt, task.ResolvedBy)
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not resolve recovery task %s: %v", task.ID, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package offboarding
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime"
+	"testing"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/spf13/viper"
+)
+
+var postgresURI string
+
+func init() {
+	viper.SetConfigName("app")
+	viper.AddConfigPath("../../config")
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+	postgresURI = viper.GetString("postgres.dev") // Change to "postgres.dev" for development/local db
+	log.SetReportCaller(true)
+	log.SetFormatter(&log.TextFormatter{
+		ForceColors:     true,
+		FullTimestamp:   true,
+		TimestampFormat: "2006/01/02 15:04:05",
+		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
+			filename := path.Base(f.File)
+			return fmt.Sprintf("%s()", f.Function), fmt.Sprintf("\t%s:%d", filename, f.Line)
+		},
+	})
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestRecoveryTasks(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+	// Mock Data
+	ownerID, _ := gonanoid.New()
+	newOwnerID, _ := gonanoid.New()
+	typeID, _ := gonanoid.New()
+	deviceID, _ := gonanoid.New()
+	for _, id := range []string{ownerID, newOwnerID} {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO owners (id, first_name, last_name, email)
+			VALUES ($1, $2, $3, $4)
+		`, id, "John", "Doe", "john.doe@example.com")
+		if err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO types (id, name) VALUES ($1, $2)`, typeID, "Laptop")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO devices (id, serial_number, name, type_id, owner_id, purchase_date, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, deviceID, "SN123", "Laptop 1", typeID, ownerID, time.Now(), "active")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+
+	t.Run("SetOwnerStatus", func(t *testing.T) {
+		if err := dao.SetOwnerStatus(ctx, tx, ownerID, utils.OwnerStatusDeparting); err != nil {
+			t.Fatalf("Error setting owner status: %v", err)
+		}
+		var status string
+		if err := tx.QueryRow(ctx, `SELECT status FROM owners WHERE id = $1`, ownerID).Scan(&status); err != nil {
+			t.Fatalf("Error getting owner: %v", err)
+		}
+		if status != utils.OwnerStatusDeparting {
+			t.Fatalf("Expected status %q, got %q", utils.OwnerStatusDeparting, status)
+		}
+	})
+	t.Run("SetOwnerStatusMissing", func(t *testing.T) {
+		if err := dao.SetOwnerStatus(ctx, tx, "missing", utils.OwnerStatusDeparting); err == nil {
+			t.Fatal("Expected error for missing owner, got nil")
+		}
+	})
+	t.Run("GetDevices", func(t *testing.T) {
+		devices, err := dao.GetDevices(ctx, tx, ownerID)
+		if err != nil {
+			t.Fatalf("Error getting devices: %v", err)
+		}
+		if len(devices) != 1 || devices[0].ID != deviceID {
+			t.Fatalf("Expected device %s, got %v", deviceID, devices)
+		}
+	})
+
+	task := &utils.RecoveryTask{
+		OwnerID:   ownerID,
+		DeviceID:  deviceID,
+		CreatedAt: time.Now(),
+		CreatedBy: "test",
+	}
+	t.Run("CreateTask", func(t *testing.T) {
+		if err := dao.CreateTask(ctx, tx, task); err != nil {
+			t.Fatalf("Error creating recovery task: %v", err)
+		}
+		tasks, err := dao.GetTasks(ctx, tx, ownerID)
+		if err != nil {
+			t.Fatalf("Error getting recovery tasks: %v", err)
+		}
+		if len(tasks) != 1 || tasks[0].Status != utils.RecoveryTaskOpen {
+			t.Fatalf("Expected 1 open recovery task, got %v", tasks)
+		}
+	})
+	t.Run("LockDeviceOwner", func(t *testing.T) {
+		got, err := dao.LockDeviceOwner(ctx, tx, deviceID)
+		if err != nil {
+			t.Fatalf("Error locking device: %v", err)
+		}
+		if got != ownerID {
+			t.Fatalf("Expected owner %s, got %s", ownerID, got)
+		}
+	})
+	t.Run("ResolveTask", func(t *testing.T) {
+		if err := dao.ReassignDevice(ctx, tx, deviceID, newOwnerID); err != nil {
+			t.Fatalf("Error reassigning device: %v", err)
+		}
+		now := time.Now()
+		resolvedBy := "test"
+		task.Status = utils.RecoveryTaskReassigned
+		task.ResolvedAt = &now
+		task.ResolvedBy = &resolvedBy
+		if err := dao.ResolveTask(ctx, tx, task); err != nil {
+			t.Fatalf("Error resolving recovery task: %v", err)
+		}
+		got, err := dao.GetTask(ctx, tx, task.ID)
+		if err != nil {
+			t.Fatalf("Error getting recovery task: %v", err)
+		}
+		if got.Status != utils.RecoveryTaskReassigned || got.ResolvedBy == nil {
+			t.Fatalf("Expected resolved task, got %+v", got)
+		}
+		devices, err := dao.GetDevices(ctx, tx, ownerID)
+		if err != nil {
+			t.Fatalf("Error getting devices: %v", err)
+		}
+		if len(devices) != 0 {
+			t.Fatalf("Expected no devices left, got %v", devices)
+		}
+	})
+}
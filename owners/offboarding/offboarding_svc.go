@@ -0,0 +1,309 @@
+package offboarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/devices/components"
+	"github.com/rickCrz7/Inventory-API/devices/logs"
+	"github.com/rickCrz7/Inventory-API/owners"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	logTypeOffboarding = "offboarding"
+	logTypeRecovery    = "recovery"
+)
+
+var (
+	ErrInvalidRequest = errors.New("invalid offboarding request")
+	ErrTaskResolved   = errors.New("recovery task is already resolved")
+	ErrDeviceMoved    = errors.New("device no longer belongs to the departing owner")
+)
+
+// Report lists what a departing owner still holds and where each recovery
+// task stands. Outstanding counts devices still to be recovered; lost devices
+// stay assigned to the owner to keep their record and do not hold up
+// ReadyForRemoval.
+type Report struct {
+	Owner           *utils.Owner          `json:"owner"`
+	Devices         []*utils.Device       `json:"devices"`
+	Tasks           []*utils.RecoveryTask `json:"tasks"`
+	Outstanding     int                   `json:"outstanding"`
+	ReadyForRemoval bool                  `json:"ready_for_removal"`
+}
+
+type Request struct {
+	CreatedBy string `json:"created_by"`
+	Note      string `json:"note"`
+}
+
+// Resolution closes a recovery task. Status is either "reassigned", which
+// moves the device to OwnerID, or "lost".
+type Resolution struct {
+	Status     string `json:"status"`
+	OwnerID    string `json:"owner_id"`
+	Note       string `json:"note"`
+	ResolvedBy string `json:"resolved_by"`
+}
+
+func (r *Resolution) validate() error {
+	if r.ResolvedBy == "" {
+		return fmt.Errorf("%w: resolved_by is required", ErrInvalidRequest)
+	}
+	switch r.Status {
+	case utils.RecoveryTaskReassigned:
+		if r.OwnerID == "" {
+			return fmt.Errorf("%w: owner_id is required to reassign a device", ErrInvalidRequest)
+		}
+	case utils.RecoveryTaskLost:
+	default:
+		return fmt.Errorf("%w: status must be %q or %q", ErrInvalidRequest, utils.RecoveryTaskReassigned, utils.RecoveryTaskLost)
+	}
+	return nil
+}
+
+type Service struct {
+	dao       *Dao
+	ownersDao *owners.Dao
+	logsDao   *logs.Dao
+	statuses  *components.StatusSetter
+	pdb       *utils.DB
+}
+
+func NewService(dao *Dao, pdb *utils.DB) *Service {
+	return &Service{
+		dao:       dao,
+		ownersDao: owners.NewDao(),
+		logsDao:   logs.NewDao(),
+		statuses:  components.NewStatusSetter(),
+		pdb:       pdb,
+	}
+}
+
+// Offboard marks the owner as departing and opens a recovery task for every
+// device they hold that is not lost and has no open task yet. Running it
+// again is safe and only picks up devices assigned since the last run.
+func (s *Service) Offboard(ctx context.Context, ownerID string, req *Request) (*Report, error) {
+	ctx, span := tracing.Start(ctx, "offboarding.Offboard")
+	defer span.End()
+
+	if req.CreatedBy == "" {
+		return nil, fmt.Errorf("%w: created_by is required", ErrInvalidRequest)
+	}
+
+	var report *Report
+	err := s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		owner, err := s.ownersDao.GetOwner(ctx, tx, ownerID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get owner: %v", err)
+			return err
+		}
+		if owner.Status != utils.OwnerStatusDeparting {
+			if err := s.dao.SetOwnerStatus(ctx, tx, ownerID, utils.OwnerStatusDeparting); err != nil {
+				utils.Log(ctx).Errorf("Failed to mark owner as departing: %v", err)
+				return err
+			}
+			owner.Status = utils.OwnerStatusDeparting
+		}
+
+		devices, err := s.dao.GetDevices(ctx, tx, ownerID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get owner devices: %v", err)
+			return err
+		}
+		tasks, err := s.dao.GetTasks(ctx, tx, ownerID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get recovery tasks: %v", err)
+			return err
+		}
+		open := map[string]bool{}
+		for _, task := range tasks {
+			if task.Status == utils.RecoveryTaskOpen {
+				open[task.DeviceID] = true
+			}
+		}
+
+		now := time.Now()
+		for _, device := range devices {
+			if device.Status == utils.DeviceStatusLost || open[device.ID] {
+				continue
+			}
+			task := &utils.RecoveryTask{
+				OwnerID:   ownerID,
+				DeviceID:  device.ID,
+				Note:      req.Note,
+				CreatedAt: now,
+				CreatedBy: req.CreatedBy,
+			}
+			if err := s.dao.CreateTask(ctx, tx, task); err != nil {
+				utils.Log(ctx).Errorf("Failed to create recovery task: %v", err)
+				return err
+			}
+			tasks = append(tasks, task)
+
+			if err := s.logsDao.CreateLog(ctx, tx, &utils.DeviceLog{
+				DeviceID:  device.ID,
+				LogType:   logTypeOffboarding,
+				Note:      fmt.Sprintf("Owner %s %s is departing; recovery task %s opened", owner.FirstName, owner.LastName, task.ID),
+				CreatedAt: now,
+				CreatedBy: req.CreatedBy,
+			}); err != nil {
+				utils.Log(ctx).Errorf("Failed to write offboarding log: %v", err)
+				return err
+			}
+		}
+
+		report = newReport(owner, devices, tasks)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	utils.Log(ctx).WithFields(log.Fields{
+		"OwnerID": ownerID,
+		"Devices": len(report.Devices),
+		"Tasks":   len(report.Tasks),
+	}).Info("Owner offboarding started")
+	return report, nil
+}
+
+func (s *Service) GetReport(ctx context.Context, ownerID string) (*Report, error) {
+	ctx, span := tracing.Start(ctx, "offboarding.GetReport")
+	defer span.End()
+
+	var report *Report
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		owner, err := s.ownersDao.GetOwner(ctx, tx, ownerID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get owner: %v", err)
+			return err
+		}
+		devices, err := s.dao.GetDevices(ctx, tx, ownerID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get owner devices: %v", err)
+			return err
+		}
+		tasks, err := s.dao.GetTasks(ctx, tx, ownerID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get recovery tasks: %v", err)
+			return err
+		}
+
+		report = newReport(owner, devices, tasks)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ResolveTask closes an open recovery task by reassigning its device to an
+// active owner or marking it lost, and logs the outcome on the device.
+func (s *Service) ResolveTask(ctx context.Context, taskID string, resolution *Resolution) (*utils.RecoveryTask, error) {
+	ctx, span := tracing.Start(ctx, "offboarding.ResolveTask")
+	defer span.End()
+
+	if err := resolution.validate(); err != nil {
+		return nil, err
+	}
+
+	var task *utils.RecoveryTask
+	err := s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		task, err = s.dao.GetTask(ctx, tx, taskID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get recovery task: %v", err)
+			return err
+		}
+		if task.Status != utils.RecoveryTaskOpen {
+			return fmt.Errorf("%w: %s is %s", ErrTaskResolved, task.ID, task.Status)
+		}
+		ownerID, err := s.dao.LockDeviceOwner(ctx, tx, task.DeviceID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get device owner: %v", err)
+			return err
+		}
+		if ownerID != task.OwnerID {
+			return fmt.Errorf("%w: device %s is now assigned to %s", ErrDeviceMoved, task.DeviceID, ownerID)
+		}
+
+		var note string
+		switch resolution.Status {
+		case utils.RecoveryTaskReassigned:
+			newOwner, err := s.ownersDao.GetOwner(ctx, tx, resolution.OwnerID)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: owner %s does not exist", ErrInvalidRequest, resolution.OwnerID)
+			}
+			if err != nil {
+				utils.Log(ctx).Errorf("Failed to get new owner: %v", err)
+				return err
+			}
+			if newOwner.ID == task.OwnerID || newOwner.Status != utils.OwnerStatusActive {
+				return fmt.Errorf("%w: device must be reassigned to another active owner", ErrInvalidRequest)
+			}
+			if err := s.dao.ReassignDevice(ctx, tx, task.DeviceID, newOwner.ID); err != nil {
+				utils.Log(ctx).Errorf("Failed to reassign device: %v", err)
+				return err
+			}
+			note = fmt.Sprintf("Recovered from departing owner and reassigned to %s %s", newOwner.FirstName, newOwner.LastName)
+		case utils.RecoveryTaskLost:
+			if err := s.statuses.SetStatus(ctx, tx, task.DeviceID, utils.DeviceStatusLost); err != nil {
+				utils.Log(ctx).Errorf("Failed to mark device as lost: %v", err)
+				return err
+			}
+			note = "Not recovered from departing owner; marked lost"
+		}
+		if resolution.Note != "" {
+			note += ": " + resolution.Note
+		}
+
+		now := time.Now()
+		task.Status = resolution.Status
+		task.Note = resolution.Note
+		task.ResolvedAt = &now
+		task.ResolvedBy = &resolution.ResolvedBy
+		if err := s.dao.ResolveTask(ctx, tx, task); err != nil {
+			utils.Log(ctx).Errorf("Failed to resolve recovery task: %v", err)
+			return err
+		}
+		if err := s.logsDao.CreateLog(ctx, tx, &utils.DeviceLog{
+			DeviceID:  task.DeviceID,
+			LogType:   logTypeRecovery,
+			Note:      note,
+			CreatedAt: now,
+			CreatedBy: resolution.ResolvedBy,
+		}); err != nil {
+			utils.Log(ctx).Errorf("Failed to write recovery log: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// newReport counts the devices the owner still holds that are not lost. Once
+// none remain the owner can be deleted.
+func newReport(owner *utils.Owner, devices []*utils.Device, tasks []*utils.RecoveryTask) *Report {
+	report := &Report{
+		Owner:   owner,
+		Devices: devices,
+		Tasks:   tasks,
+	}
+	for _, device := range devices {
+		if device.Status != utils.DeviceStatusLost {
+			report.Outstanding++
+		}
+	}
+	report.ReadyForRemoval = report.Outstanding == 0
+	return report
+}
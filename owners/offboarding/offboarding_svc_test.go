@@ -0,0 +1,61 @@
+package offboarding
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+func TestResolutionValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		resolution Resolution
+		wantErr    bool
+	}{
+		{"Reassigned", Resolution{Status: utils.RecoveryTaskReassigned, OwnerID: "o1", ResolvedBy: "admin"}, false},
+		{"Lost", Resolution{Status: utils.RecoveryTaskLost, ResolvedBy: "admin"}, false},
+		{"ReassignedWithoutOwner", Resolution{Status: utils.RecoveryTaskReassigned, ResolvedBy: "admin"}, true},
+		{"MissingResolvedBy", Resolution{Status: utils.RecoveryTaskLost}, true},
+		{"OpenStatus", Resolution{Status: utils.RecoveryTaskOpen, ResolvedBy: "admin"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.resolution.validate()
+			if tt.wantErr && !errors.Is(err, ErrInvalidRequest) {
+				t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	owner := &utils.Owner{ID: "o1", Status: utils.OwnerStatusDeparting}
+	devices := []*utils.Device{
+		{ID: "d1", Status: "active"},
+		{ID: "d2", Status: utils.DeviceStatusLost},
+	}
+	report := newReport(owner, devices, nil)
+	if report.Outstanding != 1 {
+		t.Fatalf("Expected 1 outstanding device, got %d", report.Outstanding)
+	}
+	if report.ReadyForRemoval {
+		t.Fatal("Expected owner not to be ready for removal")
+	}
+
+	report = newReport(owner, devices[1:], nil)
+	if report.Outstanding != 0 {
+		t.Fatalf("Expected no outstanding devices, got %d", report.Outstanding)
+	}
+	if !report.ReadyForRemoval {
+		t.Fatal("Expected owner with only lost devices to be ready for removal")
+	}
+
+	report = newReport(owner, nil, nil)
+	if !report.ReadyForRemoval {
+		t.Fatal("Expected owner without devices to be ready for removal")
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/jackc/pgx/v5"
 	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/rickCrz7/Inventory-API/utils"
-	log "github.com/sirupsen/logrus"
 )
 
 type Dao struct{}
@@ -16,60 +15,60 @@ func NewDao() *Dao {
 }
 
 func (d *Dao) GetOwner(ctx context.Context, tx pgx.Tx, id string) (*utils.Owner, error) {
-	log.Printf("Fetching owner with ID: %s", id)
-	query := `SELECT id, first_name, last_name, campus_id, email
+	utils.Log(ctx).Printf("Fetching owner with ID: %s", id)
+	query := `SELECT id, first_name, last_name, campus_id, email, status, department_id
 	FROM owners 
 	WHERE id = $1`
 	row := tx.QueryRow(ctx, query, id)
 	var owner utils.Owner
 	err := row.Scan(&owner.ID, &owner.FirstName, &owner.LastName,
-		&owner.CampusID, &owner.Email)
+		&owner.CampusID, &owner.Email, &owner.Status, &owner.DepartmentID)
 	if err != nil {
-		log.Errorf("Could not get owner %s: %v", id, err)
+		utils.Log(ctx).Errorf("Could not get owner %s: %v", id, err)
 		return nil, err
 	}
 	return &owner, nil
 }
 
 func (d *Dao) GetOwnerByCampusID(ctx context.Context, tx pgx.Tx, campus_id string) (*utils.Owner, error) {
-	log.Printf("Fetching owner with Campus ID: %s", campus_id)
-	query := `SELECT id, first_name, last_name, campus_id, email
+	utils.Log(ctx).Printf("Fetching owner with Campus ID: %s", campus_id)
+	query := `SELECT id, first_name, last_name, campus_id, email, status, department_id
 	FROM owners
 	WHERE campus_id = $1`
 	row := tx.QueryRow(ctx, query, campus_id)
 	var owner utils.Owner
 	err := row.Scan(&owner.ID, &owner.FirstName, &owner.LastName,
-		&owner.CampusID, &owner.Email)
+		&owner.CampusID, &owner.Email, &owner.Status, &owner.DepartmentID)
 	if err != nil {
-		log.Errorf("Could not get owner %s: %v", campus_id, err)
+		utils.Log(ctx).Errorf("Could not get owner %s: %v", campus_id, err)
 		return nil, err
 	}
 	return &owner, nil
 }
 
 func (d *Dao) GetOwnerByEmail(ctx context.Context, tx pgx.Tx, email string) (*utils.Owner, error) {
-	log.Printf("Fetching owner with Email: %s", email)
-	query := `SELECT id, first_name, last_name, campus_id, email
+	utils.Log(ctx).Printf("Fetching owner with Email: %s", email)
+	query := `SELECT id, first_name, last_name, campus_id, email, status, department_id
 	FROM owners
 	WHERE email = $1`
 	row := tx.QueryRow(ctx, query, email)
 	var owner utils.Owner
 	err := row.Scan(&owner.ID, &owner.FirstName, &owner.LastName,
-		&owner.CampusID, &owner.Email)
+		&owner.CampusID, &owner.Email, &owner.Status, &owner.DepartmentID)
 	if err != nil {
-		log.Errorf("Could not get owner %s: %v", email, err)
+		utils.Log(ctx).Errorf("Could not get owner %s: %v", email, err)
 		return nil, err
 	}
 	return &owner, nil
 }
 
 func (d *Dao) GetOwners(ctx context.Context, tx pgx.Tx) ([]*utils.Owner, error) {
-	log.Printf("Fetching all owners")
-	query := `SELECT id, first_name, last_name, campus_id, email
+	utils.Log(ctx).Printf("Fetching all owners")
+	query := `SELECT id, first_name, last_name, campus_id, email, status, department_id
 	FROM owners`
 	rows, err := tx.Query(ctx, query)
 	if err != nil {
-		log.Errorf("Could not get owners: %v", err)
+		utils.Log(ctx).Errorf("Could not get owners: %v", err)
 		return nil, err
 	}
 	defer rows.Close()
@@ -78,59 +77,116 @@ func (d *Dao) GetOwners(ctx context.Context, tx pgx.Tx) ([]*utils.Owner, error)
 	for rows.Next() {
 		var owner utils.Owner
 		if err := rows.Scan(&owner.ID, &owner.FirstName, &owner.LastName,
-			&owner.CampusID, &owner.Email); err != nil {
-			log.Errorf("Could not scan owner: %v", err)
+			&owner.CampusID, &owner.Email, &owner.Status, &owner.DepartmentID); err != nil {
+			utils.Log(ctx).Errorf("Could not scan owner: %v", err)
 			return nil, err
 		}
 		owners = append(owners, &owner)
 	}
 	if err := rows.Err(); err != nil {
-		log.Errorf("Error occurred while fetching owners: %v", err)
+		utils.Log(ctx).Errorf("Error occurred while fetching owners: %v", err)
 		return nil, err
 	}
 	return owners, nil
 }
 
 func (d *Dao) CreateOwner(ctx context.Context, tx pgx.Tx, owner *utils.Owner) error {
-	log.Printf("Creating owner: %v", owner)
+	utils.Log(ctx).Printf("Creating owner: %v", owner)
 	if owner.ID == "" {
 		var err error
 		owner.ID, err = gonanoid.New()
 		if err != nil {
-			log.Errorf("Could not generate owner ID: %v", err)
+			utils.Log(ctx).Errorf("Could not generate owner ID: %v", err)
 			return err
 		}
 	}
-	query := `INSERT INTO owners (id, first_name, last_name, email, campus_id)
-	VALUES ($1, $2, $3, $4, $5)`
-	_, err := tx.Exec(ctx, query, owner.ID, owner.FirstName, owner.LastName, owner.Email, owner.CampusID)
+	if owner.Status == "" {
+		owner.Status = utils.OwnerStatusActive
+	}
+	query := `INSERT INTO owners (id, first_name, last_name, email, campus_id, status, department_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := tx.Exec(ctx, query, owner.ID, owner.FirstName, owner.LastName, owner.Email, owner.CampusID, owner.Status, owner.DepartmentID)
 	if err != nil {
-		log.Errorf("Could not create owner: %v", err)
+		utils.Log(ctx).Errorf("Could not create owner: %v", err)
 		return err
 	}
 	return nil
 }
 
 func (d *Dao) UpdateOwner(ctx context.Context, tx pgx.Tx, owner *utils.Owner) error {
-	log.Printf("Updating owner: %v", owner)
+	utils.Log(ctx).Printf("Updating owner: %v", owner)
 	query := `UPDATE owners
-	SET first_name = $1, last_name = $2, email = $3, campus_id = $4
-	WHERE id = $5`
-	_, err := tx.Exec(ctx, query, owner.FirstName, owner.LastName, owner.Email, owner.CampusID, owner.ID)
+	SET first_name = $1, last_name = $2, email = $3, campus_id = $4, status = COALESCE(NULLIF($5, ''), status), department_id = $7
+	WHERE id = $6`
+	_, err := tx.Exec(ctx, query, owner.FirstName, owner.LastName, owner.Email, owner.CampusID, owner.Status, owner.ID, owner.DepartmentID)
 	if err != nil {
-		log.Errorf("Could not update owner: %v", err)
+		utils.Log(ctx).Errorf("Could not update owner: %v", err)
 		return err
 	}
 	return nil
 }
 
 func (d *Dao) DeleteOwner(ctx context.Context, tx pgx.Tx, id string) error {
-	log.Printf("Deleting owner with ID: %s", id)
+	utils.Log(ctx).Printf("Deleting owner with ID: %s", id)
 	query := `DELETE FROM owners WHERE id = $1`
 	_, err := tx.Exec(ctx, query, id)
 	if err != nil {
-		log.Errorf("Could not delete owner %s: %v", id, err)
+		utils.Log(ctx).Errorf("Could not delete owner %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+// SetStatus changes an owner's status.
+func (d *Dao) SetStatus(ctx context.Context, tx pgx.Tx, id string, status string) error {
+	utils.Log(ctx).Printf("Setting status of owner %s to %s", id, status)
+	query := `UPDATE owners SET status = $1 WHERE id = $2`
+	if _, err := tx.Exec(ctx, query, status, id); err != nil {
+		utils.Log(ctx).Errorf("Could not set status of owner %s: %v", id, err)
 		return err
 	}
 	return nil
 }
+
+// CountDevices counts the devices assigned to the owner that are still to be
+// recovered and the ones marked lost.
+func (d *Dao) CountDevices(ctx context.Context, tx pgx.Tx, id string) (int, int, error) {
+	utils.Log(ctx).Printf("Counting devices for owner with ID: %s", id)
+	query := `SELECT count(*) FILTER (WHERE status <> $2), count(*) FILTER (WHERE status = $2)
+	FROM devices WHERE owner_id = $1`
+	var outstanding, lost int
+	if err := tx.QueryRow(ctx, query, id, utils.DeviceStatusLost).Scan(&outstanding, &lost); err != nil {
+		utils.Log(ctx).Errorf("Could not count devices for owner %s: %v", id, err)
+		return 0, 0, err
+	}
+	return outstanding, lost, nil
+}
+
+func (d *Dao) GetOwnersByIDs(ctx context.Context, tx pgx.Tx, ids []string) ([]*utils.Owner, error) {
+	utils.Log(ctx).Printf("Fetching owners with IDs: %v", ids)
+	query := `SELECT id, first_name, last_name, campus_id, email, status, department_id
+	FROM owners
+	WHERE id = ANY($1)`
+	rows, err := tx.Query(ctx, query, ids)
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not get owners %v: %v", ids, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var owners []*utils.Owner
+	for rows.Next() {
+		var owner utils.Owner
+		if err := rows.Scan(&owner.ID, &owner.FirstName, &owner.LastName,
+			&owner.CampusID, &owner.Email, &owner.Status, &owner.DepartmentID); err != nil {
+			utils.Log(ctx).Errorf("Could not scan owner: %v", err)
+			return nil, err
+		}
+		owners = append(owners, &owner)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error occurred while fetching owners: %v", err)
+		return nil, err
+	}
+	return owners, nil
+}
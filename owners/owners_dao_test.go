@@ -2,6 +2,7 @@ package owners
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path"
 	"runtime"
@@ -38,7 +39,7 @@ func init() {
 }
 
 func TestGetOwner(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -86,7 +87,7 @@ func TestGetOwner(t *testing.T) {
 }
 
 func TestGetOwnerByCampusID(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -135,7 +136,7 @@ func TestGetOwnerByCampusID(t *testing.T) {
 }
 
 func TestGetOwnerByEmail(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -183,7 +184,7 @@ func TestGetOwnerByEmail(t *testing.T) {
 }
 
 func TestGetOwners(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -221,7 +222,7 @@ func TestGetOwners(t *testing.T) {
 }
 
 func TestCreateOwner(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -280,7 +281,7 @@ func TestCreateOwner(t *testing.T) {
 }
 
 func TestUpdateOwner(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -330,7 +331,7 @@ func TestUpdateOwner(t *testing.T) {
 }
 
 func TestDeleteOwner(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -369,3 +370,115 @@ func TestDeleteOwner(t *testing.T) {
 		}
 	})
 }
+
+func TestDeleteOwnerWithDevices(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+	svc := NewService(dao, utils.NewDB(pdb, nil, 0))
+
+	// Mock Data
+	id, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO owners (id, first_name, last_name, email)
+		VALUES ($1, $2, $3, $4)
+	`, id, "John", "Doe", "john.doe@example.com")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	typeID, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO types (id, name, description) VALUES ($1, $2, $3)
+	`, typeID, "Test Type", "This is a test type")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	deviceID, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO devices (id, serial_number, name, purchase_date, status, owner_id, type_id) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, deviceID, "SN-O1", "Test Device", "2023-01-01", "active", id, typeID)
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+
+	t.Run("DeleteOwnerWithDevice", func(t *testing.T) {
+		err := svc.deleteOwner(ctx, tx, id)
+		if !errors.Is(err, ErrOwnerHasDevices) {
+			t.Fatalf("Expected ErrOwnerHasDevices, got %v", err)
+		}
+	})
+
+	t.Run("DeleteOwnerWithLostDevice", func(t *testing.T) {
+		if _, err := tx.Exec(ctx, `UPDATE devices SET status = $1 WHERE id = $2`, utils.DeviceStatusLost, deviceID); err != nil {
+			t.Fatalf("Error marking device lost: %v", err)
+		}
+		if err := svc.deleteOwner(ctx, tx, id); err != nil {
+			t.Fatalf("Error deleting owner: %v", err)
+		}
+		owner, err := dao.GetOwner(ctx, tx, id)
+		if err != nil {
+			t.Fatalf("Expected the owner to be kept, got %v", err)
+		}
+		if owner.Status != utils.OwnerStatusRemoved {
+			t.Errorf("Expected status %q, got %q", utils.OwnerStatusRemoved, owner.Status)
+		}
+		var count int
+		if err := tx.QueryRow(ctx, `SELECT count(*) FROM devices WHERE id = $1`, deviceID).Scan(&count); err != nil {
+			t.Fatalf("Error counting devices: %v", err)
+		}
+		if count != 1 {
+			t.Errorf("Expected the lost device to be kept, got %d", count)
+		}
+	})
+}
+
+func TestGetOwnersByIDs(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+
+	// Mock Data
+	var ids []string
+	for i := 0; i < 3; i++ {
+		id, _ := gonanoid.New()
+		_, err = tx.Exec(ctx, `
+			INSERT INTO owners (id, first_name, last_name, email)
+			VALUES ($1, $2, $3, $4)
+		`, id, "John", "Doe", "john.doe@example.com")
+		if err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	t.Run("GetOwnersByIDs", func(t *testing.T) {
+		owners, err := dao.GetOwnersByIDs(ctx, tx, []string{ids[0], ids[1], "nonexistent-id"})
+		if err != nil {
+			t.Fatalf("Error getting owners: %v", err)
+		}
+		if len(owners) != 2 {
+			t.Fatalf("Expected 2 owners, but got %d", len(owners))
+		}
+	})
+}
@@ -2,6 +2,7 @@ package owners
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -70,7 +71,7 @@ func (h *Handler) GetOwners(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) CreateOwner(w http.ResponseWriter, r *http.Request) {
 	var owner utils.Owner
 	if err := json.NewDecoder(r.Body).Decode(&owner); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
 		return
 	}
 	if err := h.svc.CreateOwner(r.Context(), &owner); err != nil {
@@ -84,7 +85,7 @@ func (h *Handler) CreateOwner(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) UpdateOwner(w http.ResponseWriter, r *http.Request) {
 	var owner utils.Owner
 	if err := json.NewDecoder(r.Body).Decode(&owner); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
 		return
 	}
 	if err := h.svc.UpdateOwner(r.Context(), &owner); err != nil {
@@ -97,7 +98,12 @@ func (h *Handler) UpdateOwner(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) DeleteOwner(w http.ResponseWriter, r *http.Request) {
 	id := mux.Vars(r)["id"]
-	if err := h.svc.DeleteOwner(r.Context(), id); err != nil {
+	err := h.svc.DeleteOwner(r.Context(), id)
+	if errors.Is(err, ErrOwnerHasDevices) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
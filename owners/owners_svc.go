@@ -2,19 +2,24 @@ package owners
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rickCrz7/Inventory-API/tracing"
 	"github.com/rickCrz7/Inventory-API/utils"
-	log "github.com/sirupsen/logrus"
 )
 
+// ErrOwnerHasDevices is returned when deleting an owner who still holds
+// devices that are not lost. Offboard the owner first.
+var ErrOwnerHasDevices = errors.New("owner still has devices, reassign them or mark them lost first")
+
 type Service struct {
 	dao *Dao
-	pdb *pgxpool.Pool
+	pdb *utils.DB
 }
 
-func NewService(dao *Dao, pdb *pgxpool.Pool) *Service {
+func NewService(dao *Dao, pdb *utils.DB) *Service {
 	return &Service{
 		dao: dao,
 		pdb: pdb,
@@ -22,159 +27,165 @@ func NewService(dao *Dao, pdb *pgxpool.Pool) *Service {
 }
 
 func (s *Service) GetOwner(ctx context.Context, id string) (*utils.Owner, error) {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadOnly,
+	ctx, span := tracing.Start(ctx, "owners.GetOwner")
+	defer span.End()
+
+	var owner *utils.Owner
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		owner, err = s.dao.GetOwner(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get owner: %v", err)
+			return err
+		}
+		return nil
 	})
 	if err != nil {
-		log.Errorf("Failed to begin transaction: %v", err)
-		return nil, err
-	}
-	defer tx.Rollback(ctx)
-
-	owner, err := s.dao.GetOwner(ctx, tx, id)
-	if err != nil {
-		log.Errorf("Failed to get owner: %v", err)
-		return nil, err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Failed to commit transaction: %v", err)
 		return nil, err
 	}
 	return owner, nil
 }
 
 func (s *Service) GetOwnerByCampusID(ctx context.Context, campusID string) (*utils.Owner, error) {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadOnly,
+	ctx, span := tracing.Start(ctx, "owners.GetOwnerByCampusID")
+	defer span.End()
+
+	var owner *utils.Owner
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		owner, err = s.dao.GetOwnerByCampusID(ctx, tx, campusID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get owner: %v", err)
+			return err
+		}
+		return nil
 	})
 	if err != nil {
-		log.Errorf("Failed to begin transaction: %v", err)
-		return nil, err
-	}
-	defer tx.Rollback(ctx)
-
-	owner, err := s.dao.GetOwnerByCampusID(ctx, tx, campusID)
-	if err != nil {
-		log.Errorf("Failed to get owner: %v", err)
-		return nil, err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Failed to commit transaction: %v", err)
 		return nil, err
 	}
 	return owner, nil
 }
 
 func (s *Service) GetOwnerByEmail(ctx context.Context, email string) (*utils.Owner, error) {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadOnly,
+	ctx, span := tracing.Start(ctx, "owners.GetOwnerByEmail")
+	defer span.End()
+
+	var owner *utils.Owner
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		owner, err = s.dao.GetOwnerByEmail(ctx, tx, email)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get owner: %v", err)
+			return err
+		}
+		return nil
 	})
 	if err != nil {
-		log.Errorf("Failed to begin transaction: %v", err)
-		return nil, err
-	}
-	defer tx.Rollback(ctx)
-
-	owner, err := s.dao.GetOwnerByEmail(ctx, tx, email)
-	if err != nil {
-		log.Errorf("Failed to get owner: %v", err)
-		return nil, err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Failed to commit transaction: %v", err)
 		return nil, err
 	}
 	return owner, nil
 }
 
 func (s *Service) GetOwners(ctx context.Context) ([]*utils.Owner, error) {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadOnly,
+	ctx, span := tracing.Start(ctx, "owners.GetOwners")
+	defer span.End()
+
+	var owners []*utils.Owner
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		owners, err = s.dao.GetOwners(ctx, tx)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get owners: %v", err)
+			return err
+		}
+		return nil
 	})
 	if err != nil {
-		log.Errorf("Failed to begin transaction: %v", err)
-		return nil, err
-	}
-	defer tx.Rollback(ctx)
-
-	owners, err := s.dao.GetOwners(ctx, tx)
-	if err != nil {
-		log.Errorf("Failed to get owners: %v", err)
-		return nil, err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Failed to commit transaction: %v", err)
 		return nil, err
 	}
 	return owners, nil
 }
 
 func (s *Service) CreateOwner(ctx context.Context, owner *utils.Owner) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadWrite,
+	ctx, span := tracing.Start(ctx, "owners.CreateOwner")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.dao.CreateOwner(ctx, tx, owner); err != nil {
+			utils.Log(ctx).Errorf("Failed to create owner: %v", err)
+			return err
+		}
+		return nil
 	})
-	if err != nil {
-		log.Errorf("Failed to begin transaction: %v", err)
-		return err
-	}
-	defer tx.Rollback(ctx)
-
-	if err := s.dao.CreateOwner(ctx, tx, owner); err != nil {
-		log.Errorf("Failed to create owner: %v", err)
-		return err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Failed to commit transaction: %v", err)
-		return err
-	}
-	return nil
 }
 
 func (s *Service) UpdateOwner(ctx context.Context, owner *utils.Owner) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadWrite,
+	ctx, span := tracing.Start(ctx, "owners.UpdateOwner")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.dao.UpdateOwner(ctx, tx, owner); err != nil {
+			utils.Log(ctx).Errorf("Failed to update owner: %v", err)
+			return err
+		}
+		return nil
 	})
-	if err != nil {
-		log.Errorf("Failed to begin transaction: %v", err)
-		return err
-	}
-	defer tx.Rollback(ctx)
-
-	if err := s.dao.UpdateOwner(ctx, tx, owner); err != nil {
-		log.Errorf("Failed to update owner: %v", err)
-		return err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Failed to commit transaction: %v", err)
-		return err
-	}
-	return nil
 }
 
+// DeleteOwner removes an owner once every device has been reassigned or
+// marked lost. Deleting the row would cascade to the owner's devices, so an
+// owner with lost devices is only marked removed to keep their records.
 func (s *Service) DeleteOwner(ctx context.Context, id string) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadWrite,
+	ctx, span := tracing.Start(ctx, "owners.DeleteOwner")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		return s.deleteOwner(ctx, tx, id)
 	})
+}
+
+// deleteOwner does the work of DeleteOwner inside tx.
+func (s *Service) deleteOwner(ctx context.Context, tx pgx.Tx, id string) error {
+	outstanding, lost, err := s.dao.CountDevices(ctx, tx, id)
 	if err != nil {
-		log.Errorf("Failed to begin transaction: %v", err)
+		utils.Log(ctx).Errorf("Failed to count owner devices: %v", err)
 		return err
 	}
-	defer tx.Rollback(ctx)
+	if outstanding > 0 {
+		utils.Log(ctx).Errorf("Refusing to delete owner %s with %d devices", id, outstanding)
+		return fmt.Errorf("%w: %d remaining", ErrOwnerHasDevices, outstanding)
+	}
 
+	if lost > 0 {
+		if err := s.dao.SetStatus(ctx, tx, id, utils.OwnerStatusRemoved); err != nil {
+			utils.Log(ctx).Errorf("Failed to mark owner removed: %v", err)
+			return err
+		}
+		return nil
+	}
 	if err := s.dao.DeleteOwner(ctx, tx, id); err != nil {
-		log.Errorf("Failed to delete owner: %v", err)
+		utils.Log(ctx).Errorf("Failed to delete owner: %v", err)
 		return err
 	}
+	return nil
+}
 
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Failed to commit transaction: %v", err)
-		return err
+func (s *Service) GetOwnersByIDs(ctx context.Context, ids []string) ([]*utils.Owner, error) {
+	ctx, span := tracing.Start(ctx, "owners.GetOwnersByIDs")
+	defer span.End()
+
+	var owners []*utils.Owner
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		owners, err = s.dao.GetOwnersByIDs(ctx, tx, ids)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get owners: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return owners, nil
 }
@@ -0,0 +1,309 @@
+package purchaseorders
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Dao struct{}
+
+func NewDao() *Dao {
+	return &Dao{}
+}
+
+const orderColumns = `id, po_number, vendor_id, status, currency, note, created_at, created_by, ordered_at`
+
+func scanOrder(row pgx.Row, order *utils.PurchaseOrder) error {
+	return row.Scan(&order.ID, &order.PONumber, &order.VendorID, &order.Status, &order.Currency, &order.Note,
+		&order.CreatedAt, &order.CreatedBy, &order.OrderedAt)
+}
+
+func (d *Dao) GetOrder(ctx context.Context, tx pgx.Tx, id string) (*utils.PurchaseOrder, error) {
+	utils.Log(ctx).Printf("Fetching purchase order with ID: %s", id)
+	query := `SELECT ` + orderColumns + ` FROM purchase_orders WHERE id = $1`
+	var order utils.PurchaseOrder
+	if err := scanOrder(tx.QueryRow(ctx, query, id), &order); err != nil {
+		utils.Log(ctx).Errorf("Error fetching purchase order with ID %s: %v", id, err)
+		return nil, err
+	}
+	return &order, nil
+}
+
+// LockOrder fetches a purchase order and locks it until the transaction
+// ends, so concurrent receipts and edits are applied one at a time.
+func (d *Dao) LockOrder(ctx context.Context, tx pgx.Tx, id string) (*utils.PurchaseOrder, error) {
+	utils.Log(ctx).Printf("Locking purchase order with ID: %s", id)
+	query := `SELECT ` + orderColumns + ` FROM purchase_orders WHERE id = $1 FOR UPDATE`
+	var order utils.PurchaseOrder
+	if err := scanOrder(tx.QueryRow(ctx, query, id), &order); err != nil {
+		utils.Log(ctx).Errorf("Error locking purchase order with ID %s: %v", id, err)
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (d *Dao) GetOrderByNumber(ctx context.Context, tx pgx.Tx, poNumber string) (*utils.PurchaseOrder, error) {
+	utils.Log(ctx).Printf("Fetching purchase order with number: %s", poNumber)
+	query := `SELECT ` + orderColumns + ` FROM purchase_orders WHERE po_number = $1`
+	var order utils.PurchaseOrder
+	if err := scanOrder(tx.QueryRow(ctx, query, poNumber), &order); err != nil {
+		utils.Log(ctx).Errorf("Error fetching purchase order with number %s: %v", poNumber, err)
+		return nil, err
+	}
+	return &order, nil
+}
+
+// GetOrders returns purchase orders, newest first, optionally only those of
+// one vendor or in one status.
+func (d *Dao) GetOrders(ctx context.Context, tx pgx.Tx, vendorID string, status string) ([]*utils.PurchaseOrder, error) {
+	utils.Log(ctx).Printf("Fetching purchase orders (vendor: %q, status: %q)", vendorID, status)
+	query := `SELECT ` + orderColumns + ` FROM purchase_orders
+	WHERE ($1 = '' OR vendor_id = $1) AND ($2 = '' OR status = $2)
+	ORDER BY created_at DESC`
+	rows, err := tx.Query(ctx, query, vendorID, status)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching purchase orders: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []*utils.PurchaseOrder
+	for rows.Next() {
+		var order utils.PurchaseOrder
+		if err := scanOrder(rows, &order); err != nil {
+			utils.Log(ctx).Errorf("Error scanning purchase order row: %v", err)
+			return nil, err
+		}
+		orders = append(orders, &order)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over purchase order rows: %v", err)
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (d *Dao) CreateOrder(ctx context.Context, tx pgx.Tx, order *utils.PurchaseOrder) error {
+	utils.Log(ctx).Printf("Creating purchase order: %s", order.PONumber)
+	if order.ID == "" {
+		id, err := gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Error generating ID for new purchase order: %v", err)
+			return err
+		}
+		order.ID = id
+	}
+	query := `INSERT INTO purchase_orders (` + orderColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := tx.Exec(ctx, query, order.ID, order.PONumber, order.VendorID, order.Status, order.Currency, order.Note,
+		order.CreatedAt, order.CreatedBy, order.OrderedAt)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error creating purchase order: %v", err)
+		return err
+	}
+	return nil
+}
+
+// UpdateOrder changes the editable header fields of a purchase order.
+func (d *Dao) UpdateOrder(ctx context.Context, tx pgx.Tx, order *utils.PurchaseOrder) error {
+	utils.Log(ctx).Printf("Updating purchase order: %s", order.ID)
+	query := `UPDATE purchase_orders SET po_number = $2, vendor_id = $3, currency = $4, note = $5 WHERE id = $1`
+	tag, err := tx.Exec(ctx, query, order.ID, order.PONumber, order.VendorID, order.Currency, order.Note)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error updating purchase order with ID %s: %v", order.ID, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// SetStatus changes the status of a purchase order. orderedAt is only
+// written when it is not nil.
+func (d *Dao) SetStatus(ctx context.Context, tx pgx.Tx, id string, status string, orderedAt *time.Time) error {
+	utils.Log(ctx).Printf("Setting status of purchase order %s to %s", id, status)
+	query := `UPDATE purchase_orders SET status = $2, ordered_at = COALESCE($3, ordered_at) WHERE id = $1`
+	if _, err := tx.Exec(ctx, query, id, status, orderedAt); err != nil {
+		utils.Log(ctx).Errorf("Error setting status of purchase order %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+func (d *Dao) DeleteOrder(ctx context.Context, tx pgx.Tx, id string) error {
+	utils.Log(ctx).Printf("Deleting purchase order with ID: %s", id)
+	query := `DELETE FROM purchase_orders WHERE id = $1`
+	tag, err := tx.Exec(ctx, query, id)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error deleting purchase order with ID %s: %v", id, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+const lineColumns = `id, purchase_order_id, type_id, description, quantity, unit_cost, received`
+
+func scanLine(row pgx.Row, line *utils.PurchaseOrderLine) error {
+	return row.Scan(&line.ID, &line.PurchaseOrderID, &line.TypeID, &line.Description, &line.Quantity, &line.UnitCost, &line.Received)
+}
+
+func (d *Dao) GetLines(ctx context.Context, tx pgx.Tx, orderID string) ([]*utils.PurchaseOrderLine, error) {
+	utils.Log(ctx).Printf("Fetching lines of purchase order %s", orderID)
+	query := `SELECT ` + lineColumns + ` FROM purchase_order_lines WHERE purchase_order_id = $1 ORDER BY id`
+	rows, err := tx.Query(ctx, query, orderID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching lines of purchase order %s: %v", orderID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []*utils.PurchaseOrderLine
+	for rows.Next() {
+		var line utils.PurchaseOrderLine
+		if err := scanLine(rows, &line); err != nil {
+			utils.Log(ctx).Errorf("Error scanning purchase order line row: %v", err)
+			return nil, err
+		}
+		lines = append(lines, &line)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over purchase order line rows: %v", err)
+		return nil, err
+	}
+	return lines, nil
+}
+
+func (d *Dao) GetLine(ctx context.Context, tx pgx.Tx, orderID string, lineID string) (*utils.PurchaseOrderLine, error) {
+	utils.Log(ctx).Printf("Fetching line %s of purchase order %s", lineID, orderID)
+	query := `SELECT ` + lineColumns + ` FROM purchase_order_lines WHERE purchase_order_id = $1 AND id = $2`
+	var line utils.PurchaseOrderLine
+	if err := scanLine(tx.QueryRow(ctx, query, orderID, lineID), &line); err != nil {
+		utils.Log(ctx).Errorf("Error fetching line %s of purchase order %s: %v", lineID, orderID, err)
+		return nil, err
+	}
+	return &line, nil
+}
+
+func (d *Dao) CreateLine(ctx context.Context, tx pgx.Tx, line *utils.PurchaseOrderLine) error {
+	utils.Log(ctx).Printf("Creating line on purchase order %s: %+v", line.PurchaseOrderID, line)
+	if line.ID == "" {
+		id, err := gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Error generating ID for new purchase order line: %v", err)
+			return err
+		}
+		line.ID = id
+	}
+	query := `INSERT INTO purchase_order_lines (` + lineColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := tx.Exec(ctx, query, line.ID, line.PurchaseOrderID, line.TypeID, line.Description, line.Quantity, line.UnitCost, line.Received)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error creating purchase order line: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (d *Dao) DeleteLines(ctx context.Context, tx pgx.Tx, orderID string) error {
+	utils.Log(ctx).Printf("Deleting lines of purchase order %s", orderID)
+	query := `DELETE FROM purchase_order_lines WHERE purchase_order_id = $1`
+	if _, err := tx.Exec(ctx, query, orderID); err != nil {
+		utils.Log(ctx).Errorf("Error deleting lines of purchase order %s: %v", orderID, err)
+		return err
+	}
+	return nil
+}
+
+func (d *Dao) AddReceived(ctx context.Context, tx pgx.Tx, lineID string, quantity int) error {
+	utils.Log(ctx).Printf("Receiving %d on purchase order line %s", quantity, lineID)
+	query := `UPDATE purchase_order_lines SET received = received + $2 WHERE id = $1`
+	if _, err := tx.Exec(ctx, query, lineID, quantity); err != nil {
+		utils.Log(ctx).Errorf("Error receiving on purchase order line %s: %v", lineID, err)
+		return err
+	}
+	return nil
+}
+
+func (d *Dao) CreateReceipt(ctx context.Context, tx pgx.Tx, receipt *utils.PurchaseOrderReceipt) error {
+	utils.Log(ctx).Printf("Creating receipt on purchase order %s line %s", receipt.PurchaseOrderID, receipt.LineID)
+	if receipt.ID == "" {
+		id, err := gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Error generating ID for new receipt: %v", err)
+			return err
+		}
+		receipt.ID = id
+	}
+	query := `INSERT INTO purchase_order_receipts (id, purchase_order_id, line_id, quantity, device_ids, received_at, received_by, note)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := tx.Exec(ctx, query, receipt.ID, receipt.PurchaseOrderID, receipt.LineID, receipt.Quantity, receipt.DeviceIDs,
+		receipt.ReceivedAt, receipt.ReceivedBy, receipt.Note)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error creating receipt: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (d *Dao) GetReceipts(ctx context.Context, tx pgx.Tx, orderID string) ([]*utils.PurchaseOrderReceipt, error) {
+	utils.Log(ctx).Printf("Fetching receipts of purchase order %s", orderID)
+	query := `SELECT id, purchase_order_id, line_id, quantity, device_ids, received_at, received_by, note
+	FROM purchase_order_receipts
+	WHERE purchase_order_id = $1
+	ORDER BY received_at`
+	rows, err := tx.Query(ctx, query, orderID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching receipts of purchase order %s: %v", orderID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var receipts []*utils.PurchaseOrderReceipt
+	for rows.Next() {
+		var receipt utils.PurchaseOrderReceipt
+		if err := rows.Scan(&receipt.ID, &receipt.PurchaseOrderID, &receipt.LineID, &receipt.Quantity, &receipt.DeviceIDs,
+			&receipt.ReceivedAt, &receipt.ReceivedBy, &receipt.Note); err != nil {
+			utils.Log(ctx).Errorf("Error scanning receipt row: %v", err)
+			return nil, err
+		}
+		receipts = append(receipts, &receipt)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over receipt rows: %v", err)
+		return nil, err
+	}
+	return receipts, nil
+}
+
+// FindSerials returns those of serials that devices already have.
+func (d *Dao) FindSerials(ctx context.Context, tx pgx.Tx, serials []string) ([]string, error) {
+	utils.Log(ctx).Printf("Checking %d serial numbers", len(serials))
+	query := `SELECT DISTINCT serial_number FROM devices WHERE serial_number = ANY($1)`
+	rows, err := tx.Query(ctx, query, serials)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error checking serial numbers: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var found []string
+	for rows.Next() {
+		var serial string
+		if err := rows.Scan(&serial); err != nil {
+			utils.Log(ctx).Errorf("Error scanning serial number: %v", err)
+			return nil, err
+		}
+		found = append(found, serial)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over serial numbers: %v", err)
+		return nil, err
+	}
+	return found, nil
+}
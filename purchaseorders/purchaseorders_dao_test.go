@@ -0,0 +1,171 @@
+package purchaseorders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"runtime"
+	"testing"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/devices"
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+var postgresURI string
+
+func init() {
+	viper.SetConfigName("app")
+	viper.AddConfigPath("../config")
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+	postgresURI = viper.GetString("postgres.dev") // Change to "postgres.dev" for development/local db
+	log.SetReportCaller(true)
+	log.SetFormatter(&log.TextFormatter{
+		ForceColors:     true,
+		FullTimestamp:   true,
+		TimestampFormat: "2006/01/02 15:04:05",
+		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
+			filename := path.Base(f.File)
+			return fmt.Sprintf("%s()", f.Function), fmt.Sprintf("\t%s:%d", filename, f.Line)
+		},
+	})
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestReceive(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+	svc := NewService(dao, utils.NewDB(pdb, nil, 0), "USD")
+
+	// Mock Data
+	suffix, _ := gonanoid.New(8)
+	ownerID, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO owners (id, first_name, last_name, email)
+		VALUES ($1, $2, $3, $4)
+	`, ownerID, "IT", "Stock", "it.stock@example.com")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	typeID, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO types (id, name, description) VALUES ($1, $2, $3)
+	`, typeID, "Test Laptop", "This is a test type")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	vendorID, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO vendors (id, name) VALUES ($1, $2)
+	`, vendorID, "Test Vendor "+suffix)
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	now := time.Now()
+	order := &utils.PurchaseOrder{
+		PONumber:  "PO-" + suffix,
+		VendorID:  vendorID,
+		Status:    utils.PurchaseOrderOrdered,
+		Currency:  "USD",
+		CreatedAt: now,
+		CreatedBy: "tester",
+		OrderedAt: &now,
+	}
+	if err := dao.CreateOrder(ctx, tx, order); err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	line := &utils.PurchaseOrderLine{PurchaseOrderID: order.ID, TypeID: typeID, Quantity: 3, UnitCost: 899.99}
+	if err := dao.CreateLine(ctx, tx, line); err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	delivery := func(serials ...string) *Delivery {
+		return &Delivery{SerialNumbers: serials, OwnerID: ownerID, ReceivedBy: "tester"}
+	}
+
+	t.Run("ReceivePartial", func(t *testing.T) {
+		receipt, err := svc.receive(ctx, tx, order.ID, line.ID, delivery("SN-A-"+suffix, "SN-B-"+suffix))
+		if err != nil {
+			t.Fatalf("Error receiving line: %v", err)
+		}
+		if len(receipt.DeviceIDs) != 2 {
+			t.Fatalf("Expected 2 devices, got %d", len(receipt.DeviceIDs))
+		}
+		got, err := dao.GetLine(ctx, tx, order.ID, line.ID)
+		if err != nil {
+			t.Fatalf("Error getting line: %v", err)
+		}
+		if got.Received != 2 {
+			t.Errorf("Expected 2 received, got %d", got.Received)
+		}
+		current, err := dao.GetOrder(ctx, tx, order.ID)
+		if err != nil {
+			t.Fatalf("Error getting order: %v", err)
+		}
+		if current.Status != utils.PurchaseOrderPartiallyReceived {
+			t.Errorf("Expected %s, got %s", utils.PurchaseOrderPartiallyReceived, current.Status)
+		}
+		device, err := devices.NewDao().GetDevice(ctx, tx, receipt.DeviceIDs[0])
+		if err != nil {
+			t.Fatalf("Error getting device: %v", err)
+		}
+		if device.Status != utils.DeviceStatusReceived || device.PONumber == nil || *device.PONumber != order.PONumber {
+			t.Errorf("Expected a received device on %s, got %+v", order.PONumber, device)
+		}
+	})
+	t.Run("ReceiveTooMany", func(t *testing.T) {
+		_, err := svc.receive(ctx, tx, order.ID, line.ID, delivery("SN-C-"+suffix, "SN-D-"+suffix))
+		if !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+	t.Run("ReceiveSerialInUse", func(t *testing.T) {
+		_, err := svc.receive(ctx, tx, order.ID, line.ID, delivery("SN-A-"+suffix))
+		if !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+	t.Run("ReceiveRest", func(t *testing.T) {
+		if _, err := svc.receive(ctx, tx, order.ID, line.ID, delivery("SN-C-"+suffix)); err != nil {
+			t.Fatalf("Error receiving line: %v", err)
+		}
+		current, err := dao.GetOrder(ctx, tx, order.ID)
+		if err != nil {
+			t.Fatalf("Error getting order: %v", err)
+		}
+		if current.Status != utils.PurchaseOrderReceived {
+			t.Errorf("Expected %s, got %s", utils.PurchaseOrderReceived, current.Status)
+		}
+		receipts, err := dao.GetReceipts(ctx, tx, order.ID)
+		if err != nil {
+			t.Fatalf("Error getting receipts: %v", err)
+		}
+		if len(receipts) != 2 {
+			t.Errorf("Expected 2 receipts, got %d", len(receipts))
+		}
+	})
+	t.Run("ReceiveComplete", func(t *testing.T) {
+		_, err := svc.receive(ctx, tx, order.ID, line.ID, delivery("SN-D-"+suffix))
+		if !errors.Is(err, ErrWrongStatus) {
+			t.Errorf("Expected ErrWrongStatus, got %v", err)
+		}
+	})
+}
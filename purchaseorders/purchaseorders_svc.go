@@ -0,0 +1,528 @@
+package purchaseorders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/devices"
+	"github.com/rickCrz7/Inventory-API/devices/logs"
+	"github.com/rickCrz7/Inventory-API/owners"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/types"
+	"github.com/rickCrz7/Inventory-API/utils"
+	"github.com/rickCrz7/Inventory-API/vendors"
+)
+
+const logTypeReceived = "received"
+
+var (
+	ErrInvalidRequest = errors.New("invalid purchase order request")
+	// ErrPONumberTaken is returned when another purchase order already has
+	// the PO number.
+	ErrPONumberTaken = errors.New("po number is already used")
+	// ErrWrongStatus is returned when a purchase order is not in a status
+	// that allows the change, e.g. editing an order that has been placed.
+	ErrWrongStatus = errors.New("purchase order status does not allow this")
+)
+
+var statuses = []string{
+	utils.PurchaseOrderDraft,
+	utils.PurchaseOrderOrdered,
+	utils.PurchaseOrderPartiallyReceived,
+	utils.PurchaseOrderReceived,
+	utils.PurchaseOrderCancelled,
+}
+
+// Delivery receives devices against a purchase order line, one per serial
+// number. The devices are given to OwnerID, usually the IT stock owner, and
+// placed in LocationID when it is set.
+type Delivery struct {
+	SerialNumbers []string `json:"serial_numbers"`
+	OwnerID       string   `json:"owner_id"`
+	LocationID    *string  `json:"location_id"`
+	ReceivedBy    string   `json:"received_by"`
+	Note          string   `json:"note"`
+}
+
+func validate(order *utils.PurchaseOrder) error {
+	if strings.TrimSpace(order.PONumber) == "" {
+		return fmt.Errorf("%w: po_number is required", ErrInvalidRequest)
+	}
+	if order.VendorID == "" {
+		return fmt.Errorf("%w: vendor_id is required", ErrInvalidRequest)
+	}
+	if len(order.Currency) != 3 {
+		return fmt.Errorf("%w: currency must be a three letter code", ErrInvalidRequest)
+	}
+	if len(order.Lines) == 0 {
+		return fmt.Errorf("%w: at least one line is required", ErrInvalidRequest)
+	}
+	for i, line := range order.Lines {
+		if line.TypeID == "" {
+			return fmt.Errorf("%w: line %d: type_id is required", ErrInvalidRequest, i+1)
+		}
+		if line.Quantity <= 0 {
+			return fmt.Errorf("%w: line %d: quantity must be positive", ErrInvalidRequest, i+1)
+		}
+		if line.UnitCost < 0 {
+			return fmt.Errorf("%w: line %d: unit_cost must not be negative", ErrInvalidRequest, i+1)
+		}
+	}
+	return nil
+}
+
+// validateDelivery trims the serial numbers and checks they are present and
+// distinct.
+func validateDelivery(delivery *Delivery) error {
+	if len(delivery.SerialNumbers) == 0 {
+		return fmt.Errorf("%w: serial_numbers are required", ErrInvalidRequest)
+	}
+	if delivery.OwnerID == "" {
+		return fmt.Errorf("%w: owner_id is required", ErrInvalidRequest)
+	}
+	if delivery.ReceivedBy == "" {
+		return fmt.Errorf("%w: received_by is required", ErrInvalidRequest)
+	}
+	seen := map[string]bool{}
+	for i, serial := range delivery.SerialNumbers {
+		serial = strings.TrimSpace(serial)
+		if serial == "" {
+			return fmt.Errorf("%w: serial number %d is empty", ErrInvalidRequest, i+1)
+		}
+		if seen[serial] {
+			return fmt.Errorf("%w: serial number %s is listed twice", ErrInvalidRequest, serial)
+		}
+		seen[serial] = true
+		delivery.SerialNumbers[i] = serial
+	}
+	return nil
+}
+
+// statusAfterReceipt returns received once every line is complete and
+// partially_received before that.
+func statusAfterReceipt(lines []*utils.PurchaseOrderLine) string {
+	for _, line := range lines {
+		if line.Received < line.Quantity {
+			return utils.PurchaseOrderPartiallyReceived
+		}
+	}
+	return utils.PurchaseOrderReceived
+}
+
+type Service struct {
+	dao        *Dao
+	vendorsDao *vendors.Dao
+	typesDao   *types.Dao
+	ownersDao  *owners.Dao
+	devicesDao *devices.Dao
+	logsDao    *logs.Dao
+	pdb        *utils.DB
+	// currency is used for orders created without one.
+	currency string
+}
+
+func NewService(dao *Dao, pdb *utils.DB, currency string) *Service {
+	return &Service{
+		dao:        dao,
+		vendorsDao: vendors.NewDao(),
+		typesDao:   types.NewDao(),
+		ownersDao:  owners.NewDao(),
+		devicesDao: devices.NewDao(),
+		logsDao:    logs.NewDao(),
+		pdb:        pdb,
+		currency:   currency,
+	}
+}
+
+func (s *Service) GetOrder(ctx context.Context, id string) (*utils.PurchaseOrder, error) {
+	ctx, span := tracing.Start(ctx, "purchaseorders.GetOrder")
+	defer span.End()
+
+	var order *utils.PurchaseOrder
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		order, err = s.dao.GetOrder(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get purchase order: %v", err)
+			return err
+		}
+		order.Lines, err = s.dao.GetLines(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get purchase order lines: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// GetOrders lists purchase orders without their lines, optionally only those
+// of one vendor or in one status.
+func (s *Service) GetOrders(ctx context.Context, vendorID string, status string) ([]*utils.PurchaseOrder, error) {
+	ctx, span := tracing.Start(ctx, "purchaseorders.GetOrders")
+	defer span.End()
+
+	if status != "" && !contains(statuses, status) {
+		return nil, fmt.Errorf("%w: status must be one of %s", ErrInvalidRequest, strings.Join(statuses, ", "))
+	}
+	var orders []*utils.PurchaseOrder
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		orders, err = s.dao.GetOrders(ctx, tx, vendorID, status)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get purchase orders: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// CreateOrder creates a draft purchase order with its lines.
+func (s *Service) CreateOrder(ctx context.Context, order *utils.PurchaseOrder) error {
+	ctx, span := tracing.Start(ctx, "purchaseorders.CreateOrder")
+	defer span.End()
+
+	if order.Currency == "" {
+		order.Currency = s.currency
+	}
+	if err := validate(order); err != nil {
+		return err
+	}
+	if order.CreatedBy == "" {
+		return fmt.Errorf("%w: created_by is required", ErrInvalidRequest)
+	}
+	order.Status = utils.PurchaseOrderDraft
+	order.CreatedAt = time.Now()
+	order.OrderedAt = nil
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.check(ctx, tx, order); err != nil {
+			return err
+		}
+		if err := s.dao.CreateOrder(ctx, tx, order); err != nil {
+			utils.Log(ctx).Errorf("Failed to create purchase order: %v", err)
+			return err
+		}
+		return s.createLines(ctx, tx, order)
+	})
+}
+
+// UpdateOrder replaces the header fields and lines of a draft purchase order.
+func (s *Service) UpdateOrder(ctx context.Context, order *utils.PurchaseOrder) error {
+	ctx, span := tracing.Start(ctx, "purchaseorders.UpdateOrder")
+	defer span.End()
+
+	if order.Currency == "" {
+		order.Currency = s.currency
+	}
+	if err := validate(order); err != nil {
+		return err
+	}
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		current, err := s.dao.LockOrder(ctx, tx, order.ID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get purchase order: %v", err)
+			return err
+		}
+		if current.Status != utils.PurchaseOrderDraft {
+			return fmt.Errorf("%w: only draft orders can be edited, this one is %s", ErrWrongStatus, current.Status)
+		}
+		if err := s.check(ctx, tx, order); err != nil {
+			return err
+		}
+		if err := s.dao.UpdateOrder(ctx, tx, order); err != nil {
+			utils.Log(ctx).Errorf("Failed to update purchase order: %v", err)
+			return err
+		}
+		if err := s.dao.DeleteLines(ctx, tx, order.ID); err != nil {
+			return err
+		}
+		order.Status = current.Status
+		order.CreatedAt = current.CreatedAt
+		order.CreatedBy = current.CreatedBy
+		order.OrderedAt = current.OrderedAt
+		return s.createLines(ctx, tx, order)
+	})
+}
+
+// check makes sure the PO number is free and the vendor and types exist.
+func (s *Service) check(ctx context.Context, tx pgx.Tx, order *utils.PurchaseOrder) error {
+	existing, err := s.dao.GetOrderByNumber(ctx, tx, order.PONumber)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if existing != nil && existing.ID != order.ID {
+		return fmt.Errorf("%w: %s", ErrPONumberTaken, order.PONumber)
+	}
+	if _, err := s.vendorsDao.GetVendor(ctx, tx, order.VendorID); errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: vendor %s does not exist", ErrInvalidRequest, order.VendorID)
+	} else if err != nil {
+		return err
+	}
+	for _, line := range order.Lines {
+		if _, err := s.typesDao.GetType(ctx, tx, line.TypeID); errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: type %s does not exist", ErrInvalidRequest, line.TypeID)
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) createLines(ctx context.Context, tx pgx.Tx, order *utils.PurchaseOrder) error {
+	for _, line := range order.Lines {
+		line.ID = ""
+		line.PurchaseOrderID = order.ID
+		line.Received = 0
+		if err := s.dao.CreateLine(ctx, tx, line); err != nil {
+			utils.Log(ctx).Errorf("Failed to create purchase order line: %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// PlaceOrder marks a draft purchase order as sent to the vendor, after which
+// its lines can be received.
+func (s *Service) PlaceOrder(ctx context.Context, id string) (*utils.PurchaseOrder, error) {
+	ctx, span := tracing.Start(ctx, "purchaseorders.PlaceOrder")
+	defer span.End()
+
+	var order *utils.PurchaseOrder
+	err := s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		order, err = s.dao.LockOrder(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get purchase order: %v", err)
+			return err
+		}
+		if order.Status != utils.PurchaseOrderDraft {
+			return fmt.Errorf("%w: only draft orders can be placed, this one is %s", ErrWrongStatus, order.Status)
+		}
+		now := time.Now()
+		if err := s.dao.SetStatus(ctx, tx, id, utils.PurchaseOrderOrdered, &now); err != nil {
+			return err
+		}
+		order.Status = utils.PurchaseOrderOrdered
+		order.OrderedAt = &now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// CancelOrder cancels a purchase order nothing has been received against.
+func (s *Service) CancelOrder(ctx context.Context, id string) (*utils.PurchaseOrder, error) {
+	ctx, span := tracing.Start(ctx, "purchaseorders.CancelOrder")
+	defer span.End()
+
+	var order *utils.PurchaseOrder
+	err := s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		order, err = s.dao.LockOrder(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get purchase order: %v", err)
+			return err
+		}
+		if order.Status != utils.PurchaseOrderDraft && order.Status != utils.PurchaseOrderOrdered {
+			return fmt.Errorf("%w: orders that are %s cannot be cancelled", ErrWrongStatus, order.Status)
+		}
+		if err := s.dao.SetStatus(ctx, tx, id, utils.PurchaseOrderCancelled, nil); err != nil {
+			return err
+		}
+		order.Status = utils.PurchaseOrderCancelled
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// DeleteOrder deletes a draft purchase order. Placed orders are cancelled
+// instead so their history is kept.
+func (s *Service) DeleteOrder(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "purchaseorders.DeleteOrder")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		order, err := s.dao.LockOrder(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get purchase order: %v", err)
+			return err
+		}
+		if order.Status != utils.PurchaseOrderDraft {
+			return fmt.Errorf("%w: only draft orders can be deleted, this one is %s", ErrWrongStatus, order.Status)
+		}
+		if err := s.dao.DeleteOrder(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Failed to delete purchase order: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+func (s *Service) GetReceipts(ctx context.Context, id string) ([]*utils.PurchaseOrderReceipt, error) {
+	ctx, span := tracing.Start(ctx, "purchaseorders.GetReceipts")
+	defer span.End()
+
+	var receipts []*utils.PurchaseOrderReceipt
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		if _, err := s.dao.GetOrder(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Failed to get purchase order: %v", err)
+			return err
+		}
+		var err error
+		receipts, err = s.dao.GetReceipts(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get purchase order receipts: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
+
+// ReceiveLine creates a device in received status for each serial number in
+// the delivery, carrying the line's cost and the order's vendor and PO
+// number, and records the receipt. The order becomes partially_received or,
+// once every line is complete, received.
+func (s *Service) ReceiveLine(ctx context.Context, orderID string, lineID string, delivery *Delivery) (*utils.PurchaseOrderReceipt, error) {
+	ctx, span := tracing.Start(ctx, "purchaseorders.ReceiveLine")
+	defer span.End()
+
+	if err := validateDelivery(delivery); err != nil {
+		return nil, err
+	}
+
+	var receipt *utils.PurchaseOrderReceipt
+	err := s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		receipt, err = s.receive(ctx, tx, orderID, lineID, delivery)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// receive does the work of ReceiveLine inside tx.
+func (s *Service) receive(ctx context.Context, tx pgx.Tx, orderID string, lineID string, delivery *Delivery) (*utils.PurchaseOrderReceipt, error) {
+	order, err := s.dao.LockOrder(ctx, tx, orderID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Failed to get purchase order: %v", err)
+		return nil, err
+	}
+	if order.Status != utils.PurchaseOrderOrdered && order.Status != utils.PurchaseOrderPartiallyReceived {
+		return nil, fmt.Errorf("%w: orders that are %s cannot be received", ErrWrongStatus, order.Status)
+	}
+	line, err := s.dao.GetLine(ctx, tx, orderID, lineID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Failed to get purchase order line: %v", err)
+		return nil, err
+	}
+	if remaining := line.Quantity - line.Received; len(delivery.SerialNumbers) > remaining {
+		return nil, fmt.Errorf("%w: %d devices delivered but only %d remain on the line", ErrInvalidRequest, len(delivery.SerialNumbers), remaining)
+	}
+	taken, err := s.dao.FindSerials(ctx, tx, delivery.SerialNumbers)
+	if err != nil {
+		return nil, err
+	}
+	if len(taken) > 0 {
+		return nil, fmt.Errorf("%w: serial numbers already in use: %s", ErrInvalidRequest, strings.Join(taken, ", "))
+	}
+	if _, err := s.ownersDao.GetOwner(ctx, tx, delivery.OwnerID); errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: owner %s does not exist", ErrInvalidRequest, delivery.OwnerID)
+	} else if err != nil {
+		return nil, err
+	}
+	vendor, err := s.vendorsDao.GetVendor(ctx, tx, order.VendorID)
+	if err != nil {
+		return nil, err
+	}
+	typ, err := s.typesDao.GetType(ctx, tx, line.TypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	receipt := &utils.PurchaseOrderReceipt{
+		PurchaseOrderID: orderID,
+		LineID:          lineID,
+		Quantity:        len(delivery.SerialNumbers),
+		ReceivedAt:      now,
+		ReceivedBy:      delivery.ReceivedBy,
+		Note:            delivery.Note,
+	}
+	for _, serial := range delivery.SerialNumbers {
+		device := &utils.Device{
+			SerialNumber: serial,
+			Name:         typ.Name,
+			TypeID:       line.TypeID,
+			OwnerID:      delivery.OwnerID,
+			LocationID:   delivery.LocationID,
+			PurchaseDate: now,
+			PurchaseCost: &line.UnitCost,
+			Currency:     &order.Currency,
+			Vendor:       &vendor.Name,
+			PONumber:     &order.PONumber,
+			Status:       utils.DeviceStatusReceived,
+		}
+		if err := s.devicesDao.CreateDevice(ctx, tx, device); err != nil {
+			utils.Log(ctx).Errorf("Failed to create received device: %v", err)
+			return nil, err
+		}
+		entry := &utils.DeviceLog{
+			DeviceID:  device.ID,
+			LogType:   logTypeReceived,
+			Note:      fmt.Sprintf("Received on PO %s from %s", order.PONumber, vendor.Name),
+			CreatedAt: now,
+			CreatedBy: delivery.ReceivedBy,
+		}
+		if err := s.logsDao.CreateLog(ctx, tx, entry); err != nil {
+			utils.Log(ctx).Errorf("Failed to write receipt log: %v", err)
+			return nil, err
+		}
+		receipt.DeviceIDs = append(receipt.DeviceIDs, device.ID)
+	}
+
+	if err := s.dao.AddReceived(ctx, tx, lineID, receipt.Quantity); err != nil {
+		return nil, err
+	}
+	if err := s.dao.CreateReceipt(ctx, tx, receipt); err != nil {
+		return nil, err
+	}
+	lines, err := s.dao.GetLines(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.dao.SetStatus(ctx, tx, orderID, statusAfterReceipt(lines), nil); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package purchaseorders
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+func TestValidate(t *testing.T) {
+	order := func() *utils.PurchaseOrder {
+		return &utils.PurchaseOrder{
+			PONumber: "PO-1001",
+			VendorID: "vendor1",
+			Currency: "USD",
+			Lines: []*utils.PurchaseOrderLine{
+				{TypeID: "type1", Quantity: 5, UnitCost: 899.99},
+			},
+		}
+	}
+	t.Run("Validate", func(t *testing.T) {
+		if err := validate(order()); err != nil {
+			t.Fatalf("Expected order to be valid, got %v", err)
+		}
+	})
+	t.Run("ValidateNoLines", func(t *testing.T) {
+		o := order()
+		o.Lines = nil
+		if err := validate(o); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+	t.Run("ValidateZeroQuantity", func(t *testing.T) {
+		o := order()
+		o.Lines[0].Quantity = 0
+		if err := validate(o); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+	t.Run("ValidateCurrency", func(t *testing.T) {
+		o := order()
+		o.Currency = "US"
+		if err := validate(o); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+}
+
+func TestValidateDelivery(t *testing.T) {
+	t.Run("ValidateDelivery", func(t *testing.T) {
+		delivery := &Delivery{SerialNumbers: []string{" SN1 ", "SN2"}, OwnerID: "owner1", ReceivedBy: "jdoe"}
+		if err := validateDelivery(delivery); err != nil {
+			t.Fatalf("Expected delivery to be valid, got %v", err)
+		}
+		if delivery.SerialNumbers[0] != "SN1" {
+			t.Fatalf("Expected serial number to be trimmed, got %q", delivery.SerialNumbers[0])
+		}
+	})
+	t.Run("ValidateDeliveryDuplicate", func(t *testing.T) {
+		delivery := &Delivery{SerialNumbers: []string{"SN1", "SN1"}, OwnerID: "owner1", ReceivedBy: "jdoe"}
+		if err := validateDelivery(delivery); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+}
+
+func TestStatusAfterReceipt(t *testing.T) {
+	lines := []*utils.PurchaseOrderLine{
+		{Quantity: 5, Received: 5},
+		{Quantity: 3, Received: 1},
+	}
+	if status := statusAfterReceipt(lines); status != utils.PurchaseOrderPartiallyReceived {
+		t.Fatalf("Expected %s, got %s", utils.PurchaseOrderPartiallyReceived, status)
+	}
+	lines[1].Received = 3
+	if status := statusAfterReceipt(lines); status != utils.PurchaseOrderReceived {
+		t.Fatalf("Expected %s, got %s", utils.PurchaseOrderReceived, status)
+	}
+}
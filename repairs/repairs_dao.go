@@ -0,0 +1,191 @@
+package repairs
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Dao struct{}
+
+func NewDao() *Dao {
+	return &Dao{}
+}
+
+const ticketColumns = `id, device_id, title, description, status, assignee, cost, currency, rma_number, prior_status,
+	opened_at, opened_by, resolved_at, resolved_by, resolution`
+
+func scanTicket(row pgx.Row, ticket *utils.RepairTicket) error {
+	return row.Scan(&ticket.ID, &ticket.DeviceID, &ticket.Title, &ticket.Description, &ticket.Status, &ticket.Assignee, &ticket.Cost,
+		&ticket.Currency, &ticket.RMANumber, &ticket.PriorStatus, &ticket.OpenedAt, &ticket.OpenedBy, &ticket.ResolvedAt,
+		&ticket.ResolvedBy, &ticket.Resolution)
+}
+
+func (d *Dao) GetTicket(ctx context.Context, tx pgx.Tx, id string) (*utils.RepairTicket, error) {
+	utils.Log(ctx).Printf("Fetching repair ticket with ID: %s", id)
+	query := `SELECT ` + ticketColumns + ` FROM repair_tickets WHERE id = $1`
+	var ticket utils.RepairTicket
+	if err := scanTicket(tx.QueryRow(ctx, query, id), &ticket); err != nil {
+		utils.Log(ctx).Errorf("Error fetching repair ticket with ID %s: %v", id, err)
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+// LockTicket fetches a repair ticket and locks it until the transaction ends.
+func (d *Dao) LockTicket(ctx context.Context, tx pgx.Tx, id string) (*utils.RepairTicket, error) {
+	utils.Log(ctx).Printf("Locking repair ticket with ID: %s", id)
+	query := `SELECT ` + ticketColumns + ` FROM repair_tickets WHERE id = $1 FOR UPDATE`
+	var ticket utils.RepairTicket
+	if err := scanTicket(tx.QueryRow(ctx, query, id), &ticket); err != nil {
+		utils.Log(ctx).Errorf("Error locking repair ticket with ID %s: %v", id, err)
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+// GetUnresolvedTicket returns the ticket a device is in repair for, or
+// pgx.ErrNoRows.
+func (d *Dao) GetUnresolvedTicket(ctx context.Context, tx pgx.Tx, deviceID string) (*utils.RepairTicket, error) {
+	utils.Log(ctx).Printf("Fetching unresolved repair ticket of device with ID: %s", deviceID)
+	query := `SELECT ` + ticketColumns + ` FROM repair_tickets WHERE device_id = $1 AND status <> $2`
+	var ticket utils.RepairTicket
+	if err := scanTicket(tx.QueryRow(ctx, query, deviceID, utils.RepairResolved), &ticket); err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+// GetTickets returns repair tickets, newest first, optionally only those of
+// one device, in one status or with one assignee.
+func (d *Dao) GetTickets(ctx context.Context, tx pgx.Tx, deviceID string, status string, assignee string) ([]*utils.RepairTicket, error) {
+	utils.Log(ctx).Printf("Fetching repair tickets (device: %q, status: %q, assignee: %q)", deviceID, status, assignee)
+	query := `SELECT ` + ticketColumns + ` FROM repair_tickets
+	WHERE ($1 = '' OR device_id = $1) AND ($2 = '' OR status = $2) AND ($3 = '' OR assignee = $3)
+	ORDER BY opened_at DESC`
+	rows, err := tx.Query(ctx, query, deviceID, status, assignee)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching repair tickets: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tickets []*utils.RepairTicket
+	for rows.Next() {
+		var ticket utils.RepairTicket
+		if err := scanTicket(rows, &ticket); err != nil {
+			utils.Log(ctx).Errorf("Error scanning repair ticket row: %v", err)
+			return nil, err
+		}
+		tickets = append(tickets, &ticket)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over repair ticket rows: %v", err)
+		return nil, err
+	}
+	return tickets, nil
+}
+
+func (d *Dao) CreateTicket(ctx context.Context, tx pgx.Tx, ticket *utils.RepairTicket) error {
+	utils.Log(ctx).Printf("Creating repair ticket: %+v", ticket)
+	if ticket.ID == "" {
+		id, err := gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Error generating ID for new repair ticket: %v", err)
+			return err
+		}
+		ticket.ID = id
+	}
+	query := `INSERT INTO repair_tickets (` + ticketColumns + `)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+	_, err := tx.Exec(ctx, query, ticket.ID, ticket.DeviceID, ticket.Title, ticket.Description, ticket.Status, ticket.Assignee,
+		ticket.Cost, ticket.Currency, ticket.RMANumber, ticket.PriorStatus, ticket.OpenedAt, ticket.OpenedBy, ticket.ResolvedAt,
+		ticket.ResolvedBy, ticket.Resolution)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error creating repair ticket: %v", err)
+		return err
+	}
+	return nil
+}
+
+// UpdateTicket saves every field of a ticket except its device, prior status
+// and opening details.
+func (d *Dao) UpdateTicket(ctx context.Context, tx pgx.Tx, ticket *utils.RepairTicket) error {
+	utils.Log(ctx).Printf("Updating repair ticket: %+v", ticket)
+	query := `UPDATE repair_tickets
+	SET title = $2, description = $3, status = $4, assignee = $5, cost = $6, currency = $7, rma_number = $8,
+		resolved_at = $9, resolved_by = $10, resolution = $11
+	WHERE id = $1`
+	tag, err := tx.Exec(ctx, query, ticket.ID, ticket.Title, ticket.Description, ticket.Status, ticket.Assignee, ticket.Cost,
+		ticket.Currency, ticket.RMANumber, ticket.ResolvedAt, ticket.ResolvedBy, ticket.Resolution)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error updating repair ticket with ID %s: %v", ticket.ID, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// LockDeviceStatus returns the status of a device and locks its row, or
+// pgx.ErrNoRows when it does not exist.
+func (d *Dao) LockDeviceStatus(ctx context.Context, tx pgx.Tx, deviceID string) (string, error) {
+	utils.Log(ctx).Printf("Locking device with ID: %s", deviceID)
+	var status string
+	if err := tx.QueryRow(ctx, `SELECT status FROM devices WHERE id = $1 FOR UPDATE`, deviceID).Scan(&status); err != nil {
+		utils.Log(ctx).Errorf("Error locking device with ID %s: %v", deviceID, err)
+		return "", err
+	}
+	return status, nil
+}
+
+func (d *Dao) CreateComment(ctx context.Context, tx pgx.Tx, comment *utils.RepairComment) error {
+	utils.Log(ctx).Printf("Adding comment to repair ticket %s", comment.TicketID)
+	if comment.ID == "" {
+		id, err := gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Error generating ID for new repair comment: %v", err)
+			return err
+		}
+		comment.ID = id
+	}
+	query := `INSERT INTO repair_comments (id, ticket_id, body, created_at, created_by) VALUES ($1, $2, $3, $4, $5)`
+	_, err := tx.Exec(ctx, query, comment.ID, comment.TicketID, comment.Body, comment.CreatedAt, comment.CreatedBy)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error creating repair comment: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetComments returns the comments on a ticket, oldest first.
+func (d *Dao) GetComments(ctx context.Context, tx pgx.Tx, ticketID string) ([]*utils.RepairComment, error) {
+	utils.Log(ctx).Printf("Fetching comments of repair ticket with ID: %s", ticketID)
+	query := `SELECT id, ticket_id, body, created_at, created_by FROM repair_comments
+	WHERE ticket_id = $1
+	ORDER BY created_at, id`
+	rows, err := tx.Query(ctx, query, ticketID)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching repair comments: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*utils.RepairComment
+	for rows.Next() {
+		var comment utils.RepairComment
+		if err := rows.Scan(&comment.ID, &comment.TicketID, &comment.Body, &comment.CreatedAt, &comment.CreatedBy); err != nil {
+			utils.Log(ctx).Errorf("Error scanning repair comment row: %v", err)
+			return nil, err
+		}
+		comments = append(comments, &comment)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over repair comment rows: %v", err)
+		return nil, err
+	}
+	return comments, nil
+}
@@ -0,0 +1,152 @@
+package repairs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"runtime"
+	"testing"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+var postgresURI string
+
+func init() {
+	viper.SetConfigName("app")
+	viper.AddConfigPath("../config")
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+	postgresURI = viper.GetString("postgres.dev") // Change to "postgres.dev" for development/local db
+	log.SetReportCaller(true)
+	log.SetFormatter(&log.TextFormatter{
+		ForceColors:     true,
+		FullTimestamp:   true,
+		TimestampFormat: "2006/01/02 15:04:05",
+		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
+			filename := path.Base(f.File)
+			return fmt.Sprintf("%s()", f.Function), fmt.Sprintf("\t%s:%d", filename, f.Line)
+		},
+	})
+	log.SetLevel(log.DebugLevel)
+}
+
+func TestTickets(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+	svc := NewService(dao, utils.NewDB(pdb, nil, 0), "USD")
+
+	// Mock Data
+	ownerID, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO owners (id, first_name, last_name, email)
+		VALUES ($1, $2, $3, $4)
+	`, ownerID, "John", "Doe", "john.doe@example.com")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	typeID, _ := gonanoid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO types (id, name, description) VALUES ($1, $2, $3)
+	`, typeID, "Test Type", "This is a test type")
+	if err != nil {
+		t.Fatalf("Error inserting mock data: %v", err)
+	}
+	var devices []string
+	for i := 0; i < 2; i++ {
+		d_id, _ := gonanoid.New()
+		_, err = tx.Exec(ctx, `
+			INSERT INTO devices (id, serial_number, name, purchase_date, status, owner_id, type_id) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, d_id, fmt.Sprintf("SN-R%d", i), fmt.Sprintf("Test Device %d", i), "2023-01-01", "active", ownerID, typeID)
+		if err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+		devices = append(devices, d_id)
+	}
+	laptop, spare := devices[0], devices[1]
+	status := func(t *testing.T, deviceID string) string {
+		var status string
+		if err := tx.QueryRow(ctx, `SELECT status FROM devices WHERE id = $1`, deviceID).Scan(&status); err != nil {
+			t.Fatalf("Error getting device status: %v", err)
+		}
+		return status
+	}
+	open := func(t *testing.T, deviceID string) *utils.RepairTicket {
+		ticket := &utils.RepairTicket{
+			DeviceID: deviceID,
+			Title:    "Cracked screen",
+			Status:   utils.RepairOpen,
+			Currency: "USD",
+			OpenedAt: time.Now(),
+			OpenedBy: "helpdesk",
+		}
+		if err := svc.openTicket(ctx, tx, ticket); err != nil {
+			t.Fatalf("Error opening ticket: %v", err)
+		}
+		return ticket
+	}
+	resolution := &Resolution{Resolution: "Screen replaced", ResolvedBy: "helpdesk"}
+
+	t.Run("OpenTicket", func(t *testing.T) {
+		ticket := open(t, laptop)
+		if ticket.PriorStatus != "active" {
+			t.Errorf("Expected prior status active, got %s", ticket.PriorStatus)
+		}
+		if got := status(t, laptop); got != utils.DeviceStatusInRepair {
+			t.Errorf("Expected %s, got %s", utils.DeviceStatusInRepair, got)
+		}
+		if err := svc.openTicket(ctx, tx, &utils.RepairTicket{DeviceID: laptop, Title: "Again", Currency: "USD", OpenedAt: time.Now(), OpenedBy: "helpdesk"}); !errors.Is(err, ErrTicketOpen) {
+			t.Errorf("Expected ErrTicketOpen, got %v", err)
+		}
+	})
+	t.Run("ResolveTicket", func(t *testing.T) {
+		ticket, err := dao.GetUnresolvedTicket(ctx, tx, laptop)
+		if err != nil {
+			t.Fatalf("Error getting ticket: %v", err)
+		}
+		resolved, err := svc.resolveTicket(ctx, tx, ticket.ID, resolution)
+		if err != nil {
+			t.Fatalf("Error resolving ticket: %v", err)
+		}
+		if resolved.Status != utils.RepairResolved {
+			t.Errorf("Expected %s, got %s", utils.RepairResolved, resolved.Status)
+		}
+		if got := status(t, laptop); got != "active" {
+			t.Errorf("Expected the prior status active to be restored, got %s", got)
+		}
+		if _, err := svc.resolveTicket(ctx, tx, ticket.ID, resolution); !errors.Is(err, ErrWrongStatus) {
+			t.Errorf("Expected ErrWrongStatus, got %v", err)
+		}
+	})
+	t.Run("ResolveTicketMovedDevice", func(t *testing.T) {
+		ticket := open(t, spare)
+		if _, err := tx.Exec(ctx, `UPDATE devices SET status = $2 WHERE id = $1`, spare, utils.DeviceStatusLost); err != nil {
+			t.Fatalf("Error updating mock data: %v", err)
+		}
+		if _, err := svc.resolveTicket(ctx, tx, ticket.ID, resolution); err != nil {
+			t.Fatalf("Error resolving ticket: %v", err)
+		}
+		if got := status(t, spare); got != utils.DeviceStatusLost {
+			t.Errorf("Expected the device to stay %s, got %s", utils.DeviceStatusLost, got)
+		}
+	})
+}
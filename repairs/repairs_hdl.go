@@ -0,0 +1,138 @@
+package repairs
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Handler struct {
+	svc *Service
+	// atz *authz.Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{
+		svc: svc,
+		// atz: atz,
+	}
+}
+
+func (h *Handler) GetTicket(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	ticket, err := h.svc.GetTicket(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ticket)
+}
+
+// GetTickets lists tickets filtered by ?device_id=, ?status= and ?assignee=.
+func (h *Handler) GetTickets(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	tickets, err := h.svc.GetTickets(r.Context(), query.Get("device_id"), query.Get("status"), query.Get("assignee"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(tickets)
+}
+
+func (h *Handler) OpenTicket(w http.ResponseWriter, r *http.Request) {
+	var ticket utils.RepairTicket
+	if err := json.NewDecoder(r.Body).Decode(&ticket); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	if err := h.svc.OpenTicket(r.Context(), &ticket); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(ticket)
+}
+
+func (h *Handler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var update Update
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	ticket, err := h.svc.UpdateTicket(r.Context(), id, &update)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ticket)
+}
+
+func (h *Handler) ResolveTicket(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var resolution Resolution
+	if err := json.NewDecoder(r.Body).Decode(&resolution); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	ticket, err := h.svc.ResolveTicket(r.Context(), id, &resolution)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ticket)
+}
+
+func (h *Handler) GetComments(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	comments, err := h.svc.GetComments(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(comments)
+}
+
+func (h *Handler) AddComment(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var comment utils.RepairComment
+	if err := json.NewDecoder(r.Body).Decode(&comment); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	if err := h.svc.AddComment(r.Context(), id, &comment); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(comment)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrInvalidRequest):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrTicketOpen), errors.Is(err, ErrWrongStatus):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,406 @@
+package repairs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/devices/components"
+	"github.com/rickCrz7/Inventory-API/devices/logs"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+const logTypeRepair = "repair"
+
+var (
+	ErrInvalidRequest = errors.New("invalid repair request")
+	// ErrTicketOpen is returned when opening a ticket for a device that is
+	// already in repair.
+	ErrTicketOpen = errors.New("device already has an open repair ticket")
+	// ErrWrongStatus is returned when changing a ticket that is resolved.
+	ErrWrongStatus = errors.New("repair ticket status does not allow this")
+)
+
+// Update replaces the editable fields of an unresolved ticket.
+type Update struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Assignee    *string  `json:"assignee"`
+	Cost        *float64 `json:"cost"`
+	RMANumber   *string  `json:"rma_number"`
+	UpdatedBy   string   `json:"updated_by"`
+}
+
+// Resolution closes a ticket. Cost, when set, replaces the ticket's cost.
+type Resolution struct {
+	Resolution string   `json:"resolution"`
+	Cost       *float64 `json:"cost"`
+	ResolvedBy string   `json:"resolved_by"`
+}
+
+func validateTicket(ticket *utils.RepairTicket) error {
+	if ticket.DeviceID == "" {
+		return fmt.Errorf("%w: device_id is required", ErrInvalidRequest)
+	}
+	if ticket.Title == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidRequest)
+	}
+	if ticket.OpenedBy == "" {
+		return fmt.Errorf("%w: opened_by is required", ErrInvalidRequest)
+	}
+	if ticket.Cost != nil && *ticket.Cost < 0 {
+		return fmt.Errorf("%w: cost cannot be negative", ErrInvalidRequest)
+	}
+	if ticket.Currency != "" && len(ticket.Currency) != 3 {
+		return fmt.Errorf("%w: currency must be a three letter code", ErrInvalidRequest)
+	}
+	return nil
+}
+
+func validateUpdate(update *Update) error {
+	if update.Title == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidRequest)
+	}
+	if update.UpdatedBy == "" {
+		return fmt.Errorf("%w: updated_by is required", ErrInvalidRequest)
+	}
+	switch update.Status {
+	case utils.RepairOpen, utils.RepairWaitingParts, utils.RepairSentToVendor:
+	case utils.RepairResolved:
+		return fmt.Errorf("%w: tickets are resolved through the resolve endpoint", ErrInvalidRequest)
+	default:
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidRequest, update.Status)
+	}
+	if update.Cost != nil && *update.Cost < 0 {
+		return fmt.Errorf("%w: cost cannot be negative", ErrInvalidRequest)
+	}
+	return nil
+}
+
+// changes describes what an update changes on a ticket, one entry per field
+// that shows up in the device's timeline.
+func changes(ticket *utils.RepairTicket, update *Update) []string {
+	var changed []string
+	if ticket.Status != update.Status {
+		changed = append(changed, fmt.Sprintf("status %s -> %s", ticket.Status, update.Status))
+	}
+	if deref(ticket.Assignee) != deref(update.Assignee) {
+		changed = append(changed, fmt.Sprintf("assignee %q -> %q", deref(ticket.Assignee), deref(update.Assignee)))
+	}
+	if formatCost(ticket.Cost) != formatCost(update.Cost) {
+		changed = append(changed, fmt.Sprintf("cost %s -> %s", formatCost(ticket.Cost), formatCost(update.Cost)))
+	}
+	if deref(ticket.RMANumber) != deref(update.RMANumber) {
+		changed = append(changed, fmt.Sprintf("RMA number %q -> %q", deref(ticket.RMANumber), deref(update.RMANumber)))
+	}
+	return changed
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func formatCost(cost *float64) string {
+	if cost == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%.2f", *cost)
+}
+
+type Service struct {
+	dao      *Dao
+	logsDao  *logs.Dao
+	statuses *components.StatusSetter
+	pdb      *utils.DB
+	// currency is used for tickets opened without one.
+	currency string
+}
+
+func NewService(dao *Dao, pdb *utils.DB, currency string) *Service {
+	return &Service{
+		dao:      dao,
+		logsDao:  logs.NewDao(),
+		statuses: components.NewStatusSetter(),
+		pdb:      pdb,
+		currency: currency,
+	}
+}
+
+// GetTicket returns a ticket with its comments.
+func (s *Service) GetTicket(ctx context.Context, id string) (*utils.RepairTicket, error) {
+	ctx, span := tracing.Start(ctx, "repairs.GetTicket")
+	defer span.End()
+
+	var ticket *utils.RepairTicket
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		ticket, err = s.dao.GetTicket(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get repair ticket: %v", err)
+			return err
+		}
+		ticket.Comments, err = s.dao.GetComments(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get repair comments: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+func (s *Service) GetTickets(ctx context.Context, deviceID string, status string, assignee string) ([]*utils.RepairTicket, error) {
+	ctx, span := tracing.Start(ctx, "repairs.GetTickets")
+	defer span.End()
+
+	var tickets []*utils.RepairTicket
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		tickets, err = s.dao.GetTickets(ctx, tx, deviceID, status, assignee)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get repair tickets: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+// OpenTicket opens a ticket and moves the device to in_repair, remembering
+// its status so resolving the ticket can put it back.
+func (s *Service) OpenTicket(ctx context.Context, ticket *utils.RepairTicket) error {
+	ctx, span := tracing.Start(ctx, "repairs.OpenTicket")
+	defer span.End()
+
+	if err := validateTicket(ticket); err != nil {
+		return err
+	}
+	ticket.ID = ""
+	ticket.Status = utils.RepairOpen
+	ticket.OpenedAt = time.Now()
+	ticket.ResolvedAt, ticket.ResolvedBy, ticket.Resolution = nil, nil, nil
+	if ticket.Currency == "" {
+		ticket.Currency = s.currency
+	}
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		return s.openTicket(ctx, tx, ticket)
+	})
+}
+
+// UpdateTicket edits an unresolved ticket and logs changes to its status,
+// assignee, cost and RMA number on the device.
+func (s *Service) UpdateTicket(ctx context.Context, id string, update *Update) (*utils.RepairTicket, error) {
+	ctx, span := tracing.Start(ctx, "repairs.UpdateTicket")
+	defer span.End()
+
+	if err := validateUpdate(update); err != nil {
+		return nil, err
+	}
+	var ticket *utils.RepairTicket
+	err := s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		ticket, err = s.dao.LockTicket(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if ticket.Status == utils.RepairResolved {
+			return fmt.Errorf("%w: ticket %s is resolved", ErrWrongStatus, id)
+		}
+		changed := changes(ticket, update)
+		ticket.Title = update.Title
+		ticket.Description = update.Description
+		ticket.Status = update.Status
+		ticket.Assignee = update.Assignee
+		ticket.Cost = update.Cost
+		ticket.RMANumber = update.RMANumber
+		if err := s.dao.UpdateTicket(ctx, tx, ticket); err != nil {
+			utils.Log(ctx).Errorf("Failed to update repair ticket: %v", err)
+			return err
+		}
+		if len(changed) == 0 {
+			return nil
+		}
+		note := fmt.Sprintf("Repair ticket %s updated: %s", id, strings.Join(changed, ", "))
+		return s.log(ctx, tx, ticket.DeviceID, note, update.UpdatedBy, time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// ResolveTicket closes a ticket and restores the device's prior status. If
+// the device was moved out of in_repair while the ticket was open, its
+// current status is left alone.
+func (s *Service) ResolveTicket(ctx context.Context, id string, resolution *Resolution) (*utils.RepairTicket, error) {
+	ctx, span := tracing.Start(ctx, "repairs.ResolveTicket")
+	defer span.End()
+
+	if resolution.Resolution == "" {
+		return nil, fmt.Errorf("%w: resolution is required", ErrInvalidRequest)
+	}
+	if resolution.ResolvedBy == "" {
+		return nil, fmt.Errorf("%w: resolved_by is required", ErrInvalidRequest)
+	}
+	if resolution.Cost != nil && *resolution.Cost < 0 {
+		return nil, fmt.Errorf("%w: cost cannot be negative", ErrInvalidRequest)
+	}
+	var ticket *utils.RepairTicket
+	err := s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		ticket, err = s.resolveTicket(ctx, tx, id, resolution)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// openTicket does the work of OpenTicket inside tx.
+func (s *Service) openTicket(ctx context.Context, tx pgx.Tx, ticket *utils.RepairTicket) error {
+	status, err := s.dao.LockDeviceStatus(ctx, tx, ticket.DeviceID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: device %s does not exist", ErrInvalidRequest, ticket.DeviceID)
+	} else if err != nil {
+		return err
+	}
+	if open, err := s.dao.GetUnresolvedTicket(ctx, tx, ticket.DeviceID); err == nil {
+		return fmt.Errorf("%w: ticket %s", ErrTicketOpen, open.ID)
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	ticket.PriorStatus = status
+	if err := s.dao.CreateTicket(ctx, tx, ticket); err != nil {
+		utils.Log(ctx).Errorf("Failed to create repair ticket: %v", err)
+		return err
+	}
+	if err := s.statuses.SetStatus(ctx, tx, ticket.DeviceID, utils.DeviceStatusInRepair); err != nil {
+		return err
+	}
+	note := fmt.Sprintf("Repair ticket %s opened: %s (status %s -> %s)", ticket.ID, ticket.Title, status, utils.DeviceStatusInRepair)
+	return s.log(ctx, tx, ticket.DeviceID, note, ticket.OpenedBy, ticket.OpenedAt)
+}
+
+// resolveTicket does the work of ResolveTicket inside tx.
+func (s *Service) resolveTicket(ctx context.Context, tx pgx.Tx, id string, resolution *Resolution) (*utils.RepairTicket, error) {
+	ticket, err := s.dao.LockTicket(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.Status == utils.RepairResolved {
+		return nil, fmt.Errorf("%w: ticket %s is already resolved", ErrWrongStatus, id)
+	}
+	status, err := s.dao.LockDeviceStatus(ctx, tx, ticket.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ticket.Status = utils.RepairResolved
+	ticket.ResolvedAt = &now
+	ticket.ResolvedBy = &resolution.ResolvedBy
+	ticket.Resolution = &resolution.Resolution
+	if resolution.Cost != nil {
+		ticket.Cost = resolution.Cost
+	}
+	if err := s.dao.UpdateTicket(ctx, tx, ticket); err != nil {
+		utils.Log(ctx).Errorf("Failed to resolve repair ticket: %v", err)
+		return nil, err
+	}
+	note := fmt.Sprintf("Repair ticket %s resolved: %s", id, resolution.Resolution)
+	if ticket.Cost != nil {
+		note += fmt.Sprintf(" (cost %s %s)", formatCost(ticket.Cost), ticket.Currency)
+	}
+	if status == utils.DeviceStatusInRepair {
+		if err := s.statuses.SetStatus(ctx, tx, ticket.DeviceID, ticket.PriorStatus); err != nil {
+			return nil, err
+		}
+		note += fmt.Sprintf(" (status %s -> %s)", status, ticket.PriorStatus)
+	}
+	if err := s.log(ctx, tx, ticket.DeviceID, note, resolution.ResolvedBy, now); err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// AddComment adds a comment to a ticket, resolved or not, and copies it to
+// the device's timeline.
+func (s *Service) AddComment(ctx context.Context, ticketID string, comment *utils.RepairComment) error {
+	ctx, span := tracing.Start(ctx, "repairs.AddComment")
+	defer span.End()
+
+	if comment.Body == "" {
+		return fmt.Errorf("%w: body is required", ErrInvalidRequest)
+	}
+	if comment.CreatedBy == "" {
+		return fmt.Errorf("%w: created_by is required", ErrInvalidRequest)
+	}
+	comment.ID = ""
+	comment.TicketID = ticketID
+	comment.CreatedAt = time.Now()
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		ticket, err := s.dao.GetTicket(ctx, tx, ticketID)
+		if err != nil {
+			return err
+		}
+		if err := s.dao.CreateComment(ctx, tx, comment); err != nil {
+			utils.Log(ctx).Errorf("Failed to create repair comment: %v", err)
+			return err
+		}
+		note := fmt.Sprintf("Comment on repair ticket %s: %s", ticketID, comment.Body)
+		return s.log(ctx, tx, ticket.DeviceID, note, comment.CreatedBy, comment.CreatedAt)
+	})
+}
+
+func (s *Service) GetComments(ctx context.Context, ticketID string) ([]*utils.RepairComment, error) {
+	ctx, span := tracing.Start(ctx, "repairs.GetComments")
+	defer span.End()
+
+	var comments []*utils.RepairComment
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		if _, err := s.dao.GetTicket(ctx, tx, ticketID); err != nil {
+			return err
+		}
+		var err error
+		comments, err = s.dao.GetComments(ctx, tx, ticketID)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get repair comments: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (s *Service) log(ctx context.Context, tx pgx.Tx, deviceID string, note string, by string, at time.Time) error {
+	entry := &utils.DeviceLog{
+		DeviceID:  deviceID,
+		LogType:   logTypeRepair,
+		Note:      note,
+		CreatedAt: at,
+		CreatedBy: by,
+	}
+	if err := s.logsDao.CreateLog(ctx, tx, entry); err != nil {
+		utils.Log(ctx).Errorf("Failed to write repair log: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package repairs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+func TestValidateUpdate(t *testing.T) {
+	update := func() *Update {
+		return &Update{Title: "Cracked screen", Status: utils.RepairWaitingParts, UpdatedBy: "helpdesk"}
+	}
+	t.Run("Validate", func(t *testing.T) {
+		if err := validateUpdate(update()); err != nil {
+			t.Fatalf("Expected update to be valid, got %v", err)
+		}
+	})
+	t.Run("ValidateResolved", func(t *testing.T) {
+		u := update()
+		u.Status = utils.RepairResolved
+		if err := validateUpdate(u); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+	t.Run("ValidateUnknownStatus", func(t *testing.T) {
+		u := update()
+		u.Status = "lost"
+		if err := validateUpdate(u); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+	t.Run("ValidateNegativeCost", func(t *testing.T) {
+		u := update()
+		cost := -1.0
+		u.Cost = &cost
+		if err := validateUpdate(u); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+		}
+	})
+}
+
+func TestChanges(t *testing.T) {
+	cost := 120.0
+	rma := "RMA-1"
+	ticket := &utils.RepairTicket{Status: utils.RepairOpen}
+	t.Run("None", func(t *testing.T) {
+		if changed := changes(ticket, &Update{Status: utils.RepairOpen}); len(changed) != 0 {
+			t.Errorf("Expected no changes, got %v", changed)
+		}
+	})
+	t.Run("Fields", func(t *testing.T) {
+		changed := changes(ticket, &Update{Status: utils.RepairSentToVendor, Cost: &cost, RMANumber: &rma})
+		if len(changed) != 3 {
+			t.Fatalf("Expected 3 changes, got %v", changed)
+		}
+		if changed[1] != "cost none -> 120.00" {
+			t.Errorf("Unexpected cost change %q", changed[1])
+		}
+	})
+}
@@ -0,0 +1,1367 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: inventory.proto
+
+package inventorypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Owner struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirstName     string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	CampusId      *string                `protobuf:"bytes,4,opt,name=campus_id,json=campusId,proto3,oneof" json:"campus_id,omitempty"`
+	Email         string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Owner) Reset() {
+	*x = Owner{}
+	mi := &file_inventory_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Owner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Owner) ProtoMessage() {}
+
+func (x *Owner) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Owner.ProtoReflect.Descriptor instead.
+func (*Owner) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Owner) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Owner) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Owner) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Owner) GetCampusId() string {
+	if x != nil && x.CampusId != nil {
+		return *x.CampusId
+	}
+	return ""
+}
+
+func (x *Owner) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Owner) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type Type struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Type) Reset() {
+	*x = Type{}
+	mi := &file_inventory_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Type) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Type) ProtoMessage() {}
+
+func (x *Type) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Type.ProtoReflect.Descriptor instead.
+func (*Type) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Type) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Type) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Type) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+type TypeProperty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeId        string                 `protobuf:"bytes,2,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	DataType      string                 `protobuf:"bytes,4,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`
+	Required      bool                   `protobuf:"varint,5,opt,name=required,proto3" json:"required,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypeProperty) Reset() {
+	*x = TypeProperty{}
+	mi := &file_inventory_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeProperty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeProperty) ProtoMessage() {}
+
+func (x *TypeProperty) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeProperty.ProtoReflect.Descriptor instead.
+func (*TypeProperty) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TypeProperty) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TypeProperty) GetTypeId() string {
+	if x != nil {
+		return x.TypeId
+	}
+	return ""
+}
+
+func (x *TypeProperty) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TypeProperty) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
+func (x *TypeProperty) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+type Device struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SerialNumber  string                 `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	TypeId        string                 `protobuf:"bytes,4,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,5,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	PurchaseDate  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=purchase_date,json=purchaseDate,proto3" json:"purchase_date,omitempty"`
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_inventory_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Device) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Device) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *Device) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Device) GetTypeId() string {
+	if x != nil {
+		return x.TypeId
+	}
+	return ""
+}
+
+func (x *Device) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Device) GetPurchaseDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PurchaseDate
+	}
+	return nil
+}
+
+func (x *Device) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type DeviceProperty struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeviceId       string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	TypePropertyId string                 `protobuf:"bytes,3,opt,name=type_property_id,json=typePropertyId,proto3" json:"type_property_id,omitempty"`
+	Value          string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeviceProperty) Reset() {
+	*x = DeviceProperty{}
+	mi := &file_inventory_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceProperty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceProperty) ProtoMessage() {}
+
+func (x *DeviceProperty) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceProperty.ProtoReflect.Descriptor instead.
+func (*DeviceProperty) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeviceProperty) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeviceProperty) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *DeviceProperty) GetTypePropertyId() string {
+	if x != nil {
+		return x.TypePropertyId
+	}
+	return ""
+}
+
+func (x *DeviceProperty) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type DeviceLog struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	LogType       string                 `protobuf:"bytes,3,opt,name=log_type,json=logType,proto3" json:"log_type,omitempty"`
+	Note          string                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceLog) Reset() {
+	*x = DeviceLog{}
+	mi := &file_inventory_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceLog) ProtoMessage() {}
+
+func (x *DeviceLog) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceLog.ProtoReflect.Descriptor instead.
+func (*DeviceLog) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeviceLog) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeviceLog) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *DeviceLog) GetLogType() string {
+	if x != nil {
+		return x.LogType
+	}
+	return ""
+}
+
+func (x *DeviceLog) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *DeviceLog) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DeviceLog) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+type GetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	mi := &file_inventory_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_inventory_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetOwnerByCampusIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampusId      string                 `protobuf:"bytes,1,opt,name=campus_id,json=campusId,proto3" json:"campus_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOwnerByCampusIDRequest) Reset() {
+	*x = GetOwnerByCampusIDRequest{}
+	mi := &file_inventory_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOwnerByCampusIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOwnerByCampusIDRequest) ProtoMessage() {}
+
+func (x *GetOwnerByCampusIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOwnerByCampusIDRequest.ProtoReflect.Descriptor instead.
+func (*GetOwnerByCampusIDRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetOwnerByCampusIDRequest) GetCampusId() string {
+	if x != nil {
+		return x.CampusId
+	}
+	return ""
+}
+
+type GetOwnerByEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOwnerByEmailRequest) Reset() {
+	*x = GetOwnerByEmailRequest{}
+	mi := &file_inventory_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOwnerByEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOwnerByEmailRequest) ProtoMessage() {}
+
+func (x *GetOwnerByEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOwnerByEmailRequest.ProtoReflect.Descriptor instead.
+func (*GetOwnerByEmailRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetOwnerByEmailRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ListOwnersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Owners        []*Owner               `protobuf:"bytes,1,rep,name=owners,proto3" json:"owners,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOwnersResponse) Reset() {
+	*x = ListOwnersResponse{}
+	mi := &file_inventory_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOwnersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOwnersResponse) ProtoMessage() {}
+
+func (x *ListOwnersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOwnersResponse.ProtoReflect.Descriptor instead.
+func (*ListOwnersResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListOwnersResponse) GetOwners() []*Owner {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+type ListTypesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []*Type                `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTypesResponse) Reset() {
+	*x = ListTypesResponse{}
+	mi := &file_inventory_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTypesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTypesResponse) ProtoMessage() {}
+
+func (x *ListTypesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTypesResponse.ProtoReflect.Descriptor instead.
+func (*ListTypesResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListTypesResponse) GetTypes() []*Type {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type ListTypePropertiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TypeId        string                 `protobuf:"bytes,1,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTypePropertiesRequest) Reset() {
+	*x = ListTypePropertiesRequest{}
+	mi := &file_inventory_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTypePropertiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTypePropertiesRequest) ProtoMessage() {}
+
+func (x *ListTypePropertiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTypePropertiesRequest.ProtoReflect.Descriptor instead.
+func (*ListTypePropertiesRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListTypePropertiesRequest) GetTypeId() string {
+	if x != nil {
+		return x.TypeId
+	}
+	return ""
+}
+
+type ListTypePropertiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Properties    []*TypeProperty        `protobuf:"bytes,1,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTypePropertiesResponse) Reset() {
+	*x = ListTypePropertiesResponse{}
+	mi := &file_inventory_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTypePropertiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTypePropertiesResponse) ProtoMessage() {}
+
+func (x *ListTypePropertiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTypePropertiesResponse.ProtoReflect.Descriptor instead.
+func (*ListTypePropertiesResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListTypePropertiesResponse) GetProperties() []*TypeProperty {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+type ListDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Devices       []*Device              `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	mi := &file_inventory_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListDevicesResponse) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type ListDevicePropertiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicePropertiesRequest) Reset() {
+	*x = ListDevicePropertiesRequest{}
+	mi := &file_inventory_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicePropertiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicePropertiesRequest) ProtoMessage() {}
+
+func (x *ListDevicePropertiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicePropertiesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicePropertiesRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListDevicePropertiesRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type ListDevicePropertiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Properties    []*DeviceProperty      `protobuf:"bytes,1,rep,name=properties,proto3" json:"properties,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicePropertiesResponse) Reset() {
+	*x = ListDevicePropertiesResponse{}
+	mi := &file_inventory_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicePropertiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicePropertiesResponse) ProtoMessage() {}
+
+func (x *ListDevicePropertiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicePropertiesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicePropertiesResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListDevicePropertiesResponse) GetProperties() []*DeviceProperty {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+type ListDeviceLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeviceLogsRequest) Reset() {
+	*x = ListDeviceLogsRequest{}
+	mi := &file_inventory_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeviceLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeviceLogsRequest) ProtoMessage() {}
+
+func (x *ListDeviceLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeviceLogsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeviceLogsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListDeviceLogsRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type ListDeviceLogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logs          []*DeviceLog           `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeviceLogsResponse) Reset() {
+	*x = ListDeviceLogsResponse{}
+	mi := &file_inventory_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeviceLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeviceLogsResponse) ProtoMessage() {}
+
+func (x *ListDeviceLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeviceLogsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeviceLogsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListDeviceLogsResponse) GetLogs() []*DeviceLog {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+type TailDeviceLogsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// Send the logs already recorded for the device before tailing.
+	IncludeExisting bool `protobuf:"varint,2,opt,name=include_existing,json=includeExisting,proto3" json:"include_existing,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TailDeviceLogsRequest) Reset() {
+	*x = TailDeviceLogsRequest{}
+	mi := &file_inventory_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TailDeviceLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TailDeviceLogsRequest) ProtoMessage() {}
+
+func (x *TailDeviceLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TailDeviceLogsRequest.ProtoReflect.Descriptor instead.
+func (*TailDeviceLogsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TailDeviceLogsRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *TailDeviceLogsRequest) GetIncludeExisting() bool {
+	if x != nil {
+		return x.IncludeExisting
+	}
+	return false
+}
+
+var File_inventory_proto protoreflect.FileDescriptor
+
+const file_inventory_proto_rawDesc = "" +
+	"\n" +
+	"\x0finventory.proto\x12\finventory.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb1\x01\n" +
+	"\x05Owner\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12 \n" +
+	"\tcampus_id\x18\x04 \x01(\tH\x00R\bcampusId\x88\x01\x01\x12\x14\n" +
+	"\x05email\x18\x05 \x01(\tR\x05email\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06statusB\f\n" +
+	"\n" +
+	"_campus_id\"a\n" +
+	"\x04Type\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01B\x0e\n" +
+	"\f_description\"\x84\x01\n" +
+	"\fTypeProperty\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atype_id\x18\x02 \x01(\tR\x06typeId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1b\n" +
+	"\tdata_type\x18\x04 \x01(\tR\bdataType\x12\x1a\n" +
+	"\brequired\x18\x05 \x01(\bR\brequired\"\xde\x01\n" +
+	"\x06Device\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rserial_number\x18\x02 \x01(\tR\fserialNumber\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x17\n" +
+	"\atype_id\x18\x04 \x01(\tR\x06typeId\x12\x19\n" +
+	"\bowner_id\x18\x05 \x01(\tR\aownerId\x12?\n" +
+	"\rpurchase_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fpurchaseDate\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\"}\n" +
+	"\x0eDeviceProperty\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12(\n" +
+	"\x10type_property_id\x18\x03 \x01(\tR\x0etypePropertyId\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\"\xc1\x01\n" +
+	"\tDeviceLog\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12\x19\n" +
+	"\blog_type\x18\x03 \x01(\tR\alogType\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\tR\tcreatedBy\"\x1c\n" +
+	"\n" +
+	"GetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1f\n" +
+	"\rDeleteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"8\n" +
+	"\x19GetOwnerByCampusIDRequest\x12\x1b\n" +
+	"\tcampus_id\x18\x01 \x01(\tR\bcampusId\".\n" +
+	"\x16GetOwnerByEmailRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"A\n" +
+	"\x12ListOwnersResponse\x12+\n" +
+	"\x06owners\x18\x01 \x03(\v2\x13.inventory.v1.OwnerR\x06owners\"=\n" +
+	"\x11ListTypesResponse\x12(\n" +
+	"\x05types\x18\x01 \x03(\v2\x12.inventory.v1.TypeR\x05types\"4\n" +
+	"\x19ListTypePropertiesRequest\x12\x17\n" +
+	"\atype_id\x18\x01 \x01(\tR\x06typeId\"X\n" +
+	"\x1aListTypePropertiesResponse\x12:\n" +
+	"\n" +
+	"properties\x18\x01 \x03(\v2\x1a.inventory.v1.TypePropertyR\n" +
+	"properties\"E\n" +
+	"\x13ListDevicesResponse\x12.\n" +
+	"\adevices\x18\x01 \x03(\v2\x14.inventory.v1.DeviceR\adevices\":\n" +
+	"\x1bListDevicePropertiesRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"\\\n" +
+	"\x1cListDevicePropertiesResponse\x12<\n" +
+	"\n" +
+	"properties\x18\x01 \x03(\v2\x1c.inventory.v1.DevicePropertyR\n" +
+	"properties\"4\n" +
+	"\x15ListDeviceLogsRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"E\n" +
+	"\x16ListDeviceLogsResponse\x12+\n" +
+	"\x04logs\x18\x01 \x03(\v2\x17.inventory.v1.DeviceLogR\x04logs\"_\n" +
+	"\x15TailDeviceLogsRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12)\n" +
+	"\x10include_existing\x18\x02 \x01(\bR\x0fincludeExisting2\xc8\x10\n" +
+	"\tInventory\x129\n" +
+	"\bGetOwner\x12\x18.inventory.v1.GetRequest\x1a\x13.inventory.v1.Owner\x12R\n" +
+	"\x12GetOwnerByCampusID\x12'.inventory.v1.GetOwnerByCampusIDRequest\x1a\x13.inventory.v1.Owner\x12L\n" +
+	"\x0fGetOwnerByEmail\x12$.inventory.v1.GetOwnerByEmailRequest\x1a\x13.inventory.v1.Owner\x12F\n" +
+	"\n" +
+	"ListOwners\x12\x16.google.protobuf.Empty\x1a .inventory.v1.ListOwnersResponse\x127\n" +
+	"\vCreateOwner\x12\x13.inventory.v1.Owner\x1a\x13.inventory.v1.Owner\x127\n" +
+	"\vUpdateOwner\x12\x13.inventory.v1.Owner\x1a\x13.inventory.v1.Owner\x12B\n" +
+	"\vDeleteOwner\x12\x1b.inventory.v1.DeleteRequest\x1a\x16.google.protobuf.Empty\x127\n" +
+	"\aGetType\x12\x18.inventory.v1.GetRequest\x1a\x12.inventory.v1.Type\x12D\n" +
+	"\tListTypes\x12\x16.google.protobuf.Empty\x1a\x1f.inventory.v1.ListTypesResponse\x124\n" +
+	"\n" +
+	"CreateType\x12\x12.inventory.v1.Type\x1a\x12.inventory.v1.Type\x124\n" +
+	"\n" +
+	"UpdateType\x12\x12.inventory.v1.Type\x1a\x12.inventory.v1.Type\x12A\n" +
+	"\n" +
+	"DeleteType\x12\x1b.inventory.v1.DeleteRequest\x1a\x16.google.protobuf.Empty\x12g\n" +
+	"\x12ListTypeProperties\x12'.inventory.v1.ListTypePropertiesRequest\x1a(.inventory.v1.ListTypePropertiesResponse\x12L\n" +
+	"\x12CreateTypeProperty\x12\x1a.inventory.v1.TypeProperty\x1a\x1a.inventory.v1.TypeProperty\x12L\n" +
+	"\x12UpdateTypeProperty\x12\x1a.inventory.v1.TypeProperty\x1a\x1a.inventory.v1.TypeProperty\x12I\n" +
+	"\x12DeleteTypeProperty\x12\x1b.inventory.v1.DeleteRequest\x1a\x16.google.protobuf.Empty\x12;\n" +
+	"\tGetDevice\x12\x18.inventory.v1.GetRequest\x1a\x14.inventory.v1.Device\x12H\n" +
+	"\vListDevices\x12\x16.google.protobuf.Empty\x1a!.inventory.v1.ListDevicesResponse\x12:\n" +
+	"\fCreateDevice\x12\x14.inventory.v1.Device\x1a\x14.inventory.v1.Device\x12:\n" +
+	"\fUpdateDevice\x12\x14.inventory.v1.Device\x1a\x14.inventory.v1.Device\x12C\n" +
+	"\fDeleteDevice\x12\x1b.inventory.v1.DeleteRequest\x1a\x16.google.protobuf.Empty\x12m\n" +
+	"\x14ListDeviceProperties\x12).inventory.v1.ListDevicePropertiesRequest\x1a*.inventory.v1.ListDevicePropertiesResponse\x12R\n" +
+	"\x14CreateDeviceProperty\x12\x1c.inventory.v1.DeviceProperty\x1a\x1c.inventory.v1.DeviceProperty\x12R\n" +
+	"\x14UpdateDeviceProperty\x12\x1c.inventory.v1.DeviceProperty\x1a\x1c.inventory.v1.DeviceProperty\x12K\n" +
+	"\x14DeleteDeviceProperty\x12\x1b.inventory.v1.DeleteRequest\x1a\x16.google.protobuf.Empty\x12[\n" +
+	"\x0eListDeviceLogs\x12#.inventory.v1.ListDeviceLogsRequest\x1a$.inventory.v1.ListDeviceLogsResponse\x12C\n" +
+	"\x0fCreateDeviceLog\x12\x17.inventory.v1.DeviceLog\x1a\x17.inventory.v1.DeviceLog\x12F\n" +
+	"\x0fDeleteDeviceLog\x12\x1b.inventory.v1.DeleteRequest\x1a\x16.google.protobuf.Empty\x12P\n" +
+	"\x0eTailDeviceLogs\x12#.inventory.v1.TailDeviceLogsRequest\x1a\x17.inventory.v1.DeviceLog0\x01B3Z1github.com/rickCrz7/Inventory-API/rpc/inventorypbb\x06proto3"
+
+var (
+	file_inventory_proto_rawDescOnce sync.Once
+	file_inventory_proto_rawDescData []byte
+)
+
+func file_inventory_proto_rawDescGZIP() []byte {
+	file_inventory_proto_rawDescOnce.Do(func() {
+		file_inventory_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_inventory_proto_rawDesc), len(file_inventory_proto_rawDesc)))
+	})
+	return file_inventory_proto_rawDescData
+}
+
+var file_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_inventory_proto_goTypes = []any{
+	(*Owner)(nil),                        // 0: inventory.v1.Owner
+	(*Type)(nil),                         // 1: inventory.v1.Type
+	(*TypeProperty)(nil),                 // 2: inventory.v1.TypeProperty
+	(*Device)(nil),                       // 3: inventory.v1.Device
+	(*DeviceProperty)(nil),               // 4: inventory.v1.DeviceProperty
+	(*DeviceLog)(nil),                    // 5: inventory.v1.DeviceLog
+	(*GetRequest)(nil),                   // 6: inventory.v1.GetRequest
+	(*DeleteRequest)(nil),                // 7: inventory.v1.DeleteRequest
+	(*GetOwnerByCampusIDRequest)(nil),    // 8: inventory.v1.GetOwnerByCampusIDRequest
+	(*GetOwnerByEmailRequest)(nil),       // 9: inventory.v1.GetOwnerByEmailRequest
+	(*ListOwnersResponse)(nil),           // 10: inventory.v1.ListOwnersResponse
+	(*ListTypesResponse)(nil),            // 11: inventory.v1.ListTypesResponse
+	(*ListTypePropertiesRequest)(nil),    // 12: inventory.v1.ListTypePropertiesRequest
+	(*ListTypePropertiesResponse)(nil),   // 13: inventory.v1.ListTypePropertiesResponse
+	(*ListDevicesResponse)(nil),          // 14: inventory.v1.ListDevicesResponse
+	(*ListDevicePropertiesRequest)(nil),  // 15: inventory.v1.ListDevicePropertiesRequest
+	(*ListDevicePropertiesResponse)(nil), // 16: inventory.v1.ListDevicePropertiesResponse
+	(*ListDeviceLogsRequest)(nil),        // 17: inventory.v1.ListDeviceLogsRequest
+	(*ListDeviceLogsResponse)(nil),       // 18: inventory.v1.ListDeviceLogsResponse
+	(*TailDeviceLogsRequest)(nil),        // 19: inventory.v1.TailDeviceLogsRequest
+	(*timestamppb.Timestamp)(nil),        // 20: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                // 21: google.protobuf.Empty
+}
+var file_inventory_proto_depIdxs = []int32{
+	20, // 0: inventory.v1.Device.purchase_date:type_name -> google.protobuf.Timestamp
+	20, // 1: inventory.v1.DeviceLog.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: inventory.v1.ListOwnersResponse.owners:type_name -> inventory.v1.Owner
+	1,  // 3: inventory.v1.ListTypesResponse.types:type_name -> inventory.v1.Type
+	2,  // 4: inventory.v1.ListTypePropertiesResponse.properties:type_name -> inventory.v1.TypeProperty
+	3,  // 5: inventory.v1.ListDevicesResponse.devices:type_name -> inventory.v1.Device
+	4,  // 6: inventory.v1.ListDevicePropertiesResponse.properties:type_name -> inventory.v1.DeviceProperty
+	5,  // 7: inventory.v1.ListDeviceLogsResponse.logs:type_name -> inventory.v1.DeviceLog
+	6,  // 8: inventory.v1.Inventory.GetOwner:input_type -> inventory.v1.GetRequest
+	8,  // 9: inventory.v1.Inventory.GetOwnerByCampusID:input_type -> inventory.v1.GetOwnerByCampusIDRequest
+	9,  // 10: inventory.v1.Inventory.GetOwnerByEmail:input_type -> inventory.v1.GetOwnerByEmailRequest
+	21, // 11: inventory.v1.Inventory.ListOwners:input_type -> google.protobuf.Empty
+	0,  // 12: inventory.v1.Inventory.CreateOwner:input_type -> inventory.v1.Owner
+	0,  // 13: inventory.v1.Inventory.UpdateOwner:input_type -> inventory.v1.Owner
+	7,  // 14: inventory.v1.Inventory.DeleteOwner:input_type -> inventory.v1.DeleteRequest
+	6,  // 15: inventory.v1.Inventory.GetType:input_type -> inventory.v1.GetRequest
+	21, // 16: inventory.v1.Inventory.ListTypes:input_type -> google.protobuf.Empty
+	1,  // 17: inventory.v1.Inventory.CreateType:input_type -> inventory.v1.Type
+	1,  // 18: inventory.v1.Inventory.UpdateType:input_type -> inventory.v1.Type
+	7,  // 19: inventory.v1.Inventory.DeleteType:input_type -> inventory.v1.DeleteRequest
+	12, // 20: inventory.v1.Inventory.ListTypeProperties:input_type -> inventory.v1.ListTypePropertiesRequest
+	2,  // 21: inventory.v1.Inventory.CreateTypeProperty:input_type -> inventory.v1.TypeProperty
+	2,  // 22: inventory.v1.Inventory.UpdateTypeProperty:input_type -> inventory.v1.TypeProperty
+	7,  // 23: inventory.v1.Inventory.DeleteTypeProperty:input_type -> inventory.v1.DeleteRequest
+	6,  // 24: inventory.v1.Inventory.GetDevice:input_type -> inventory.v1.GetRequest
+	21, // 25: inventory.v1.Inventory.ListDevices:input_type -> google.protobuf.Empty
+	3,  // 26: inventory.v1.Inventory.CreateDevice:input_type -> inventory.v1.Device
+	3,  // 27: inventory.v1.Inventory.UpdateDevice:input_type -> inventory.v1.Device
+	7,  // 28: inventory.v1.Inventory.DeleteDevice:input_type -> inventory.v1.DeleteRequest
+	15, // 29: inventory.v1.Inventory.ListDeviceProperties:input_type -> inventory.v1.ListDevicePropertiesRequest
+	4,  // 30: inventory.v1.Inventory.CreateDeviceProperty:input_type -> inventory.v1.DeviceProperty
+	4,  // 31: inventory.v1.Inventory.UpdateDeviceProperty:input_type -> inventory.v1.DeviceProperty
+	7,  // 32: inventory.v1.Inventory.DeleteDeviceProperty:input_type -> inventory.v1.DeleteRequest
+	17, // 33: inventory.v1.Inventory.ListDeviceLogs:input_type -> inventory.v1.ListDeviceLogsRequest
+	5,  // 34: inventory.v1.Inventory.CreateDeviceLog:input_type -> inventory.v1.DeviceLog
+	7,  // 35: inventory.v1.Inventory.DeleteDeviceLog:input_type -> inventory.v1.DeleteRequest
+	19, // 36: inventory.v1.Inventory.TailDeviceLogs:input_type -> inventory.v1.TailDeviceLogsRequest
+	0,  // 37: inventory.v1.Inventory.GetOwner:output_type -> inventory.v1.Owner
+	0,  // 38: inventory.v1.Inventory.GetOwnerByCampusID:output_type -> inventory.v1.Owner
+	0,  // 39: inventory.v1.Inventory.GetOwnerByEmail:output_type -> inventory.v1.Owner
+	10, // 40: inventory.v1.Inventory.ListOwners:output_type -> inventory.v1.ListOwnersResponse
+	0,  // 41: inventory.v1.Inventory.CreateOwner:output_type -> inventory.v1.Owner
+	0,  // 42: inventory.v1.Inventory.UpdateOwner:output_type -> inventory.v1.Owner
+	21, // 43: inventory.v1.Inventory.DeleteOwner:output_type -> google.protobuf.Empty
+	1,  // 44: inventory.v1.Inventory.GetType:output_type -> inventory.v1.Type
+	11, // 45: inventory.v1.Inventory.ListTypes:output_type -> inventory.v1.ListTypesResponse
+	1,  // 46: inventory.v1.Inventory.CreateType:output_type -> inventory.v1.Type
+	1,  // 47: inventory.v1.Inventory.UpdateType:output_type -> inventory.v1.Type
+	21, // 48: inventory.v1.Inventory.DeleteType:output_type -> google.protobuf.Empty
+	13, // 49: inventory.v1.Inventory.ListTypeProperties:output_type -> inventory.v1.ListTypePropertiesResponse
+	2,  // 50: inventory.v1.Inventory.CreateTypeProperty:output_type -> inventory.v1.TypeProperty
+	2,  // 51: inventory.v1.Inventory.UpdateTypeProperty:output_type -> inventory.v1.TypeProperty
+	21, // 52: inventory.v1.Inventory.DeleteTypeProperty:output_type -> google.protobuf.Empty
+	3,  // 53: inventory.v1.Inventory.GetDevice:output_type -> inventory.v1.Device
+	14, // 54: inventory.v1.Inventory.ListDevices:output_type -> inventory.v1.ListDevicesResponse
+	3,  // 55: inventory.v1.Inventory.CreateDevice:output_type -> inventory.v1.Device
+	3,  // 56: inventory.v1.Inventory.UpdateDevice:output_type -> inventory.v1.Device
+	21, // 57: inventory.v1.Inventory.DeleteDevice:output_type -> google.protobuf.Empty
+	16, // 58: inventory.v1.Inventory.ListDeviceProperties:output_type -> inventory.v1.ListDevicePropertiesResponse
+	4,  // 59: inventory.v1.Inventory.CreateDeviceProperty:output_type -> inventory.v1.DeviceProperty
+	4,  // 60: inventory.v1.Inventory.UpdateDeviceProperty:output_type -> inventory.v1.DeviceProperty
+	21, // 61: inventory.v1.Inventory.DeleteDeviceProperty:output_type -> google.protobuf.Empty
+	18, // 62: inventory.v1.Inventory.ListDeviceLogs:output_type -> inventory.v1.ListDeviceLogsResponse
+	5,  // 63: inventory.v1.Inventory.CreateDeviceLog:output_type -> inventory.v1.DeviceLog
+	21, // 64: inventory.v1.Inventory.DeleteDeviceLog:output_type -> google.protobuf.Empty
+	5,  // 65: inventory.v1.Inventory.TailDeviceLogs:output_type -> inventory.v1.DeviceLog
+	37, // [37:66] is the sub-list for method output_type
+	8,  // [8:37] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_inventory_proto_init() }
+func file_inventory_proto_init() {
+	if File_inventory_proto != nil {
+		return
+	}
+	file_inventory_proto_msgTypes[0].OneofWrappers = []any{}
+	file_inventory_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_proto_rawDesc), len(file_inventory_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_inventory_proto_goTypes,
+		DependencyIndexes: file_inventory_proto_depIdxs,
+		MessageInfos:      file_inventory_proto_msgTypes,
+	}.Build()
+	File_inventory_proto = out.File
+	file_inventory_proto_goTypes = nil
+	file_inventory_proto_depIdxs = nil
+}
@@ -0,0 +1,1198 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: inventory.proto
+
+package inventorypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Inventory_GetOwner_FullMethodName             = "/inventory.v1.Inventory/GetOwner"
+	Inventory_GetOwnerByCampusID_FullMethodName   = "/inventory.v1.Inventory/GetOwnerByCampusID"
+	Inventory_GetOwnerByEmail_FullMethodName      = "/inventory.v1.Inventory/GetOwnerByEmail"
+	Inventory_ListOwners_FullMethodName           = "/inventory.v1.Inventory/ListOwners"
+	Inventory_CreateOwner_FullMethodName          = "/inventory.v1.Inventory/CreateOwner"
+	Inventory_UpdateOwner_FullMethodName          = "/inventory.v1.Inventory/UpdateOwner"
+	Inventory_DeleteOwner_FullMethodName          = "/inventory.v1.Inventory/DeleteOwner"
+	Inventory_GetType_FullMethodName              = "/inventory.v1.Inventory/GetType"
+	Inventory_ListTypes_FullMethodName            = "/inventory.v1.Inventory/ListTypes"
+	Inventory_CreateType_FullMethodName           = "/inventory.v1.Inventory/CreateType"
+	Inventory_UpdateType_FullMethodName           = "/inventory.v1.Inventory/UpdateType"
+	Inventory_DeleteType_FullMethodName           = "/inventory.v1.Inventory/DeleteType"
+	Inventory_ListTypeProperties_FullMethodName   = "/inventory.v1.Inventory/ListTypeProperties"
+	Inventory_CreateTypeProperty_FullMethodName   = "/inventory.v1.Inventory/CreateTypeProperty"
+	Inventory_UpdateTypeProperty_FullMethodName   = "/inventory.v1.Inventory/UpdateTypeProperty"
+	Inventory_DeleteTypeProperty_FullMethodName   = "/inventory.v1.Inventory/DeleteTypeProperty"
+	Inventory_GetDevice_FullMethodName            = "/inventory.v1.Inventory/GetDevice"
+	Inventory_ListDevices_FullMethodName          = "/inventory.v1.Inventory/ListDevices"
+	Inventory_CreateDevice_FullMethodName         = "/inventory.v1.Inventory/CreateDevice"
+	Inventory_UpdateDevice_FullMethodName         = "/inventory.v1.Inventory/UpdateDevice"
+	Inventory_DeleteDevice_FullMethodName         = "/inventory.v1.Inventory/DeleteDevice"
+	Inventory_ListDeviceProperties_FullMethodName = "/inventory.v1.Inventory/ListDeviceProperties"
+	Inventory_CreateDeviceProperty_FullMethodName = "/inventory.v1.Inventory/CreateDeviceProperty"
+	Inventory_UpdateDeviceProperty_FullMethodName = "/inventory.v1.Inventory/UpdateDeviceProperty"
+	Inventory_DeleteDeviceProperty_FullMethodName = "/inventory.v1.Inventory/DeleteDeviceProperty"
+	Inventory_ListDeviceLogs_FullMethodName       = "/inventory.v1.Inventory/ListDeviceLogs"
+	Inventory_CreateDeviceLog_FullMethodName      = "/inventory.v1.Inventory/CreateDeviceLog"
+	Inventory_DeleteDeviceLog_FullMethodName      = "/inventory.v1.Inventory/DeleteDeviceLog"
+	Inventory_TailDeviceLogs_FullMethodName       = "/inventory.v1.Inventory/TailDeviceLogs"
+)
+
+// InventoryClient is the client API for Inventory service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Inventory exposes the same operations as the REST API under /api/v1.
+type InventoryClient interface {
+	GetOwner(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Owner, error)
+	GetOwnerByCampusID(ctx context.Context, in *GetOwnerByCampusIDRequest, opts ...grpc.CallOption) (*Owner, error)
+	GetOwnerByEmail(ctx context.Context, in *GetOwnerByEmailRequest, opts ...grpc.CallOption) (*Owner, error)
+	ListOwners(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListOwnersResponse, error)
+	CreateOwner(ctx context.Context, in *Owner, opts ...grpc.CallOption) (*Owner, error)
+	UpdateOwner(ctx context.Context, in *Owner, opts ...grpc.CallOption) (*Owner, error)
+	DeleteOwner(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetType(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Type, error)
+	ListTypes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTypesResponse, error)
+	CreateType(ctx context.Context, in *Type, opts ...grpc.CallOption) (*Type, error)
+	UpdateType(ctx context.Context, in *Type, opts ...grpc.CallOption) (*Type, error)
+	DeleteType(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListTypeProperties(ctx context.Context, in *ListTypePropertiesRequest, opts ...grpc.CallOption) (*ListTypePropertiesResponse, error)
+	CreateTypeProperty(ctx context.Context, in *TypeProperty, opts ...grpc.CallOption) (*TypeProperty, error)
+	UpdateTypeProperty(ctx context.Context, in *TypeProperty, opts ...grpc.CallOption) (*TypeProperty, error)
+	DeleteTypeProperty(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetDevice(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Device, error)
+	ListDevices(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	CreateDevice(ctx context.Context, in *Device, opts ...grpc.CallOption) (*Device, error)
+	UpdateDevice(ctx context.Context, in *Device, opts ...grpc.CallOption) (*Device, error)
+	DeleteDevice(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListDeviceProperties(ctx context.Context, in *ListDevicePropertiesRequest, opts ...grpc.CallOption) (*ListDevicePropertiesResponse, error)
+	CreateDeviceProperty(ctx context.Context, in *DeviceProperty, opts ...grpc.CallOption) (*DeviceProperty, error)
+	UpdateDeviceProperty(ctx context.Context, in *DeviceProperty, opts ...grpc.CallOption) (*DeviceProperty, error)
+	DeleteDeviceProperty(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListDeviceLogs(ctx context.Context, in *ListDeviceLogsRequest, opts ...grpc.CallOption) (*ListDeviceLogsResponse, error)
+	CreateDeviceLog(ctx context.Context, in *DeviceLog, opts ...grpc.CallOption) (*DeviceLog, error)
+	DeleteDeviceLog(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// TailDeviceLogs streams the logs of a device as they are written until
+	// the client cancels the call.
+	TailDeviceLogs(ctx context.Context, in *TailDeviceLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DeviceLog], error)
+}
+
+type inventoryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInventoryClient(cc grpc.ClientConnInterface) InventoryClient {
+	return &inventoryClient{cc}
+}
+
+func (c *inventoryClient) GetOwner(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Owner, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Owner)
+	err := c.cc.Invoke(ctx, Inventory_GetOwner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) GetOwnerByCampusID(ctx context.Context, in *GetOwnerByCampusIDRequest, opts ...grpc.CallOption) (*Owner, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Owner)
+	err := c.cc.Invoke(ctx, Inventory_GetOwnerByCampusID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) GetOwnerByEmail(ctx context.Context, in *GetOwnerByEmailRequest, opts ...grpc.CallOption) (*Owner, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Owner)
+	err := c.cc.Invoke(ctx, Inventory_GetOwnerByEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) ListOwners(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListOwnersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOwnersResponse)
+	err := c.cc.Invoke(ctx, Inventory_ListOwners_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) CreateOwner(ctx context.Context, in *Owner, opts ...grpc.CallOption) (*Owner, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Owner)
+	err := c.cc.Invoke(ctx, Inventory_CreateOwner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) UpdateOwner(ctx context.Context, in *Owner, opts ...grpc.CallOption) (*Owner, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Owner)
+	err := c.cc.Invoke(ctx, Inventory_UpdateOwner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) DeleteOwner(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Inventory_DeleteOwner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) GetType(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Type, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Type)
+	err := c.cc.Invoke(ctx, Inventory_GetType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) ListTypes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTypesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTypesResponse)
+	err := c.cc.Invoke(ctx, Inventory_ListTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) CreateType(ctx context.Context, in *Type, opts ...grpc.CallOption) (*Type, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Type)
+	err := c.cc.Invoke(ctx, Inventory_CreateType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) UpdateType(ctx context.Context, in *Type, opts ...grpc.CallOption) (*Type, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Type)
+	err := c.cc.Invoke(ctx, Inventory_UpdateType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) DeleteType(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Inventory_DeleteType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) ListTypeProperties(ctx context.Context, in *ListTypePropertiesRequest, opts ...grpc.CallOption) (*ListTypePropertiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTypePropertiesResponse)
+	err := c.cc.Invoke(ctx, Inventory_ListTypeProperties_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) CreateTypeProperty(ctx context.Context, in *TypeProperty, opts ...grpc.CallOption) (*TypeProperty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TypeProperty)
+	err := c.cc.Invoke(ctx, Inventory_CreateTypeProperty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) UpdateTypeProperty(ctx context.Context, in *TypeProperty, opts ...grpc.CallOption) (*TypeProperty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TypeProperty)
+	err := c.cc.Invoke(ctx, Inventory_UpdateTypeProperty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) DeleteTypeProperty(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Inventory_DeleteTypeProperty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) GetDevice(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Device, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Device)
+	err := c.cc.Invoke(ctx, Inventory_GetDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) ListDevices(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, Inventory_ListDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) CreateDevice(ctx context.Context, in *Device, opts ...grpc.CallOption) (*Device, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Device)
+	err := c.cc.Invoke(ctx, Inventory_CreateDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) UpdateDevice(ctx context.Context, in *Device, opts ...grpc.CallOption) (*Device, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Device)
+	err := c.cc.Invoke(ctx, Inventory_UpdateDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) DeleteDevice(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Inventory_DeleteDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) ListDeviceProperties(ctx context.Context, in *ListDevicePropertiesRequest, opts ...grpc.CallOption) (*ListDevicePropertiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDevicePropertiesResponse)
+	err := c.cc.Invoke(ctx, Inventory_ListDeviceProperties_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) CreateDeviceProperty(ctx context.Context, in *DeviceProperty, opts ...grpc.CallOption) (*DeviceProperty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeviceProperty)
+	err := c.cc.Invoke(ctx, Inventory_CreateDeviceProperty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) UpdateDeviceProperty(ctx context.Context, in *DeviceProperty, opts ...grpc.CallOption) (*DeviceProperty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeviceProperty)
+	err := c.cc.Invoke(ctx, Inventory_UpdateDeviceProperty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) DeleteDeviceProperty(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Inventory_DeleteDeviceProperty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) ListDeviceLogs(ctx context.Context, in *ListDeviceLogsRequest, opts ...grpc.CallOption) (*ListDeviceLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeviceLogsResponse)
+	err := c.cc.Invoke(ctx, Inventory_ListDeviceLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) CreateDeviceLog(ctx context.Context, in *DeviceLog, opts ...grpc.CallOption) (*DeviceLog, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeviceLog)
+	err := c.cc.Invoke(ctx, Inventory_CreateDeviceLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) DeleteDeviceLog(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Inventory_DeleteDeviceLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) TailDeviceLogs(ctx context.Context, in *TailDeviceLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DeviceLog], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Inventory_ServiceDesc.Streams[0], Inventory_TailDeviceLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TailDeviceLogsRequest, DeviceLog]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Inventory_TailDeviceLogsClient = grpc.ServerStreamingClient[DeviceLog]
+
+// InventoryServer is the server API for Inventory service.
+// All implementations must embed UnimplementedInventoryServer
+// for forward compatibility.
+//
+// Inventory exposes the same operations as the REST API under /api/v1.
+type InventoryServer interface {
+	GetOwner(context.Context, *GetRequest) (*Owner, error)
+	GetOwnerByCampusID(context.Context, *GetOwnerByCampusIDRequest) (*Owner, error)
+	GetOwnerByEmail(context.Context, *GetOwnerByEmailRequest) (*Owner, error)
+	ListOwners(context.Context, *emptypb.Empty) (*ListOwnersResponse, error)
+	CreateOwner(context.Context, *Owner) (*Owner, error)
+	UpdateOwner(context.Context, *Owner) (*Owner, error)
+	DeleteOwner(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	GetType(context.Context, *GetRequest) (*Type, error)
+	ListTypes(context.Context, *emptypb.Empty) (*ListTypesResponse, error)
+	CreateType(context.Context, *Type) (*Type, error)
+	UpdateType(context.Context, *Type) (*Type, error)
+	DeleteType(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	ListTypeProperties(context.Context, *ListTypePropertiesRequest) (*ListTypePropertiesResponse, error)
+	CreateTypeProperty(context.Context, *TypeProperty) (*TypeProperty, error)
+	UpdateTypeProperty(context.Context, *TypeProperty) (*TypeProperty, error)
+	DeleteTypeProperty(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	GetDevice(context.Context, *GetRequest) (*Device, error)
+	ListDevices(context.Context, *emptypb.Empty) (*ListDevicesResponse, error)
+	CreateDevice(context.Context, *Device) (*Device, error)
+	UpdateDevice(context.Context, *Device) (*Device, error)
+	DeleteDevice(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	ListDeviceProperties(context.Context, *ListDevicePropertiesRequest) (*ListDevicePropertiesResponse, error)
+	CreateDeviceProperty(context.Context, *DeviceProperty) (*DeviceProperty, error)
+	UpdateDeviceProperty(context.Context, *DeviceProperty) (*DeviceProperty, error)
+	DeleteDeviceProperty(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	ListDeviceLogs(context.Context, *ListDeviceLogsRequest) (*ListDeviceLogsResponse, error)
+	CreateDeviceLog(context.Context, *DeviceLog) (*DeviceLog, error)
+	DeleteDeviceLog(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	// TailDeviceLogs streams the logs of a device as they are written until
+	// the client cancels the call.
+	TailDeviceLogs(*TailDeviceLogsRequest, grpc.ServerStreamingServer[DeviceLog]) error
+	mustEmbedUnimplementedInventoryServer()
+}
+
+// UnimplementedInventoryServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInventoryServer struct{}
+
+func (UnimplementedInventoryServer) GetOwner(context.Context, *GetRequest) (*Owner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOwner not implemented")
+}
+func (UnimplementedInventoryServer) GetOwnerByCampusID(context.Context, *GetOwnerByCampusIDRequest) (*Owner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOwnerByCampusID not implemented")
+}
+func (UnimplementedInventoryServer) GetOwnerByEmail(context.Context, *GetOwnerByEmailRequest) (*Owner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOwnerByEmail not implemented")
+}
+func (UnimplementedInventoryServer) ListOwners(context.Context, *emptypb.Empty) (*ListOwnersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOwners not implemented")
+}
+func (UnimplementedInventoryServer) CreateOwner(context.Context, *Owner) (*Owner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOwner not implemented")
+}
+func (UnimplementedInventoryServer) UpdateOwner(context.Context, *Owner) (*Owner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOwner not implemented")
+}
+func (UnimplementedInventoryServer) DeleteOwner(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOwner not implemented")
+}
+func (UnimplementedInventoryServer) GetType(context.Context, *GetRequest) (*Type, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetType not implemented")
+}
+func (UnimplementedInventoryServer) ListTypes(context.Context, *emptypb.Empty) (*ListTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTypes not implemented")
+}
+func (UnimplementedInventoryServer) CreateType(context.Context, *Type) (*Type, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateType not implemented")
+}
+func (UnimplementedInventoryServer) UpdateType(context.Context, *Type) (*Type, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateType not implemented")
+}
+func (UnimplementedInventoryServer) DeleteType(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteType not implemented")
+}
+func (UnimplementedInventoryServer) ListTypeProperties(context.Context, *ListTypePropertiesRequest) (*ListTypePropertiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTypeProperties not implemented")
+}
+func (UnimplementedInventoryServer) CreateTypeProperty(context.Context, *TypeProperty) (*TypeProperty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTypeProperty not implemented")
+}
+func (UnimplementedInventoryServer) UpdateTypeProperty(context.Context, *TypeProperty) (*TypeProperty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTypeProperty not implemented")
+}
+func (UnimplementedInventoryServer) DeleteTypeProperty(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTypeProperty not implemented")
+}
+func (UnimplementedInventoryServer) GetDevice(context.Context, *GetRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDevice not implemented")
+}
+func (UnimplementedInventoryServer) ListDevices(context.Context, *emptypb.Empty) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedInventoryServer) CreateDevice(context.Context, *Device) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDevice not implemented")
+}
+func (UnimplementedInventoryServer) UpdateDevice(context.Context, *Device) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDevice not implemented")
+}
+func (UnimplementedInventoryServer) DeleteDevice(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDevice not implemented")
+}
+func (UnimplementedInventoryServer) ListDeviceProperties(context.Context, *ListDevicePropertiesRequest) (*ListDevicePropertiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceProperties not implemented")
+}
+func (UnimplementedInventoryServer) CreateDeviceProperty(context.Context, *DeviceProperty) (*DeviceProperty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDeviceProperty not implemented")
+}
+func (UnimplementedInventoryServer) UpdateDeviceProperty(context.Context, *DeviceProperty) (*DeviceProperty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDeviceProperty not implemented")
+}
+func (UnimplementedInventoryServer) DeleteDeviceProperty(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDeviceProperty not implemented")
+}
+func (UnimplementedInventoryServer) ListDeviceLogs(context.Context, *ListDeviceLogsRequest) (*ListDeviceLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceLogs not implemented")
+}
+func (UnimplementedInventoryServer) CreateDeviceLog(context.Context, *DeviceLog) (*DeviceLog, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDeviceLog not implemented")
+}
+func (UnimplementedInventoryServer) DeleteDeviceLog(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDeviceLog not implemented")
+}
+func (UnimplementedInventoryServer) TailDeviceLogs(*TailDeviceLogsRequest, grpc.ServerStreamingServer[DeviceLog]) error {
+	return status.Errorf(codes.Unimplemented, "method TailDeviceLogs not implemented")
+}
+func (UnimplementedInventoryServer) mustEmbedUnimplementedInventoryServer() {}
+func (UnimplementedInventoryServer) testEmbeddedByValue()                   {}
+
+// UnsafeInventoryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InventoryServer will
+// result in compilation errors.
+type UnsafeInventoryServer interface {
+	mustEmbedUnimplementedInventoryServer()
+}
+
+func RegisterInventoryServer(s grpc.ServiceRegistrar, srv InventoryServer) {
+	// If the following call pancis, it indicates UnimplementedInventoryServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Inventory_ServiceDesc, srv)
+}
+
+func _Inventory_GetOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).GetOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_GetOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).GetOwner(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_GetOwnerByCampusID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOwnerByCampusIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).GetOwnerByCampusID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_GetOwnerByCampusID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).GetOwnerByCampusID(ctx, req.(*GetOwnerByCampusIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_GetOwnerByEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOwnerByEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).GetOwnerByEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_GetOwnerByEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).GetOwnerByEmail(ctx, req.(*GetOwnerByEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_ListOwners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).ListOwners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_ListOwners_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).ListOwners(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_CreateOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Owner)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).CreateOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_CreateOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).CreateOwner(ctx, req.(*Owner))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_UpdateOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Owner)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).UpdateOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_UpdateOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).UpdateOwner(ctx, req.(*Owner))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_DeleteOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).DeleteOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_DeleteOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).DeleteOwner(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_GetType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).GetType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_GetType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).GetType(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_ListTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).ListTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_ListTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).ListTypes(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_CreateType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Type)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).CreateType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_CreateType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).CreateType(ctx, req.(*Type))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_UpdateType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Type)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).UpdateType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_UpdateType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).UpdateType(ctx, req.(*Type))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_DeleteType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).DeleteType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_DeleteType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).DeleteType(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_ListTypeProperties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTypePropertiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).ListTypeProperties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_ListTypeProperties_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).ListTypeProperties(ctx, req.(*ListTypePropertiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_CreateTypeProperty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TypeProperty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).CreateTypeProperty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_CreateTypeProperty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).CreateTypeProperty(ctx, req.(*TypeProperty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_UpdateTypeProperty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TypeProperty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).UpdateTypeProperty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_UpdateTypeProperty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).UpdateTypeProperty(ctx, req.(*TypeProperty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_DeleteTypeProperty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).DeleteTypeProperty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_DeleteTypeProperty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).DeleteTypeProperty(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_GetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).GetDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_GetDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).GetDevice(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_ListDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).ListDevices(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_CreateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Device)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).CreateDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_CreateDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).CreateDevice(ctx, req.(*Device))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_UpdateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Device)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).UpdateDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_UpdateDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).UpdateDevice(ctx, req.(*Device))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_DeleteDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).DeleteDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_DeleteDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).DeleteDevice(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_ListDeviceProperties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicePropertiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).ListDeviceProperties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_ListDeviceProperties_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).ListDeviceProperties(ctx, req.(*ListDevicePropertiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_CreateDeviceProperty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceProperty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).CreateDeviceProperty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_CreateDeviceProperty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).CreateDeviceProperty(ctx, req.(*DeviceProperty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_UpdateDeviceProperty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceProperty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).UpdateDeviceProperty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_UpdateDeviceProperty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).UpdateDeviceProperty(ctx, req.(*DeviceProperty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_DeleteDeviceProperty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).DeleteDeviceProperty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_DeleteDeviceProperty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).DeleteDeviceProperty(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_ListDeviceLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).ListDeviceLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_ListDeviceLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).ListDeviceLogs(ctx, req.(*ListDeviceLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_CreateDeviceLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceLog)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).CreateDeviceLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_CreateDeviceLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).CreateDeviceLog(ctx, req.(*DeviceLog))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_DeleteDeviceLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).DeleteDeviceLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_DeleteDeviceLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).DeleteDeviceLog(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_TailDeviceLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailDeviceLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InventoryServer).TailDeviceLogs(m, &grpc.GenericServerStream[TailDeviceLogsRequest, DeviceLog]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Inventory_TailDeviceLogsServer = grpc.ServerStreamingServer[DeviceLog]
+
+// Inventory_ServiceDesc is the grpc.ServiceDesc for Inventory service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Inventory_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inventory.v1.Inventory",
+	HandlerType: (*InventoryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetOwner",
+			Handler:    _Inventory_GetOwner_Handler,
+		},
+		{
+			MethodName: "GetOwnerByCampusID",
+			Handler:    _Inventory_GetOwnerByCampusID_Handler,
+		},
+		{
+			MethodName: "GetOwnerByEmail",
+			Handler:    _Inventory_GetOwnerByEmail_Handler,
+		},
+		{
+			MethodName: "ListOwners",
+			Handler:    _Inventory_ListOwners_Handler,
+		},
+		{
+			MethodName: "CreateOwner",
+			Handler:    _Inventory_CreateOwner_Handler,
+		},
+		{
+			MethodName: "UpdateOwner",
+			Handler:    _Inventory_UpdateOwner_Handler,
+		},
+		{
+			MethodName: "DeleteOwner",
+			Handler:    _Inventory_DeleteOwner_Handler,
+		},
+		{
+			MethodName: "GetType",
+			Handler:    _Inventory_GetType_Handler,
+		},
+		{
+			MethodName: "ListTypes",
+			Handler:    _Inventory_ListTypes_Handler,
+		},
+		{
+			MethodName: "CreateType",
+			Handler:    _Inventory_CreateType_Handler,
+		},
+		{
+			MethodName: "UpdateType",
+			Handler:    _Inventory_UpdateType_Handler,
+		},
+		{
+			MethodName: "DeleteType",
+			Handler:    _Inventory_DeleteType_Handler,
+		},
+		{
+			MethodName: "ListTypeProperties",
+			Handler:    _Inventory_ListTypeProperties_Handler,
+		},
+		{
+			MethodName: "CreateTypeProperty",
+			Handler:    _Inventory_CreateTypeProperty_Handler,
+		},
+		{
+			MethodName: "UpdateTypeProperty",
+			Handler:    _Inventory_UpdateTypeProperty_Handler,
+		},
+		{
+			MethodName: "DeleteTypeProperty",
+			Handler:    _Inventory_DeleteTypeProperty_Handler,
+		},
+		{
+			MethodName: "GetDevice",
+			Handler:    _Inventory_GetDevice_Handler,
+		},
+		{
+			MethodName: "ListDevices",
+			Handler:    _Inventory_ListDevices_Handler,
+		},
+		{
+			MethodName: "CreateDevice",
+			Handler:    _Inventory_CreateDevice_Handler,
+		},
+		{
+			MethodName: "UpdateDevice",
+			Handler:    _Inventory_UpdateDevice_Handler,
+		},
+		{
+			MethodName: "DeleteDevice",
+			Handler:    _Inventory_DeleteDevice_Handler,
+		},
+		{
+			MethodName: "ListDeviceProperties",
+			Handler:    _Inventory_ListDeviceProperties_Handler,
+		},
+		{
+			MethodName: "CreateDeviceProperty",
+			Handler:    _Inventory_CreateDeviceProperty_Handler,
+		},
+		{
+			MethodName: "UpdateDeviceProperty",
+			Handler:    _Inventory_UpdateDeviceProperty_Handler,
+		},
+		{
+			MethodName: "DeleteDeviceProperty",
+			Handler:    _Inventory_DeleteDeviceProperty_Handler,
+		},
+		{
+			MethodName: "ListDeviceLogs",
+			Handler:    _Inventory_ListDeviceLogs_Handler,
+		},
+		{
+			MethodName: "CreateDeviceLog",
+			Handler:    _Inventory_CreateDeviceLog_Handler,
+		},
+		{
+			MethodName: "DeleteDeviceLog",
+			Handler:    _Inventory_DeleteDeviceLog_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TailDeviceLogs",
+			Handler:       _Inventory_TailDeviceLogs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "inventory.proto",
+}
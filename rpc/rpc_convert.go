@@ -0,0 +1,173 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/rickCrz7/Inventory-API/rpc/inventorypb"
+	"github.com/rickCrz7/Inventory-API/utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ownerToProto(owner *utils.Owner) *inventorypb.Owner {
+	return &inventorypb.Owner{
+		Id:        owner.ID,
+		FirstName: owner.FirstName,
+		LastName:  owner.LastName,
+		CampusId:  owner.CampusID,
+		Email:     owner.Email,
+		Status:    owner.Status,
+	}
+}
+
+func ownerFromProto(owner *inventorypb.Owner) *utils.Owner {
+	return &utils.Owner{
+		ID:        owner.GetId(),
+		FirstName: owner.GetFirstName(),
+		LastName:  owner.GetLastName(),
+		CampusID:  owner.CampusId,
+		Email:     owner.GetEmail(),
+		Status:    owner.GetStatus(),
+	}
+}
+
+// keepOwnerFields copies the fields the protobuf Owner does not carry from
+// the stored owner, so an update over gRPC leaves them unchanged.
+func keepOwnerFields(owner, current *utils.Owner) {
+	owner.DepartmentID = current.DepartmentID
+}
+
+func typeToProto(t *utils.Type) *inventorypb.Type {
+	return &inventorypb.Type{
+		Id:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+	}
+}
+
+func typeFromProto(t *inventorypb.Type) *utils.Type {
+	return &utils.Type{
+		ID:          t.GetId(),
+		Name:        t.GetName(),
+		Description: t.Description,
+	}
+}
+
+// keepTypeFields copies the depreciation settings and asset tag prefix,
+// which the protobuf Type does not carry, from the stored type.
+func keepTypeFields(t, current *utils.Type) {
+	t.DepreciationMethod = current.DepreciationMethod
+	t.UsefulLifeMonths = current.UsefulLifeMonths
+	t.SalvagePercent = current.SalvagePercent
+	t.DecliningFactor = current.DecliningFactor
+	t.AssetTagPrefix = current.AssetTagPrefix
+}
+
+func typePropertyToProto(prop *utils.TypeProperty) *inventorypb.TypeProperty {
+	return &inventorypb.TypeProperty{
+		Id:       prop.ID,
+		TypeId:   prop.TypeID,
+		Name:     prop.Name,
+		DataType: prop.DataType,
+		Required: prop.Required,
+	}
+}
+
+func typePropertyFromProto(prop *inventorypb.TypeProperty) *utils.TypeProperty {
+	return &utils.TypeProperty{
+		ID:       prop.GetId(),
+		TypeID:   prop.GetTypeId(),
+		Name:     prop.GetName(),
+		DataType: prop.GetDataType(),
+		Required: prop.GetRequired(),
+	}
+}
+
+// keepDeviceFields copies the fields the protobuf Device does not carry from
+// the stored device, so an update over gRPC leaves them unchanged.
+func keepDeviceFields(device, current *utils.Device) {
+	device.CostCenter = current.CostCenter
+	device.PurchaseCost = current.PurchaseCost
+	device.Currency = current.Currency
+	device.Vendor = current.Vendor
+	device.PONumber = current.PONumber
+	device.InvoiceRef = current.InvoiceRef
+	device.AssetTag = current.AssetTag
+}
+
+func deviceToProto(device *utils.Device) *inventorypb.Device {
+	return &inventorypb.Device{
+		Id:           device.ID,
+		SerialNumber: device.SerialNumber,
+		Name:         device.Name,
+		TypeId:       device.TypeID,
+		OwnerId:      device.OwnerID,
+		PurchaseDate: timeToProto(device.PurchaseDate),
+		Status:       device.Status,
+	}
+}
+
+func deviceFromProto(device *inventorypb.Device) *utils.Device {
+	return &utils.Device{
+		ID:           device.GetId(),
+		SerialNumber: device.GetSerialNumber(),
+		Name:         device.GetName(),
+		TypeID:       device.GetTypeId(),
+		OwnerID:      device.GetOwnerId(),
+		PurchaseDate: timeFromProto(device.GetPurchaseDate()),
+		Status:       device.GetStatus(),
+	}
+}
+
+func devicePropertyToProto(prop *utils.DeviceProperty) *inventorypb.DeviceProperty {
+	return &inventorypb.DeviceProperty{
+		Id:             prop.ID,
+		DeviceId:       prop.DeviceID,
+		TypePropertyId: prop.TypePropertyID,
+		Value:          prop.Value,
+	}
+}
+
+func devicePropertyFromProto(prop *inventorypb.DeviceProperty) *utils.DeviceProperty {
+	return &utils.DeviceProperty{
+		ID:             prop.GetId(),
+		DeviceID:       prop.GetDeviceId(),
+		TypePropertyID: prop.GetTypePropertyId(),
+		Value:          prop.GetValue(),
+	}
+}
+
+func deviceLogToProto(logEntry *utils.DeviceLog) *inventorypb.DeviceLog {
+	return &inventorypb.DeviceLog{
+		Id:        logEntry.ID,
+		DeviceId:  logEntry.DeviceID,
+		LogType:   logEntry.LogType,
+		Note:      logEntry.Note,
+		CreatedAt: timeToProto(logEntry.CreatedAt),
+		CreatedBy: logEntry.CreatedBy,
+	}
+}
+
+func deviceLogFromProto(logEntry *inventorypb.DeviceLog) *utils.DeviceLog {
+	return &utils.DeviceLog{
+		ID:        logEntry.GetId(),
+		DeviceID:  logEntry.GetDeviceId(),
+		LogType:   logEntry.GetLogType(),
+		Note:      logEntry.GetNote(),
+		CreatedAt: timeFromProto(logEntry.GetCreatedAt()),
+		CreatedBy: logEntry.GetCreatedBy(),
+	}
+}
+
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func timeFromProto(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
@@ -0,0 +1,47 @@
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+func TestConvert(t *testing.T) {
+	t.Run("Owner", func(t *testing.T) {
+		campusID := "C123"
+		owner := &utils.Owner{ID: "o1", FirstName: "John", LastName: "Doe", CampusID: &campusID, Email: "john.doe@example.com"}
+		got := ownerFromProto(ownerToProto(owner))
+		if *got != *owner {
+			t.Fatalf("Expected %+v, got %+v", owner, got)
+		}
+		owner.CampusID = nil
+		if got := ownerFromProto(ownerToProto(owner)); got.CampusID != nil {
+			t.Fatalf("Expected nil campus ID, got %q", *got.CampusID)
+		}
+	})
+	t.Run("Device", func(t *testing.T) {
+		device := &utils.Device{ID: "d1", SerialNumber: "SN123456", Name: "Test Device", TypeID: "t1", OwnerID: "o1", PurchaseDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), Status: "active"}
+		got := deviceFromProto(deviceToProto(device))
+		if *got != *device {
+			t.Fatalf("Expected %+v, got %+v", device, got)
+		}
+	})
+	t.Run("DeviceWithoutPurchaseDate", func(t *testing.T) {
+		device := &utils.Device{ID: "d1", Name: "Test Device"}
+		pb := deviceToProto(device)
+		if pb.PurchaseDate != nil {
+			t.Fatalf("Expected nil purchase date, got %v", pb.PurchaseDate)
+		}
+		if got := deviceFromProto(pb); !got.PurchaseDate.IsZero() {
+			t.Fatalf("Expected zero purchase date, got %v", got.PurchaseDate)
+		}
+	})
+	t.Run("DeviceLog", func(t *testing.T) {
+		logEntry := &utils.DeviceLog{ID: "l1", DeviceID: "d1", LogType: "INFO", Note: "note", CreatedAt: time.Date(2023, 1, 1, 12, 30, 0, 0, time.UTC), CreatedBy: "tester"}
+		got := deviceLogFromProto(deviceLogToProto(logEntry))
+		if *got != *logEntry {
+			t.Fatalf("Expected %+v, got %+v", logEntry, got)
+		}
+	})
+}
@@ -0,0 +1,382 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/devices"
+	"github.com/rickCrz7/Inventory-API/devices/logs"
+	dev_properties "github.com/rickCrz7/Inventory-API/devices/properties"
+	"github.com/rickCrz7/Inventory-API/owners"
+	"github.com/rickCrz7/Inventory-API/rpc/inventorypb"
+	"github.com/rickCrz7/Inventory-API/types"
+	"github.com/rickCrz7/Inventory-API/types/properties"
+	"github.com/rickCrz7/Inventory-API/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const defaultTailInterval = 2 * time.Second
+
+// Handler implements the Inventory gRPC service on top of the same services
+// used by the REST handlers.
+type Handler struct {
+	inventorypb.UnimplementedInventoryServer
+	owners           *owners.Service
+	types            *types.Service
+	typeProperties   *properties.Service
+	devices          *devices.Service
+	deviceProperties *dev_properties.Service
+	deviceLogs       *logs.Service
+	tailInterval     time.Duration
+}
+
+func NewHandler(
+	ownersSvc *owners.Service,
+	typesSvc *types.Service,
+	typePropertiesSvc *properties.Service,
+	devicesSvc *devices.Service,
+	devicePropertiesSvc *dev_properties.Service,
+	deviceLogsSvc *logs.Service,
+	tailInterval time.Duration,
+) *Handler {
+	if tailInterval <= 0 {
+		tailInterval = defaultTailInterval
+	}
+	return &Handler{
+		owners:           ownersSvc,
+		types:            typesSvc,
+		typeProperties:   typePropertiesSvc,
+		devices:          devicesSvc,
+		deviceProperties: devicePropertiesSvc,
+		deviceLogs:       deviceLogsSvc,
+		tailInterval:     tailInterval,
+	}
+}
+
+func (h *Handler) GetOwner(ctx context.Context, req *inventorypb.GetRequest) (*inventorypb.Owner, error) {
+	owner, err := h.owners.GetOwner(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return ownerToProto(owner), nil
+}
+
+func (h *Handler) GetOwnerByCampusID(ctx context.Context, req *inventorypb.GetOwnerByCampusIDRequest) (*inventorypb.Owner, error) {
+	owner, err := h.owners.GetOwnerByCampusID(ctx, req.GetCampusId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return ownerToProto(owner), nil
+}
+
+func (h *Handler) GetOwnerByEmail(ctx context.Context, req *inventorypb.GetOwnerByEmailRequest) (*inventorypb.Owner, error) {
+	owner, err := h.owners.GetOwnerByEmail(ctx, req.GetEmail())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return ownerToProto(owner), nil
+}
+
+func (h *Handler) ListOwners(ctx context.Context, _ *emptypb.Empty) (*inventorypb.ListOwnersResponse, error) {
+	owners, err := h.owners.GetOwners(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &inventorypb.ListOwnersResponse{}
+	for _, owner := range owners {
+		resp.Owners = append(resp.Owners, ownerToProto(owner))
+	}
+	return resp, nil
+}
+
+func (h *Handler) CreateOwner(ctx context.Context, req *inventorypb.Owner) (*inventorypb.Owner, error) {
+	owner := ownerFromProto(req)
+	if err := h.owners.CreateOwner(ctx, owner); err != nil {
+		return nil, toStatus(err)
+	}
+	return ownerToProto(owner), nil
+}
+
+func (h *Handler) UpdateOwner(ctx context.Context, req *inventorypb.Owner) (*inventorypb.Owner, error) {
+	owner := ownerFromProto(req)
+	current, err := h.owners.GetOwner(ctx, owner.ID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	keepOwnerFields(owner, current)
+	if err := h.owners.UpdateOwner(ctx, owner); err != nil {
+		return nil, toStatus(err)
+	}
+	return ownerToProto(owner), nil
+}
+
+func (h *Handler) DeleteOwner(ctx context.Context, req *inventorypb.DeleteRequest) (*emptypb.Empty, error) {
+	if err := h.owners.DeleteOwner(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) GetType(ctx context.Context, req *inventorypb.GetRequest) (*inventorypb.Type, error) {
+	t, err := h.types.GetType(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return typeToProto(t), nil
+}
+
+func (h *Handler) ListTypes(ctx context.Context, _ *emptypb.Empty) (*inventorypb.ListTypesResponse, error) {
+	types, err := h.types.GetTypes(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &inventorypb.ListTypesResponse{}
+	for _, t := range types {
+		resp.Types = append(resp.Types, typeToProto(t))
+	}
+	return resp, nil
+}
+
+func (h *Handler) CreateType(ctx context.Context, req *inventorypb.Type) (*inventorypb.Type, error) {
+	t := typeFromProto(req)
+	if err := h.types.CreateType(ctx, t); err != nil {
+		return nil, toStatus(err)
+	}
+	return typeToProto(t), nil
+}
+
+func (h *Handler) UpdateType(ctx context.Context, req *inventorypb.Type) (*inventorypb.Type, error) {
+	t := typeFromProto(req)
+	current, err := h.types.GetType(ctx, t.ID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	keepTypeFields(t, current)
+	if err := h.types.UpdateType(ctx, t); err != nil {
+		return nil, toStatus(err)
+	}
+	return typeToProto(t), nil
+}
+
+func (h *Handler) DeleteType(ctx context.Context, req *inventorypb.DeleteRequest) (*emptypb.Empty, error) {
+	if err := h.types.DeleteType(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) ListTypeProperties(ctx context.Context, req *inventorypb.ListTypePropertiesRequest) (*inventorypb.ListTypePropertiesResponse, error) {
+	props, err := h.typeProperties.GetProperties(ctx, req.GetTypeId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &inventorypb.ListTypePropertiesResponse{}
+	for _, prop := range props {
+		resp.Properties = append(resp.Properties, typePropertyToProto(prop))
+	}
+	return resp, nil
+}
+
+func (h *Handler) CreateTypeProperty(ctx context.Context, req *inventorypb.TypeProperty) (*inventorypb.TypeProperty, error) {
+	prop := typePropertyFromProto(req)
+	if err := h.typeProperties.CreateProperty(ctx, prop); err != nil {
+		return nil, toStatus(err)
+	}
+	return typePropertyToProto(prop), nil
+}
+
+func (h *Handler) UpdateTypeProperty(ctx context.Context, req *inventorypb.TypeProperty) (*inventorypb.TypeProperty, error) {
+	prop := typePropertyFromProto(req)
+	if err := h.typeProperties.UpdateProperty(ctx, prop); err != nil {
+		return nil, toStatus(err)
+	}
+	return typePropertyToProto(prop), nil
+}
+
+func (h *Handler) DeleteTypeProperty(ctx context.Context, req *inventorypb.DeleteRequest) (*emptypb.Empty, error) {
+	if err := h.typeProperties.DeleteProperty(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) GetDevice(ctx context.Context, req *inventorypb.GetRequest) (*inventorypb.Device, error) {
+	device, err := h.devices.GetDevice(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return deviceToProto(device), nil
+}
+
+func (h *Handler) ListDevices(ctx context.Context, _ *emptypb.Empty) (*inventorypb.ListDevicesResponse, error) {
+	devices, err := h.devices.GetDevices(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &inventorypb.ListDevicesResponse{}
+	for _, device := range devices {
+		resp.Devices = append(resp.Devices, deviceToProto(device))
+	}
+	return resp, nil
+}
+
+func (h *Handler) CreateDevice(ctx context.Context, req *inventorypb.Device) (*inventorypb.Device, error) {
+	device := deviceFromProto(req)
+	if err := h.devices.CreateDevice(ctx, device); err != nil {
+		return nil, toStatus(err)
+	}
+	return deviceToProto(device), nil
+}
+
+func (h *Handler) UpdateDevice(ctx context.Context, req *inventorypb.Device) (*inventorypb.Device, error) {
+	device := deviceFromProto(req)
+	current, err := h.devices.GetDevice(ctx, device.ID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	keepDeviceFields(device, current)
+	if err := h.devices.UpdateDevice(ctx, device); err != nil {
+		return nil, toStatus(err)
+	}
+	return deviceToProto(device), nil
+}
+
+func (h *Handler) DeleteDevice(ctx context.Context, req *inventorypb.DeleteRequest) (*emptypb.Empty, error) {
+	if err := h.devices.DeleteDevice(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) ListDeviceProperties(ctx context.Context, req *inventorypb.ListDevicePropertiesRequest) (*inventorypb.ListDevicePropertiesResponse, error) {
+	props, err := h.deviceProperties.GetProperties(ctx, req.GetDeviceId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &inventorypb.ListDevicePropertiesResponse{}
+	for _, prop := range props {
+		resp.Properties = append(resp.Properties, devicePropertyToProto(prop))
+	}
+	return resp, nil
+}
+
+func (h *Handler) CreateDeviceProperty(ctx context.Context, req *inventorypb.DeviceProperty) (*inventorypb.DeviceProperty, error) {
+	prop := devicePropertyFromProto(req)
+	if err := h.deviceProperties.CreateProperty(ctx, prop); err != nil {
+		return nil, toStatus(err)
+	}
+	return devicePropertyToProto(prop), nil
+}
+
+func (h *Handler) UpdateDeviceProperty(ctx context.Context, req *inventorypb.DeviceProperty) (*inventorypb.DeviceProperty, error) {
+	prop := devicePropertyFromProto(req)
+	if err := h.deviceProperties.UpdateProperty(ctx, prop); err != nil {
+		return nil, toStatus(err)
+	}
+	return devicePropertyToProto(prop), nil
+}
+
+func (h *Handler) DeleteDeviceProperty(ctx context.Context, req *inventorypb.DeleteRequest) (*emptypb.Empty, error) {
+	if err := h.deviceProperties.DeleteProperty(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) ListDeviceLogs(ctx context.Context, req *inventorypb.ListDeviceLogsRequest) (*inventorypb.ListDeviceLogsResponse, error) {
+	logs, err := h.deviceLogs.GetLogs(ctx, req.GetDeviceId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &inventorypb.ListDeviceLogsResponse{}
+	for _, logEntry := range logs {
+		resp.Logs = append(resp.Logs, deviceLogToProto(logEntry))
+	}
+	return resp, nil
+}
+
+func (h *Handler) CreateDeviceLog(ctx context.Context, req *inventorypb.DeviceLog) (*inventorypb.DeviceLog, error) {
+	logEntry := deviceLogFromProto(req)
+	if err := h.deviceLogs.CreateLog(ctx, logEntry); err != nil {
+		return nil, toStatus(err)
+	}
+	return deviceLogToProto(logEntry), nil
+}
+
+func (h *Handler) DeleteDeviceLog(ctx context.Context, req *inventorypb.DeleteRequest) (*emptypb.Empty, error) {
+	if err := h.deviceLogs.DeleteLog(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// TailDeviceLogs polls for new logs every tailInterval and sends them in
+// creation order. Logs sharing the newest timestamp are remembered by ID so a
+// log written in the same instant as the previous batch is not skipped or
+// sent twice.
+func (h *Handler) TailDeviceLogs(req *inventorypb.TailDeviceLogsRequest, stream inventorypb.Inventory_TailDeviceLogsServer) error {
+	ctx := stream.Context()
+	deviceID := req.GetDeviceId()
+	utils.Log(ctx).Printf("Tailing logs for Device ID: %s", deviceID)
+
+	since := time.Now()
+	if req.GetIncludeExisting() {
+		since = time.Time{}
+	}
+	sent := map[string]bool{}
+
+	ticker := time.NewTicker(h.tailInterval)
+	defer ticker.Stop()
+	for {
+		logs, err := h.deviceLogs.GetLogsSince(ctx, deviceID, since)
+		if err != nil {
+			return toStatus(err)
+		}
+		for _, logEntry := range logs {
+			if sent[logEntry.ID] {
+				continue
+			}
+			if err := stream.Send(deviceLogToProto(logEntry)); err != nil {
+				utils.Log(ctx).Errorf("Error sending log %s: %v", logEntry.ID, err)
+				return err
+			}
+			if logEntry.CreatedAt.After(since) {
+				since = logEntry.CreatedAt
+				sent = map[string]bool{}
+			}
+			sent[logEntry.ID] = true
+		}
+
+		select {
+		case <-ctx.Done():
+			utils.Log(ctx).Printf("Stopped tailing logs for Device ID: %s", deviceID)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func toStatus(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, err.Error())
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	if errors.Is(err, owners.ErrOwnerHasDevices) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, types.ErrInvalidType) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+var _ inventorypb.InventoryServer = (*Handler)(nil)
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+type Timeouts struct {
+	Read       time.Duration `mapstructure:"read"`
+	ReadHeader time.Duration `mapstructure:"read-header"`
+	Write      time.Duration `mapstructure:"write"`
+	Idle       time.Duration `mapstructure:"idle"`
+}
+
+type Config struct {
+	// Addr is the TCP address to listen on. It is ignored when Socket is set.
+	Addr string `mapstructure:"addr"`
+	// Socket is the path of a Unix socket to listen on instead of Addr.
+	Socket   string    `mapstructure:"socket"`
+	Timeouts Timeouts  `mapstructure:"timeouts"`
+	TLS      TLSConfig `mapstructure:"tls"`
+}
+
+// New returns an http.Server for handler with the configured timeouts.
+func New(cfg Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Handler:           handler,
+		Addr:              cfg.Addr,
+		ReadTimeout:       cfg.Timeouts.Read,
+		ReadHeaderTimeout: cfg.Timeouts.ReadHeader,
+		WriteTimeout:      cfg.Timeouts.Write,
+		IdleTimeout:       cfg.Timeouts.Idle,
+	}
+}
+
+// Listen opens the configured TCP address or Unix socket, wrapped in TLS
+// when a certificate is configured. The returned description names the
+// listener for logging.
+func Listen(cfg Config) (net.Listener, string, error) {
+	var listener net.Listener
+	var err error
+	description := cfg.Addr
+	if cfg.Socket != "" {
+		listener, err = listenUnix(cfg.Socket)
+		description = "unix:" + cfg.Socket
+	} else {
+		listener, err = net.Listen("tcp", cfg.Addr)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !cfg.TLS.Enabled() {
+		return listener, description, nil
+	}
+	tlsConfig, err := cfg.TLS.build()
+	if err != nil {
+		listener.Close()
+		return nil, "", err
+	}
+	return tls.NewListener(listener, tlsConfig), description + " (tls)", nil
+}
+
+// listenUnix removes a socket left behind by a previous run before binding,
+// and lets the group connect so a reverse proxy can share the socket.
+func listenUnix(path string) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o660); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
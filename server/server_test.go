@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCert writes a self-signed certificate for commonName to dir.
+func writeCert(t *testing.T, dir, commonName string, modTime time.Time) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Error creating certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Error marshaling key: %v", err)
+	}
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Error writing certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("Error writing key: %v", err)
+	}
+	for _, file := range []string{certFile, keyFile} {
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatalf("Error setting file time: %v", err)
+		}
+	}
+	return certFile, keyFile
+}
+
+func commonName(t *testing.T, cert *tls.Certificate) string {
+	t.Helper()
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatalf("Error parsing certificate: %v", err)
+	}
+	return leaf.Subject.CommonName
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "first", time.Now().Add(-time.Minute))
+
+	reloader, err := newCertReloader(certFile, keyFile, 0)
+	if err != nil {
+		t.Fatalf("Error loading certificate: %v", err)
+	}
+	cert, _ := reloader.getCertificate(nil)
+	if name := commonName(t, cert); name != "first" {
+		t.Fatalf("Expected first certificate, got %s", name)
+	}
+
+	writeCert(t, dir, "second", time.Now())
+	cert, _ = reloader.getCertificate(nil)
+	if name := commonName(t, cert); name != "second" {
+		t.Fatalf("Expected reloaded certificate, got %s", name)
+	}
+
+	// A broken key keeps the last good certificate in service.
+	if err := os.WriteFile(keyFile, []byte("garbage"), 0o600); err != nil {
+		t.Fatalf("Error writing key: %v", err)
+	}
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(keyFile, later, later)
+	cert, _ = reloader.getCertificate(nil)
+	if name := commonName(t, cert); name != "second" {
+		t.Fatalf("Expected last good certificate, got %s", name)
+	}
+}
+
+func TestListenUnix(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "api.sock")
+	cfg := Config{Socket: socket}
+
+	for i := 0; i < 2; i++ {
+		// The second round checks that a stale socket file is replaced.
+		listener, _, err := Listen(cfg)
+		if err != nil {
+			t.Fatalf("Error listening on socket: %v", err)
+		}
+		srv := New(cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		go srv.Serve(listener)
+
+		client := &http.Client{Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+			},
+		}}
+		resp, err := client.Get("http://unix/")
+		if err != nil {
+			t.Fatalf("Error calling server: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("Expected %d, got %d", http.StatusNoContent, resp.StatusCode)
+		}
+		// Close the listener without unlinking to simulate a crash
+		listener.(*net.UnixListener).SetUnlinkOnClose(false)
+		srv.Close()
+	}
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultReloadInterval = time.Minute
+
+type TLSConfig struct {
+	CertFile string `mapstructure:"cert-file"`
+	KeyFile  string `mapstructure:"key-file"`
+	// ClientCAFile enables mTLS: client certificates are verified against
+	// the CAs in this file.
+	ClientCAFile string `mapstructure:"client-ca-file"`
+	// ClientAuth is "require" to reject callers without a certificate or
+	// "verify-if-given" to accept them. It only applies with ClientCAFile.
+	ClientAuth string `mapstructure:"client-auth"`
+	// ReloadInterval is how often the certificate files are checked for
+	// changes.
+	ReloadInterval time.Duration `mapstructure:"reload-interval"`
+}
+
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+func (c TLSConfig) build() (*tls.Config, error) {
+	if c.CertFile == "" || c.KeyFile == "" {
+		return nil, fmt.Errorf("tls needs both cert-file and key-file")
+	}
+	interval := c.ReloadInterval
+	if interval <= 0 {
+		interval = defaultReloadInterval
+	}
+	reloader, err := newCertReloader(c.CertFile, c.KeyFile, interval)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.getCertificate,
+	}
+
+	if c.ClientCAFile == "" {
+		return config, nil
+	}
+	pem, err := os.ReadFile(c.ClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", c.ClientCAFile)
+	}
+	config.ClientCAs = pool
+	switch c.ClientAuth {
+	case "", "require":
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	case "verify-if-given":
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	default:
+		return nil, fmt.Errorf("unknown client-auth %q", c.ClientAuth)
+	}
+	return config, nil
+}
+
+// certReloader serves the certificate from disk and reloads it when the
+// files change, so renewed certificates are picked up without a restart.
+type certReloader struct {
+	certFile string
+	keyFile  string
+	interval time.Duration
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	modTime   time.Time
+	lastCheck time.Time
+}
+
+func newCertReloader(certFile, keyFile string, interval time.Duration) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, interval: interval}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *certReloader) load() error {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+	r.cert = &cert
+	r.modTime = modTime
+	return nil
+}
+
+func (r *certReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// getCertificate keeps serving the previous certificate if a reload fails,
+// e.g. while the cert has been written but the key not yet.
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.lastCheck) >= r.interval {
+		r.lastCheck = time.Now()
+		modTime, err := r.latestModTime()
+		if err != nil {
+			log.Errorf("Could not check TLS certificate: %v", err)
+		} else if modTime.After(r.modTime) {
+			if err := r.load(); err != nil {
+				log.Errorf("Could not reload TLS certificate: %v", err)
+			} else {
+				log.Printf("Reloaded TLS certificate from %s", r.certFile)
+			}
+		}
+	}
+	return r.cert, nil
+}
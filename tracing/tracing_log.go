@@ -0,0 +1,28 @@
+package tracing
+
+import (
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LogHook adds trace_id and span_id fields to log entries whose context
+// carries a recording span. Entries need a context, e.g.
+// log.WithContext(ctx).Printf(...).
+type LogHook struct{}
+
+func (h *LogHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h *LogHook) Fire(entry *log.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	spanContext := trace.SpanContextFromContext(entry.Context)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	entry.Data["trace_id"] = spanContext.TraceID().String()
+	entry.Data["span_id"] = spanContext.SpanID().String()
+	return nil
+}
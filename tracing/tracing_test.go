@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestLogHook(t *testing.T) {
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background())
+	ctx, span := provider.Tracer("test").Start(context.Background(), "test")
+	defer span.End()
+
+	hook := &LogHook{}
+	entry := log.WithContext(ctx)
+	if err := hook.Fire(entry); err != nil {
+		t.Fatalf("Error firing hook: %v", err)
+	}
+	if entry.Data["trace_id"] != span.SpanContext().TraceID().String() {
+		t.Fatalf("Expected trace_id %s, got %v", span.SpanContext().TraceID(), entry.Data["trace_id"])
+	}
+
+	entry = log.WithContext(context.Background())
+	if err := hook.Fire(entry); err != nil {
+		t.Fatalf("Error firing hook: %v", err)
+	}
+	if _, ok := entry.Data["trace_id"]; ok {
+		t.Fatal("Expected no trace_id without a span")
+	}
+}
+
+func TestOperation(t *testing.T) {
+	tests := map[string]string{
+		"begin":                               "BEGIN",
+		"\n\t\tSELECT id FROM devices":        "SELECT",
+		"INSERT INTO owners (id) VALUES ($1)": "INSERT",
+		"":                                    "query",
+	}
+	for sql, want := range tests {
+		if got := operation(sql); got != want {
+			t.Fatalf("operation(%q) = %q, want %q", sql, got, want)
+		}
+	}
+}
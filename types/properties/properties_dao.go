@@ -6,7 +6,6 @@ import (
 	"github.com/jackc/pgx/v5"
 	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/rickCrz7/Inventory-API/utils"
-	log "github.com/sirupsen/logrus"
 )
 
 type Dao struct{}
@@ -16,13 +15,13 @@ func NewDao() *Dao {
 }
 
 func (d *Dao) GetProperties(ctx context.Context, tx pgx.Tx, type_id string) ([]*utils.TypeProperty, error) {
-	log.Printf("Fetching properties with Type ID: %s", type_id)
+	utils.Log(ctx).Printf("Fetching properties with Type ID: %s", type_id)
 	query := `SELECT id, type_id, name, data_type, required
 	FROM type_properties
 	WHERE type_id = $1`
 	rows, err := tx.Query(ctx, query, type_id)
 	if err != nil {
-		log.Errorf("Could not get properties for type %s: %v", type_id, err)
+		utils.Log(ctx).Errorf("Could not get properties for type %s: %v", type_id, err)
 		return nil, err
 	}
 	defer rows.Close()
@@ -31,25 +30,25 @@ func (d *Dao) GetProperties(ctx context.Context, tx pgx.Tx, type_id string) ([]*
 	for rows.Next() {
 		var property utils.TypeProperty
 		if err := rows.Scan(&property.ID, &property.TypeID, &property.Name, &property.DataType, &property.Required); err != nil {
-			log.Errorf("Could not scan property: %v", err)
+			utils.Log(ctx).Errorf("Could not scan property: %v", err)
 			return nil, err
 		}
 		properties = append(properties, &property)
 	}
 	if err := rows.Err(); err != nil {
-		log.Errorf("Error occurred while fetching properties: %v", err)
+		utils.Log(ctx).Errorf("Error occurred while fetching properties: %v", err)
 		return nil, err
 	}
 	return properties, nil
 }
 
 func (d *Dao) CreateProperty(ctx context.Context, tx pgx.Tx, property *utils.TypeProperty) error {
-	log.Printf("Creating property: %v", property)
+	utils.Log(ctx).Printf("Creating property: %v", property)
 	if property.ID == "" {
 		var err error
 		property.ID, err = gonanoid.New()
 		if err != nil {
-			log.Errorf("Could not generate property ID: %v", err)
+			utils.Log(ctx).Errorf("Could not generate property ID: %v", err)
 			return err
 		}
 	}
@@ -57,32 +56,72 @@ func (d *Dao) CreateProperty(ctx context.Context, tx pgx.Tx, property *utils.Typ
 	VALUES ($1, $2, $3, $4, $5)`
 	_, err := tx.Exec(ctx, query, property.ID, property.TypeID, property.Name, property.DataType, property.Required)
 	if err != nil {
-		log.Errorf("Could not create property: %v", err)
+		utils.Log(ctx).Errorf("Could not create property: %v", err)
 		return err
 	}
 	return nil
 }
 
 func (d *Dao) UpdateProperty(ctx context.Context, tx pgx.Tx, property *utils.TypeProperty) error {
-	log.Printf("Updating property: %v", property)
+	utils.Log(ctx).Printf("Updating property: %v", property)
 	query := `UPDATE type_properties
 	SET type_id = $2, name = $3, data_type = $4, required = $5
 	WHERE id = $1`
 	_, err := tx.Exec(ctx, query, property.ID, property.TypeID, property.Name, property.DataType, property.Required)
 	if err != nil {
-		log.Errorf("Could not update property %s: %v", property.ID, err)
+		utils.Log(ctx).Errorf("Could not update property %s: %v", property.ID, err)
 		return err
 	}
 	return nil
 }
 
 func (d *Dao) DeleteProperty(ctx context.Context, tx pgx.Tx, id string) error {
-	log.Printf("Deleting property with ID: %s", id)
+	utils.Log(ctx).Printf("Deleting property with ID: %s", id)
 	query := `DELETE FROM type_properties WHERE id = $1`
 	_, err := tx.Exec(ctx, query, id)
 	if err != nil {
-		log.Errorf("Could not delete property %s: %v", id, err)
+		utils.Log(ctx).Errorf("Could not delete property %s: %v", id, err)
 		return err
 	}
 	return nil
 }
+
+func (d *Dao) GetPropertiesByTypeIDs(ctx context.Context, tx pgx.Tx, type_ids []string) ([]*utils.TypeProperty, error) {
+	utils.Log(ctx).Printf("Fetching properties with Type IDs: %v", type_ids)
+	query := `SELECT id, type_id, name, data_type, required
+	FROM type_properties
+	WHERE type_id = ANY($1)`
+	return d.queryProperties(ctx, tx, query, type_ids)
+}
+
+func (d *Dao) GetPropertiesByIDs(ctx context.Context, tx pgx.Tx, ids []string) ([]*utils.TypeProperty, error) {
+	utils.Log(ctx).Printf("Fetching properties with IDs: %v", ids)
+	query := `SELECT id, type_id, name, data_type, required
+	FROM type_properties
+	WHERE id = ANY($1)`
+	return d.queryProperties(ctx, tx, query, ids)
+}
+
+func (d *Dao) queryProperties(ctx context.Context, tx pgx.Tx, query string, args ...any) ([]*utils.TypeProperty, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		utils.Log(ctx).Errorf("Could not get properties: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var properties []*utils.TypeProperty
+	for rows.Next() {
+		var property utils.TypeProperty
+		if err := rows.Scan(&property.ID, &property.TypeID, &property.Name, &property.DataType, &property.Required); err != nil {
+			utils.Log(ctx).Errorf("Could not scan property: %v", err)
+			return nil, err
+		}
+		properties = append(properties, &property)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error occurred while fetching properties: %v", err)
+		return nil, err
+	}
+	return properties, nil
+}
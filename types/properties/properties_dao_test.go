@@ -38,7 +38,7 @@ func init() {
 }
 
 func TestGetProperties(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -82,7 +82,7 @@ func TestGetProperties(t *testing.T) {
 }
 
 func TestCreateProperty(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -173,7 +173,7 @@ func TestCreateProperty(t *testing.T) {
 }
 
 func TestUpdateProperty(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -240,7 +240,7 @@ func TestUpdateProperty(t *testing.T) {
 }
 
 func TestDeleteProperty(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -285,4 +285,62 @@ func TestDeleteProperty(t *testing.T) {
 			t.Errorf("Expected no properties to be fetched, but got %d", len(propertyFetched))
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestGetPropertiesByTypeIDs(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+
+	// Mock Data
+	var typeIDs, propertyIDs []string
+	for i := 0; i < 2; i++ {
+		id, _ := gonanoid.New()
+		_, err = tx.Exec(ctx, `
+			INSERT INTO types (id, name, description) VALUES ($1, $2, $3)
+		`, id, "Test Type", "This is a test type")
+		if err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+		typeIDs = append(typeIDs, id)
+		for j := 0; j < 2; j++ {
+			pid, _ := gonanoid.New()
+			_, err = tx.Exec(ctx, `
+				INSERT INTO type_properties (id, type_id, name, data_type, required) VALUES ($1, $2, $3, $4, $5)
+			`, pid, id, fmt.Sprintf("Test Property %d", j), "string", true)
+			if err != nil {
+				t.Fatalf("Error inserting mock data: %v", err)
+			}
+			propertyIDs = append(propertyIDs, pid)
+		}
+	}
+	t.Run("GetPropertiesByTypeIDs", func(t *testing.T) {
+		properties, err := dao.GetPropertiesByTypeIDs(ctx, tx, typeIDs)
+		if err != nil {
+			t.Fatalf("Error getting properties: %v", err)
+		}
+		if len(properties) != 4 {
+			t.Errorf("Expected 4 properties, but got %d", len(properties))
+		}
+	})
+	t.Run("GetPropertiesByIDs", func(t *testing.T) {
+		properties, err := dao.GetPropertiesByIDs(ctx, tx, propertyIDs[:3])
+		if err != nil {
+			t.Fatalf("Error getting properties: %v", err)
+		}
+		if len(properties) != 3 {
+			t.Errorf("Expected 3 properties, but got %d", len(properties))
+		}
+	})
+}
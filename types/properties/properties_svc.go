@@ -4,17 +4,16 @@ import (
 	"context"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rickCrz7/Inventory-API/tracing"
 	"github.com/rickCrz7/Inventory-API/utils"
-	log "github.com/sirupsen/logrus"
 )
 
 type Service struct {
 	dao *Dao
-	pdb *pgxpool.Pool
+	pdb *utils.DB
 }
 
-func NewService(dao *Dao, pdb *pgxpool.Pool) *Service {
+func NewService(dao *Dao, pdb *utils.DB) *Service {
 	return &Service{
 		dao: dao,
 		pdb: pdb,
@@ -22,90 +21,100 @@ func NewService(dao *Dao, pdb *pgxpool.Pool) *Service {
 }
 
 func (s *Service) GetProperties(ctx context.Context, type_id string) ([]*utils.TypeProperty, error) {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadOnly,
+	ctx, span := tracing.Start(ctx, "properties.GetProperties")
+	defer span.End()
+
+	var properties []*utils.TypeProperty
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		properties, err = s.dao.GetProperties(ctx, tx, type_id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get properties: %v", err)
+			return err
+		}
+		return nil
 	})
 	if err != nil {
-		log.Errorf("Failed to begin transaction: %v", err)
-		return nil, err
-	}
-	defer tx.Rollback(ctx)
-
-	properties, err := s.dao.GetProperties(ctx, tx, type_id)
-	if err != nil {
-		log.Errorf("Failed to get properties: %v", err)
-		return nil, err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Failed to commit transaction: %v", err)
 		return nil, err
 	}
 	return properties, nil
 }
 
 func (s *Service) CreateProperty(ctx context.Context, property *utils.TypeProperty) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadWrite,
+	ctx, span := tracing.Start(ctx, "properties.CreateProperty")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.dao.CreateProperty(ctx, tx, property); err != nil {
+			utils.Log(ctx).Errorf("Failed to create property: %v", err)
+			return err
+		}
+		return nil
 	})
-	if err != nil {
-		log.Errorf("Failed to begin transaction: %v", err)
-		return err
-	}
-	defer tx.Rollback(ctx)
-
-	if err := s.dao.CreateProperty(ctx, tx, property); err != nil {
-		log.Errorf("Failed to create property: %v", err)
-		return err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Failed to commit transaction: %v", err)
-		return err
-	}
-	return nil
 }
 
 func (s *Service) UpdateProperty(ctx context.Context, property *utils.TypeProperty) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadWrite,
+	ctx, span := tracing.Start(ctx, "properties.UpdateProperty")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.dao.UpdateProperty(ctx, tx, property); err != nil {
+			utils.Log(ctx).Errorf("Failed to update property: %v", err)
+			return err
+		}
+		return nil
 	})
-	if err != nil {
-		log.Errorf("Failed to begin transaction: %v", err)
-		return err
-	}
-	defer tx.Rollback(ctx)
-
-	if err := s.dao.UpdateProperty(ctx, tx, property); err != nil {
-		log.Errorf("Failed to update property: %v", err)
-		return err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Failed to commit transaction: %v", err)
-		return err
-	}
-	return nil
 }
 
 func (s *Service) DeleteProperty(ctx context.Context, id string) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadWrite,
+	ctx, span := tracing.Start(ctx, "properties.DeleteProperty")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.dao.DeleteProperty(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Failed to delete property: %v", err)
+			return err
+		}
+		return nil
 	})
-	if err != nil {
-		log.Errorf("Failed to begin transaction: %v", err)
-		return err
-	}
-	defer tx.Rollback(ctx)
+}
 
-	if err := s.dao.DeleteProperty(ctx, tx, id); err != nil {
-		log.Errorf("Failed to delete property: %v", err)
-		return err
+func (s *Service) GetPropertiesByTypeIDs(ctx context.Context, type_ids []string) ([]*utils.TypeProperty, error) {
+	ctx, span := tracing.Start(ctx, "properties.GetPropertiesByTypeIDs")
+	defer span.End()
+
+	var properties []*utils.TypeProperty
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		properties, err = s.dao.GetPropertiesByTypeIDs(ctx, tx, type_ids)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get properties: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return properties, nil
+}
 
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Failed to commit transaction: %v", err)
-		return err
+func (s *Service) GetPropertiesByIDs(ctx context.Context, ids []string) ([]*utils.TypeProperty, error) {
+	ctx, span := tracing.Start(ctx, "properties.GetPropertiesByIDs")
+	defer span.End()
+
+	var properties []*utils.TypeProperty
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		properties, err = s.dao.GetPropertiesByIDs(ctx, tx, ids)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get properties: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil
-}
\ No newline at end of file
+	return properties, nil
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/jackc/pgx/v5"
 	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/rickCrz7/Inventory-API/utils"
-	log "github.com/sirupsen/logrus"
 )
 
 type Dao struct{}
@@ -16,23 +15,23 @@ func NewDao() *Dao {
 }
 
 func (d *Dao) GetType(ctx context.Context, tx pgx.Tx, id string) (*utils.Type, error) {
-	log.Printf("Fetching type with ID: %s", id)
-	query := `SELECT id, name, description FROM types WHERE id = $1`
+	utils.Log(ctx).Printf("Fetching type with ID: %s", id)
+	query := `SELECT id, name, description, depreciation_method, useful_life_months, salvage_percent, declining_factor, asset_tag_prefix FROM types WHERE id = $1`
 	var t utils.Type
-	err := tx.QueryRow(ctx, query, id).Scan(&t.ID, &t.Name, &t.Description)
+	err := tx.QueryRow(ctx, query, id).Scan(&t.ID, &t.Name, &t.Description, &t.DepreciationMethod, &t.UsefulLifeMonths, &t.SalvagePercent, &t.DecliningFactor, &t.AssetTagPrefix)
 	if err != nil {
-		log.Errorf("Error fetching type with ID %s: %v", id, err)
+		utils.Log(ctx).Errorf("Error fetching type with ID %s: %v", id, err)
 		return nil, err
 	}
 	return &t, nil
 }
 
 func (d *Dao) GetTypes(ctx context.Context, tx pgx.Tx) ([]*utils.Type, error) {
-	log.Println("Fetching all types")
-	query := `SELECT id, name, description FROM types`
+	utils.Log(ctx).Println("Fetching all types")
+	query := `SELECT id, name, description, depreciation_method, useful_life_months, salvage_percent, declining_factor, asset_tag_prefix FROM types`
 	rows, err := tx.Query(ctx, query)
 	if err != nil {
-		log.Errorf("Error fetching types: %v", err)
+		utils.Log(ctx).Errorf("Error fetching types: %v", err)
 		return nil, err
 	}
 	defer rows.Close()
@@ -40,56 +39,86 @@ func (d *Dao) GetTypes(ctx context.Context, tx pgx.Tx) ([]*utils.Type, error) {
 	var types []*utils.Type
 	for rows.Next() {
 		var t utils.Type
-		if err := rows.Scan(&t.ID, &t.Name, &t.Description); err != nil {
-			log.Errorf("Error scanning type: %v", err)
+		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.DepreciationMethod, &t.UsefulLifeMonths, &t.SalvagePercent, &t.DecliningFactor, &t.AssetTagPrefix); err != nil {
+			utils.Log(ctx).Errorf("Error scanning type: %v", err)
 			return nil, err
 		}
 		types = append(types, &t)
 	}
 	if err := rows.Err(); err != nil {
-		log.Errorf("Error with rows: %v", err)
+		utils.Log(ctx).Errorf("Error with rows: %v", err)
 		return nil, err
 	}
 	return types, nil
 }
 
 func (d *Dao) CreateType(ctx context.Context, tx pgx.Tx, t *utils.Type) error {
-	log.Printf("Creating type: %+v", t)
+	utils.Log(ctx).Printf("Creating type: %+v", t)
 	if t.ID == "" {
 		var err error
 		t.ID, err = gonanoid.New()
 		if err != nil {
-			log.Errorf("Error generating ID: %v", err)
+			utils.Log(ctx).Errorf("Error generating ID: %v", err)
 			return err
 		}
 	}
-	query := `INSERT INTO types (id, name, description) VALUES ($1, $2, $3)`
-	_, err := tx.Exec(ctx, query, t.ID, t.Name, t.Description)
+	query := `INSERT INTO types (id, name, description, depreciation_method, useful_life_months, salvage_percent, declining_factor, asset_tag_prefix)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := tx.Exec(ctx, query, t.ID, t.Name, t.Description, t.DepreciationMethod, t.UsefulLifeMonths, t.SalvagePercent, t.DecliningFactor, t.AssetTagPrefix)
 	if err != nil {
-		log.Errorf("Error creating type: %v", err)
+		utils.Log(ctx).Errorf("Error creating type: %v", err)
 		return err
 	}
 	return nil
 }
 
 func (d *Dao) UpdateType(ctx context.Context, tx pgx.Tx, t *utils.Type) error {
-	log.Printf("Updating type: %+v", t)
-	query := `UPDATE types SET name = $1, description = $2 WHERE id = $3`
-	_, err := tx.Exec(ctx, query, t.Name, t.Description, t.ID)
+	utils.Log(ctx).Printf("Updating type: %+v", t)
+	query := `UPDATE types
+	SET name = $1, description = $2, depreciation_method = $4, useful_life_months = $5, salvage_percent = $6, declining_factor = $7,
+		asset_tag_prefix = $8
+	WHERE id = $3`
+	_, err := tx.Exec(ctx, query, t.Name, t.Description, t.ID, t.DepreciationMethod, t.UsefulLifeMonths, t.SalvagePercent, t.DecliningFactor, t.AssetTagPrefix)
 	if err != nil {
-		log.Errorf("Error updating type: %v", err)
+		utils.Log(ctx).Errorf("Error updating type: %v", err)
 		return err
 	}
 	return nil
 }
 
 func (d *Dao) DeleteType(ctx context.Context, tx pgx.Tx, id string) error {
-	log.Printf("Deleting type with ID: %s", id)
+	utils.Log(ctx).Printf("Deleting type with ID: %s", id)
 	query := `DELETE FROM types WHERE id = $1`
 	_, err := tx.Exec(ctx, query, id)
 	if err != nil {
-		log.Errorf("Error deleting type with ID %s: %v", id, err)
+		utils.Log(ctx).Errorf("Error deleting type with ID %s: %v", id, err)
 		return err
 	}
 	return nil
 }
+
+func (d *Dao) GetTypesByIDs(ctx context.Context, tx pgx.Tx, ids []string) ([]*utils.Type, error) {
+	utils.Log(ctx).Printf("Fetching types with IDs: %v", ids)
+	query := `SELECT id, name, description, depreciation_method, useful_life_months, salvage_percent, declining_factor, asset_tag_prefix FROM types WHERE id = ANY($1)`
+	rows, err := tx.Query(ctx, query, ids)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching types with IDs %v: %v", ids, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var types []*utils.Type
+	for rows.Next() {
+		var t utils.Type
+		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.DepreciationMethod, &t.UsefulLifeMonths, &t.SalvagePercent, &t.DecliningFactor, &t.AssetTagPrefix); err != nil {
+			utils.Log(ctx).Errorf("Error scanning type: %v", err)
+			return nil, err
+		}
+		types = append(types, &t)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error with rows: %v", err)
+		return nil, err
+	}
+	return types, nil
+}
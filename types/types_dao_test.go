@@ -37,7 +37,7 @@ func init() {
 }
 
 func TestGetType(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -84,7 +84,7 @@ func TestGetType(t *testing.T) {
 }
 
 func TestGetTypes(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -121,7 +121,7 @@ func TestGetTypes(t *testing.T) {
 }
 
 func TestCreateType(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -173,7 +173,7 @@ func TestCreateType(t *testing.T) {
 }
 
 func TestUpdateType(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -224,7 +224,7 @@ func TestUpdateType(t *testing.T) {
 }
 
 func TestDeleteType(t *testing.T) {
-	pdb, err := utils.OpenDB(postgresURI, false)
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
 	if err != nil {
 		t.Fatalf("Error opening database: %v", err)
 	}
@@ -261,3 +261,41 @@ func TestDeleteType(t *testing.T) {
 		}
 	})
 }
+
+func TestGetTypesByIDs(t *testing.T) {
+	pdb, err := utils.OpenDB(postgresURI, utils.PoolConfig{})
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer pdb.Close()
+
+	ctx := context.Background()
+	tx, err := pdb.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Error beginning transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	dao := NewDao()
+	// Mock Data
+	var ids []string
+	for i := 0; i < 3; i++ {
+		id, _ := gonanoid.New()
+		_, err = tx.Exec(ctx, `
+			INSERT INTO types (id, name, description) VALUES ($1, $2, $3)
+		`, id, "Test Type", "This is a test type")
+		if err != nil {
+			t.Fatalf("Error inserting mock data: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	t.Run("GetTypesByIDs", func(t *testing.T) {
+		types, err := dao.GetTypesByIDs(ctx, tx, ids)
+		if err != nil {
+			t.Fatalf("Error getting types: %v", err)
+		}
+		if len(types) != 3 {
+			t.Fatalf("Expected 3 types, got %d", len(types))
+		}
+	})
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -46,11 +47,11 @@ func (h *Handler) GetTypes(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) CreateType(w http.ResponseWriter, r *http.Request) {
 	var typ utils.Type
 	if err := json.NewDecoder(r.Body).Decode(&typ); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
 		return
 	}
 	if err := h.svc.CreateType(r.Context(), &typ); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusCreated)
@@ -60,11 +61,11 @@ func (h *Handler) CreateType(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) UpdateType(w http.ResponseWriter, r *http.Request) {
 	var typ utils.Type
 	if err := json.NewDecoder(r.Body).Decode(&typ); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
 		return
 	}
 	if err := h.svc.UpdateType(r.Context(), &typ); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -79,3 +80,12 @@ func (h *Handler) DeleteType(w http.ResponseWriter, r *http.Request) {
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidType):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
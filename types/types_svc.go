@@ -2,19 +2,67 @@ package types
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rickCrz7/Inventory-API/tracing"
 	"github.com/rickCrz7/Inventory-API/utils"
-	log "github.com/sirupsen/logrus"
 )
 
+// ErrInvalidType is returned when a type's depreciation settings are
+// inconsistent or its asset tag prefix is malformed.
+var ErrInvalidType = errors.New("invalid type")
+
+var prefixPattern = regexp.MustCompile(`^[A-Z0-9]{1,8}$`)
+
+// validatePrefix upper-cases the asset tag prefix and checks it is one to
+// eight letters or digits. Changing it only affects devices created later.
+func validatePrefix(t *utils.Type) error {
+	if t.AssetTagPrefix == nil {
+		return nil
+	}
+	prefix := strings.ToUpper(strings.TrimSpace(*t.AssetTagPrefix))
+	if !prefixPattern.MatchString(prefix) {
+		return fmt.Errorf("%w: asset_tag_prefix must be 1 to 8 letters or digits, got %q", ErrInvalidType, *t.AssetTagPrefix)
+	}
+	t.AssetTagPrefix = &prefix
+	return nil
+}
+
+// validateDepreciation defaults an empty method to none and checks that the
+// other settings fit the method.
+func validateDepreciation(t *utils.Type) error {
+	switch t.DepreciationMethod {
+	case "":
+		t.DepreciationMethod = utils.DepreciationNone
+		return nil
+	case utils.DepreciationNone:
+		return nil
+	case utils.DepreciationStraightLine, utils.DepreciationDecliningBalance:
+	default:
+		return fmt.Errorf("%w: unknown depreciation_method %q", ErrInvalidType, t.DepreciationMethod)
+	}
+	if t.UsefulLifeMonths == nil || *t.UsefulLifeMonths <= 0 {
+		return fmt.Errorf("%w: useful_life_months must be positive for %s", ErrInvalidType, t.DepreciationMethod)
+	}
+	if t.SalvagePercent < 0 || t.SalvagePercent > 100 {
+		return fmt.Errorf("%w: salvage_percent must be between 0 and 100", ErrInvalidType)
+	}
+	if t.DecliningFactor != nil && *t.DecliningFactor <= 0 {
+		return fmt.Errorf("%w: declining_factor must be positive", ErrInvalidType)
+	}
+	return nil
+}
+
 type Service struct {
 	dao *Dao
-	pdb *pgxpool.Pool
+	pdb *utils.DB
 }
 
-func NewService(dao *Dao, pdb *pgxpool.Pool) *Service {
+func NewService(dao *Dao, pdb *utils.DB) *Service {
 	return &Service{
 		dao: dao,
 		pdb: pdb,
@@ -22,113 +70,112 @@ func NewService(dao *Dao, pdb *pgxpool.Pool) *Service {
 }
 
 func (s *Service) GetType(ctx context.Context, id string) (*utils.Type, error) {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadOnly,
+	ctx, span := tracing.Start(ctx, "types.GetType")
+	defer span.End()
+
+	var typ *utils.Type
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		typ, err = s.dao.GetType(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error getting type: %v", err)
+			return err
+		}
+		return nil
 	})
 	if err != nil {
-		log.Errorf("Error beginning transaction: %v", err)
-		return nil, err
-	}
-	defer tx.Rollback(ctx)
-
-	typ, err := s.dao.GetType(ctx, tx, id)
-	if err != nil {
-		log.Errorf("Error getting type: %v", err)
-		return nil, err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
 		return nil, err
 	}
 	return typ, nil
 }
 
 func (s *Service) GetTypes(ctx context.Context) ([]*utils.Type, error) {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadOnly,
+	ctx, span := tracing.Start(ctx, "types.GetTypes")
+	defer span.End()
+
+	var types []*utils.Type
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		types, err = s.dao.GetTypes(ctx, tx)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error getting types: %v", err)
+			return err
+		}
+		return nil
 	})
 	if err != nil {
-		log.Errorf("Error beginning transaction: %v", err)
-		return nil, err
-	}
-	defer tx.Rollback(ctx)
-
-	types, err := s.dao.GetTypes(ctx, tx)
-	if err != nil {
-		log.Errorf("Error getting types: %v", err)
-		return nil, err
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
 		return nil, err
 	}
 	return types, nil
 }
 
 func (s *Service) CreateType(ctx context.Context, t *utils.Type) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadWrite,
-	})
-	if err != nil {
-		log.Errorf("Error beginning transaction: %v", err)
-		return err
-	}
-	defer tx.Rollback(ctx)
+	ctx, span := tracing.Start(ctx, "types.CreateType")
+	defer span.End()
 
-	if err := s.dao.CreateType(ctx, tx, t); err != nil {
-		log.Errorf("Error creating type: %v", err)
+	if err := validateDepreciation(t); err != nil {
 		return err
 	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
+	if err := validatePrefix(t); err != nil {
 		return err
 	}
-	return nil
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.dao.CreateType(ctx, tx, t); err != nil {
+			utils.Log(ctx).Errorf("Error creating type: %v", err)
+			return err
+		}
+		return nil
+	})
 }
 
 func (s *Service) UpdateType(ctx context.Context, t *utils.Type) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadWrite,
-	})
-	if err != nil {
-		log.Errorf("Error beginning transaction: %v", err)
-		return err
-	}
-	defer tx.Rollback(ctx)
+	ctx, span := tracing.Start(ctx, "types.UpdateType")
+	defer span.End()
 
-	if err := s.dao.UpdateType(ctx, tx, t); err != nil {
-		log.Errorf("Error updating type: %v", err)
+	if err := validateDepreciation(t); err != nil {
 		return err
 	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
+	if err := validatePrefix(t); err != nil {
 		return err
 	}
-	return nil
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.dao.UpdateType(ctx, tx, t); err != nil {
+			utils.Log(ctx).Errorf("Error updating type: %v", err)
+			return err
+		}
+		return nil
+	})
 }
 
 func (s *Service) DeleteType(ctx context.Context, id string) error {
-	tx, err := s.pdb.BeginTx(ctx, pgx.TxOptions{
-		AccessMode: pgx.ReadWrite,
+	ctx, span := tracing.Start(ctx, "types.DeleteType")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.dao.DeleteType(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Error deleting type: %v", err)
+			return err
+		}
+		return nil
 	})
-	if err != nil {
-		log.Errorf("Error beginning transaction: %v", err)
-		return err
-	}
-	defer tx.Rollback(ctx)
-
-	if err := s.dao.DeleteType(ctx, tx, id); err != nil {
-		log.Errorf("Error deleting type: %v", err)
-		return err
-	}
+}
 
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing transaction: %v", err)
-		return err
+func (s *Service) GetTypesByIDs(ctx context.Context, ids []string) ([]*utils.Type, error) {
+	ctx, span := tracing.Start(ctx, "types.GetTypesByIDs")
+	defer span.End()
+
+	var types []*utils.Type
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		types, err = s.dao.GetTypesByIDs(ctx, tx, ids)
+		if err != nil {
+			utils.Log(ctx).Errorf("Error getting types: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return types, nil
 }
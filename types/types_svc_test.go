@@ -0,0 +1,70 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+func TestValidateDepreciation(t *testing.T) {
+	life := 36
+	zero := 0
+	factor := -1.0
+	cases := []struct {
+		name  string
+		typ   utils.Type
+		valid bool
+	}{
+		{"Default", utils.Type{}, true},
+		{"StraightLine", utils.Type{DepreciationMethod: utils.DepreciationStraightLine, UsefulLifeMonths: &life, SalvagePercent: 10}, true},
+		{"Unknown", utils.Type{DepreciationMethod: "sum_of_years"}, false},
+		{"MissingLife", utils.Type{DepreciationMethod: utils.DepreciationStraightLine}, false},
+		{"ZeroLife", utils.Type{DepreciationMethod: utils.DepreciationDecliningBalance, UsefulLifeMonths: &zero}, false},
+		{"Salvage", utils.Type{DepreciationMethod: utils.DepreciationStraightLine, UsefulLifeMonths: &life, SalvagePercent: 120}, false},
+		{"Factor", utils.Type{DepreciationMethod: utils.DepreciationDecliningBalance, UsefulLifeMonths: &life, DecliningFactor: &factor}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateDepreciation(&c.typ)
+			if c.valid && err != nil {
+				t.Fatalf("Expected type to be valid, got %v", err)
+			}
+			if !c.valid && !errors.Is(err, ErrInvalidType) {
+				t.Fatalf("Expected ErrInvalidType, got %v", err)
+			}
+		})
+	}
+	t.Run("DefaultsToNone", func(t *testing.T) {
+		typ := utils.Type{}
+		validateDepreciation(&typ)
+		if typ.DepreciationMethod != utils.DepreciationNone {
+			t.Errorf("Expected method %q, got %q", utils.DepreciationNone, typ.DepreciationMethod)
+		}
+	})
+}
+
+func TestValidatePrefix(t *testing.T) {
+	prefix := func(s string) *string { return &s }
+	t.Run("UpperCases", func(t *testing.T) {
+		typ := utils.Type{AssetTagPrefix: prefix(" lap ")}
+		if err := validatePrefix(&typ); err != nil {
+			t.Fatalf("Expected prefix to be valid, got %v", err)
+		}
+		if *typ.AssetTagPrefix != "LAP" {
+			t.Errorf("Expected prefix LAP, got %q", *typ.AssetTagPrefix)
+		}
+	})
+	t.Run("Unset", func(t *testing.T) {
+		if err := validatePrefix(&utils.Type{}); err != nil {
+			t.Fatalf("Expected no prefix to be valid, got %v", err)
+		}
+	})
+	for _, bad := range []string{"", "LAP-", "TOOLONGPREFIX"} {
+		t.Run("Invalid"+bad, func(t *testing.T) {
+			if err := validatePrefix(&utils.Type{AssetTagPrefix: prefix(bad)}); !errors.Is(err, ErrInvalidType) {
+				t.Fatalf("Expected ErrInvalidType for %q, got %v", bad, err)
+			}
+		})
+	}
+}
@@ -2,25 +2,79 @@ package utils
 
 import (
 	"context"
+	"strconv"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/multitracer"
 	"github.com/jackc/pgx/v5/pgxpool"
 	log "github.com/sirupsen/logrus"
 )
 
-func OpenDB(dsn string, setLimits bool) (*pgxpool.Pool, error) {
-	db, err := pgxpool.New(context.Background(), dsn)
+// PoolConfig overrides pool and session settings from the connection string.
+// Zero values keep whatever the DSN or pgxpool defaults to.
+type PoolConfig struct {
+	MaxConns          int32         `mapstructure:"max-conns"`
+	MinConns          int32         `mapstructure:"min-conns"`
+	MaxConnLifetime   time.Duration `mapstructure:"max-conn-lifetime"`
+	MaxConnIdleTime   time.Duration `mapstructure:"max-conn-idle-time"`
+	HealthCheckPeriod time.Duration `mapstructure:"health-check-period"`
+	// StatementTimeout aborts any statement running longer than this.
+	StatementTimeout time.Duration `mapstructure:"statement-timeout"`
+	ApplicationName  string        `mapstructure:"application-name"`
+	SearchPath       string        `mapstructure:"search-path"`
+}
+
+func (c PoolConfig) apply(config *pgxpool.Config) {
+	if c.MaxConns > 0 {
+		config.MaxConns = c.MaxConns
+	}
+	if c.MinConns > 0 {
+		config.MinConns = c.MinConns
+	}
+	if c.MaxConnLifetime > 0 {
+		config.MaxConnLifetime = c.MaxConnLifetime
+	}
+	if c.MaxConnIdleTime > 0 {
+		config.MaxConnIdleTime = c.MaxConnIdleTime
+	}
+	if c.HealthCheckPeriod > 0 {
+		config.HealthCheckPeriod = c.HealthCheckPeriod
+	}
+
+	params := config.ConnConfig.RuntimeParams
+	if c.StatementTimeout > 0 {
+		params["statement_timeout"] = strconv.FormatInt(c.StatementTimeout.Milliseconds(), 10)
+	}
+	if c.ApplicationName != "" {
+		params["application_name"] = c.ApplicationName
+	}
+	if c.SearchPath != "" {
+		params["search_path"] = c.SearchPath
+	}
+}
+
+// OpenDB opens a pool for dsn with the settings in pool applied. Any tracers
+// given are attached to every connection in the pool.
+func OpenDB(dsn string, pool PoolConfig, tracers ...pgx.QueryTracer) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
 		return nil, err
 	}
+	// The pool copies its config on creation, so everything has to be set
+	// before NewWithConfig.
+	pool.apply(config)
+	switch len(tracers) {
+	case 0:
+	case 1:
+		config.ConnConfig.Tracer = tracers[0]
+	default:
+		config.ConnConfig.Tracer = multitracer.New(tracers...)
+	}
 
-	if setLimits {
-		log.Printf("Setting connection limits")
-		db.Config().MaxConns = 50
-		db.Config().MinConns = 1
-		db.Config().MaxConnLifetime = 10 * time.Minute
-		db.Config().MaxConnIdleTime = 1 * time.Minute
-		db.Config().HealthCheckPeriod = 1 * time.Minute
+	db, err := pgxpool.NewWithConfig(context.Background(), config)
+	if err != nil {
+		return nil, err
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -28,8 +82,21 @@ func OpenDB(dsn string, setLimits bool) (*pgxpool.Pool, error) {
 
 	err = db.Ping(ctx)
 	if err != nil {
+		db.Close()
 		return nil, err
 	}
 
+	params := config.ConnConfig.RuntimeParams
+	log.WithFields(log.Fields{
+		"MaxConns":          config.MaxConns,
+		"MinConns":          config.MinConns,
+		"MaxConnLifetime":   config.MaxConnLifetime.String(),
+		"MaxConnIdleTime":   config.MaxConnIdleTime.String(),
+		"HealthCheckPeriod": config.HealthCheckPeriod.String(),
+		"StatementTimeout":  params["statement_timeout"],
+		"ApplicationName":   params["application_name"],
+		"SearchPath":        params["search_path"],
+	}).Info("Opened Postgres pool")
+
 	return db, nil
 }
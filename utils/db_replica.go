@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const replicaCheckTimeout = 2 * time.Second
+
+type ReplicaConfig struct {
+	// URI enables replica routing when set.
+	URI string `mapstructure:"uri"`
+	// MaxLag is how far behind the primary the replica may replay before
+	// reads go back to the primary.
+	MaxLag        time.Duration `mapstructure:"max-lag"`
+	CheckInterval time.Duration `mapstructure:"check-interval"`
+	Pool          PoolConfig    `mapstructure:"pool"`
+}
+
+// DB sends read-only transactions to the replica while it is healthy and
+// everything else to the primary. Without a replica it is a thin wrapper
+// around the primary pool.
+type DB struct {
+	Primary *pgxpool.Pool
+	Replica *pgxpool.Pool
+	// Retry bounds the retries of InTx, ReadTx and WriteTx.
+	Retry  RetryConfig
+	maxLag time.Duration
+
+	healthy atomic.Bool
+	mu      sync.Mutex
+	lag     time.Duration
+	lastErr error
+}
+
+func NewDB(primary, replica *pgxpool.Pool, maxLag time.Duration) *DB {
+	return &DB{
+		Primary: primary,
+		Replica: replica,
+		maxLag:  maxLag,
+	}
+}
+
+type readPrimaryKey struct{}
+
+// WithReadYourWrites marks ctx so its reads go to the primary once a
+// read-write transaction has begun with it, or straight away when always is
+// set. Requests get this context so a service never reads stale data it just
+// wrote.
+func WithReadYourWrites(ctx context.Context, always bool) context.Context {
+	readPrimary := &atomic.Bool{}
+	readPrimary.Store(always)
+	return context.WithValue(ctx, readPrimaryKey{}, readPrimary)
+}
+
+// BeginTx starts a transaction on the replica when opts is read-only, the
+// replica is healthy and ctx has not asked for the primary.
+func (db *DB) BeginTx(ctx context.Context, opts pgx.TxOptions) (pgx.Tx, error) {
+	readPrimary, _ := ctx.Value(readPrimaryKey{}).(*atomic.Bool)
+	if opts.AccessMode != pgx.ReadOnly {
+		if readPrimary != nil {
+			readPrimary.Store(true)
+		}
+		return db.Primary.BeginTx(ctx, opts)
+	}
+	if db.Replica == nil || !db.healthy.Load() || (readPrimary != nil && readPrimary.Load()) {
+		return db.Primary.BeginTx(ctx, opts)
+	}
+
+	tx, err := db.Replica.BeginTx(ctx, opts)
+	if err != nil && ctx.Err() == nil {
+		Log(ctx).Warnf("Replica unavailable, reading from primary: %v", err)
+		db.setStatus(false, 0, err)
+		return db.Primary.BeginTx(ctx, opts)
+	}
+	return tx, err
+}
+
+func (db *DB) Ping(ctx context.Context) error {
+	return db.Primary.Ping(ctx)
+}
+
+func (db *DB) Close() {
+	if db.Replica != nil {
+		db.Replica.Close()
+	}
+	db.Primary.Close()
+}
+
+// ReplicaStatus reports whether reads are going to the replica, its replay
+// lag at the last check and the last error seen. ok is false when there is no
+// replica.
+func (db *DB) ReplicaStatus() (ok, healthy bool, lag time.Duration, err error) {
+	if db.Replica == nil {
+		return false, false, 0, nil
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return true, db.healthy.Load(), db.lag, db.lastErr
+}
+
+// Run checks the replica every interval until ctx is done. Reads stay on the
+// primary until the first check passes.
+func (db *DB) Run(ctx context.Context, interval time.Duration) {
+	if db.Replica == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		db.checkReplica(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (db *DB) checkReplica(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+	defer cancel()
+
+	// pg_last_xact_replay_timestamp is NULL on a primary or before anything
+	// has been replayed. On an idle primary the lag grows without the replica
+	// falling behind, so a write-free period can send reads to the primary.
+	var lagSeconds float64
+	err := db.Replica.QueryRow(ctx, `
+		SELECT CASE WHEN pg_is_in_recovery()
+			THEN COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+			ELSE 0 END
+	`).Scan(&lagSeconds)
+	if err != nil {
+		// Only log when the replica starts failing, not on every check.
+		if _, _, _, lastErr := db.ReplicaStatus(); lastErr == nil {
+			Log(ctx).Warnf("Replica check failed, reading from primary: %v", err)
+		}
+		db.setStatus(false, 0, err)
+		return
+	}
+
+	lag := time.Duration(lagSeconds * float64(time.Second))
+	healthy := db.maxLag <= 0 || lag <= db.maxLag
+	if healthy != db.healthy.Load() {
+		if healthy {
+			Log(ctx).Infof("Replica healthy (lag %s), reading from replica", lag.Round(time.Millisecond))
+		} else {
+			Log(ctx).Warnf("Replica lag %s exceeds %s, reading from primary", lag.Round(time.Millisecond), db.maxLag)
+		}
+	}
+	db.setStatus(healthy, lag, nil)
+}
+
+func (db *DB) setStatus(healthy bool, lag time.Duration, err error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.healthy.Store(healthy)
+	db.lag = lag
+	db.lastErr = err
+}
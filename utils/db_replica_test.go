@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// newRecordingPool returns a pool whose connections fail to dial, recording
+// name each time one is attempted.
+func newRecordingPool(t *testing.T, name string, dialed *[]string) *pgxpool.Pool {
+	t.Helper()
+	config, err := pgxpool.ParseConfig("postgres://inventory@127.0.0.1/inventory?sslmode=disable&connect_timeout=1")
+	if err != nil {
+		t.Fatalf("Error parsing DSN: %v", err)
+	}
+	config.ConnConfig.DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		*dialed = append(*dialed, name)
+		return nil, errors.New("no database in tests")
+	}
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Error creating pool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func TestDBRouting(t *testing.T) {
+	readOnly := pgx.TxOptions{AccessMode: pgx.ReadOnly}
+	readWrite := pgx.TxOptions{}
+
+	tests := []struct {
+		name    string
+		healthy bool
+		ctx     context.Context
+		opts    []pgx.TxOptions
+		want    []string
+	}{
+		{"unhealthy replica", false, context.Background(), []pgx.TxOptions{readOnly}, []string{"primary"}},
+		{"write", true, context.Background(), []pgx.TxOptions{readWrite}, []string{"primary"}},
+		{"read falls back", true, context.Background(), []pgx.TxOptions{readOnly}, []string{"replica", "primary"}},
+		{"read your writes header", true, WithReadYourWrites(context.Background(), true), []pgx.TxOptions{readOnly}, []string{"primary"}},
+		{"read after write", true, WithReadYourWrites(context.Background(), false), []pgx.TxOptions{readWrite, readOnly}, []string{"primary", "primary"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dialed []string
+			db := NewDB(newRecordingPool(t, "primary", &dialed), newRecordingPool(t, "replica", &dialed), 0)
+			db.healthy.Store(tt.healthy)
+			for _, opts := range tt.opts {
+				if _, err := db.BeginTx(tt.ctx, opts); err == nil {
+					t.Fatal("Expected dial error")
+				}
+			}
+			if !reflect.DeepEqual(dialed, tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, dialed)
+			}
+		})
+	}
+}
+
+func TestDBRoutingMarksReplicaUnhealthy(t *testing.T) {
+	var dialed []string
+	db := NewDB(newRecordingPool(t, "primary", &dialed), newRecordingPool(t, "replica", &dialed), 0)
+	db.healthy.Store(true)
+	db.BeginTx(context.Background(), pgx.TxOptions{AccessMode: pgx.ReadOnly})
+
+	configured, healthy, _, err := db.ReplicaStatus()
+	if !configured || healthy || err == nil {
+		t.Fatalf("Expected replica marked unhealthy with an error, got healthy=%v err=%v", healthy, err)
+	}
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestPoolConfigApply(t *testing.T) {
+	config, err := pgxpool.ParseConfig("postgres://inventory@localhost/inventory?pool_max_conns=4")
+	if err != nil {
+		t.Fatalf("Error parsing DSN: %v", err)
+	}
+	PoolConfig{
+		MinConns:         2,
+		MaxConnLifetime:  10 * time.Minute,
+		StatementTimeout: 1500 * time.Millisecond,
+		ApplicationName:  "inventory",
+		SearchPath:       "inventory,public",
+	}.apply(config)
+
+	if config.MaxConns != 4 {
+		t.Errorf("Expected max conns from DSN to be kept, got %d", config.MaxConns)
+	}
+	if config.MinConns != 2 || config.MaxConnLifetime != 10*time.Minute {
+		t.Errorf("Expected pool sizing applied, got min %d lifetime %s", config.MinConns, config.MaxConnLifetime)
+	}
+	params := config.ConnConfig.RuntimeParams
+	for key, want := range map[string]string{
+		"statement_timeout": "1500",
+		"application_name":  "inventory",
+		"search_path":       "inventory,public",
+	} {
+		if params[key] != want {
+			t.Errorf("Expected %s=%q, got %q", key, want, params[key])
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultBaseDelay   = 20 * time.Millisecond
+	defaultMaxDelay    = time.Second
+)
+
+// RetryConfig bounds how transactions are retried. Zero values use the
+// defaults.
+type RetryConfig struct {
+	// MaxAttempts counts the first try; 1 disables retries.
+	MaxAttempts int           `mapstructure:"max-attempts"`
+	BaseDelay   time.Duration `mapstructure:"base-delay"`
+	MaxDelay    time.Duration `mapstructure:"max-delay"`
+}
+
+func (c RetryConfig) withDefaults() RetryConfig {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultMaxAttempts
+	}
+	if c.BaseDelay <= 0 {
+		c.BaseDelay = defaultBaseDelay
+	}
+	if c.MaxDelay <= 0 {
+		c.MaxDelay = defaultMaxDelay
+	}
+	return c
+}
+
+// backoff is full jitter over an exponentially growing window, capped at
+// MaxDelay.
+func (c RetryConfig) backoff(attempt int) time.Duration {
+	window := c.BaseDelay << (attempt - 1)
+	if window <= 0 || window > c.MaxDelay {
+		window = c.MaxDelay
+	}
+	return time.Duration(rand.Int64N(int64(window) + 1))
+}
+
+// ReadTx runs fn in a read-only transaction, see InTx.
+func (db *DB) ReadTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	return db.InTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly}, fn)
+}
+
+// WriteTx runs fn in a read-write transaction, see InTx.
+func (db *DB) WriteTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	return db.InTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadWrite}, fn)
+}
+
+// InTx runs fn in a transaction with opts and commits it when fn returns nil.
+// Serialization failures, deadlocks and dropped connections roll back and
+// run fn again, so fn must not have side effects outside tx. Errors are
+// returned as they are so callers can still match them with errors.Is.
+func (db *DB) InTx(ctx context.Context, opts pgx.TxOptions, fn func(tx pgx.Tx) error) error {
+	retry := db.Retry.withDefaults()
+	for attempt := 1; ; attempt++ {
+		committing, err := db.runTx(ctx, opts, fn)
+		if err == nil {
+			return nil
+		}
+		if attempt >= retry.MaxAttempts || ctx.Err() != nil || !retryable(err, committing && opts.AccessMode != pgx.ReadOnly) {
+			return err
+		}
+
+		delay := retry.backoff(attempt)
+		Log(ctx).Warnf("Retrying transaction in %s after attempt %d failed: %v", delay, attempt, err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// runTx reports whether the error came from the commit itself.
+func (db *DB) runTx(ctx context.Context, opts pgx.TxOptions, fn func(tx pgx.Tx) error) (bool, error) {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		Log(ctx).Errorf("Failed to begin transaction: %v", err)
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(tx); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		Log(ctx).Errorf("Failed to commit transaction: %v", err)
+		return true, err
+	}
+	return false, nil
+}
+
+// retryable reports whether running the transaction again may succeed. A
+// connection lost during the commit of a write is not retried unless pgx
+// knows nothing was sent, since the commit may have gone through.
+func retryable(err error, committingWrite bool) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "40001", // serialization_failure
+			"40P01", // deadlock_detected
+			"57P01", // admin_shutdown
+			"57P02", // crash_shutdown
+			"57P03": // cannot_connect_now
+			return true
+		}
+		// Class 08 is connection exceptions.
+		return strings.HasPrefix(pgErr.Code, "08") && !committingWrite
+	}
+	if pgconn.SafeToRetry(err) {
+		return true
+	}
+	if committingWrite {
+		return false
+	}
+	var netErr net.Error
+	var connectErr *pgconn.ConnectError
+	return errors.As(err, &connectErr) ||
+		errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE)
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		committingWrite bool
+		want            bool
+	}{
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, false, true},
+		{"deadlock", fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: "40P01"}), false, true},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false, false},
+		{"connection exception", &pgconn.PgError{Code: "08006"}, false, true},
+		{"connection exception on commit", &pgconn.PgError{Code: "08006"}, true, false},
+		{"connection reset", io.ErrUnexpectedEOF, false, true},
+		{"connection reset on commit", io.ErrUnexpectedEOF, true, false},
+		{"no rows", pgx.ErrNoRows, false, false},
+		{"other", errors.New("boom"), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryable(tt.err, tt.committingWrite); got != tt.want {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	retry := RetryConfig{BaseDelay: 10 * time.Millisecond, MaxDelay: 30 * time.Millisecond}.withDefaults()
+	for attempt := 1; attempt <= 10; attempt++ {
+		if delay := retry.backoff(attempt); delay < 0 || delay > retry.MaxDelay {
+			t.Fatalf("Attempt %d: delay %s outside [0, %s]", attempt, delay, retry.MaxDelay)
+		}
+	}
+}
+
+func TestInTxRetries(t *testing.T) {
+	var dialed []string
+	db := NewDB(newRecordingPool(t, "primary", &dialed), nil, 0)
+	db.Retry = RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	called := false
+	err := db.ReadTx(context.Background(), func(tx pgx.Tx) error {
+		called = true
+		return nil
+	})
+	if err == nil {
+		t.Fatal("Expected dial error")
+	}
+	if called {
+		t.Fatal("Expected fn not to run without a connection")
+	}
+	if len(dialed) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(dialed))
+	}
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type logEntryKey struct{}
+
+// WithLogEntry returns a copy of ctx carrying entry, so every line logged
+// through Log for the same request shares its fields.
+func WithLogEntry(ctx context.Context, entry *log.Entry) context.Context {
+	return context.WithValue(ctx, logEntryKey{}, entry)
+}
+
+// Log returns the request-scoped entry stored in ctx, or the standard logger
+// when there is none, e.g. in background workers.
+func Log(ctx context.Context) *log.Entry {
+	if entry, ok := ctx.Value(logEntryKey{}).(*log.Entry); ok {
+		return entry.WithContext(ctx)
+	}
+	return log.WithContext(ctx)
+}
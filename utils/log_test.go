@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestLog(t *testing.T) {
+	ctx := WithLogEntry(context.Background(), log.WithField("RequestID", "abc"))
+	if got := Log(ctx).Data["RequestID"]; got != "abc" {
+		t.Fatalf("Expected RequestID abc, got %v", got)
+	}
+	if Log(ctx).Context != ctx {
+		t.Fatal("Expected entry to carry the context")
+	}
+	if _, ok := Log(context.Background()).Data["RequestID"]; ok {
+		t.Fatal("Expected no RequestID without a request entry")
+	}
+}
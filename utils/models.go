@@ -2,18 +2,269 @@ package utils
 
 import "time"
 
+const (
+	OwnerStatusActive   = "active"
+	OwnerStatusInactive = "inactive"
+	// OwnerStatusDeparting marks an owner who is being offboarded and whose
+	// devices are being recovered.
+	OwnerStatusDeparting = "departing"
+	// OwnerStatusRemoved marks a deleted owner who is kept because lost
+	// devices are still on record under them.
+	OwnerStatusRemoved = "removed"
+)
+
+const (
+	// DeviceStatusActive marks a device that is deployed or ready to be.
+	DeviceStatusActive = "active"
+	DeviceStatusLost   = "lost"
+	// DeviceStatusReceived marks a device created by receiving a purchase
+	// order line that has not been deployed yet.
+	DeviceStatusReceived = "received"
+	// DeviceStatusInRepair marks a device with an unresolved repair ticket.
+	DeviceStatusInRepair = "in_repair"
+)
+
+const (
+	PurchaseOrderDraft             = "draft"
+	PurchaseOrderOrdered           = "ordered"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"
+	PurchaseOrderCancelled         = "cancelled"
+)
+
+// Kinds of consumable stock movement.
+const (
+	MovementReceive  = "receive"
+	MovementIssue    = "issue"
+	MovementAdjust   = "adjust"
+	MovementTransfer = "transfer"
+)
+
+const (
+	ReservationReserved   = "reserved"
+	ReservationCheckedOut = "checked_out"
+	ReservationReturned   = "returned"
+	ReservationCancelled  = "cancelled"
+)
+
+const (
+	RepairOpen         = "open"
+	RepairWaitingParts = "waiting_parts"
+	RepairSentToVendor = "sent_to_vendor"
+	RepairResolved     = "resolved"
+)
+
+const (
+	RecoveryTaskOpen       = "open"
+	RecoveryTaskReassigned = "reassigned"
+	RecoveryTaskLost       = "lost"
+)
+
+const (
+	AuditOpen   = "open"
+	AuditClosed = "closed"
+)
+
+// How a scan in an audit compares to the expected device list.
+const (
+	AuditFound         = "found"
+	AuditWrongLocation = "wrong_location"
+	AuditUnexpected    = "unexpected"
+	AuditUnknown       = "unknown"
+	AuditMissing       = "missing"
+)
+
+// Depreciation methods a Type can use for its devices.
+const (
+	DepreciationNone             = "none"
+	DepreciationStraightLine     = "straight_line"
+	DepreciationDecliningBalance = "declining_balance"
+)
+
+const (
+	LocationKindCampus   = "campus"
+	LocationKindBuilding = "building"
+	LocationKindFloor    = "floor"
+	LocationKindRoom     = "room"
+	LocationKindOther    = "other"
+)
+
 type Owner struct {
 	ID        string  `json:"id"`
 	FirstName string  `json:"first_name"`
 	LastName  string  `json:"last_name"`
 	CampusID  *string `json:"campus_id"`
 	Email     string  `json:"email"`
+	Status    string  `json:"status"`
+	// DepartmentID is the department the owner belongs to, if known.
+	DepartmentID *string `json:"department_id"`
+}
+
+// Department is a node in the organisation hierarchy. Each department has its
+// own cost center code, which devices can be charged to.
+type Department struct {
+	ID         string  `json:"id"`
+	ParentID   *string `json:"parent_id"`
+	Name       string  `json:"name"`
+	CostCenter string  `json:"cost_center"`
+}
+
+// DepartmentReport totals the devices charged to a department. The Direct
+// figures cover the department alone, the others its whole subtree.
+type DepartmentReport struct {
+	Department
+	DirectDevices int `json:"direct_devices"`
+	Devices       int `json:"devices"`
+	// Values holds the purchase value per currency, so amounts in different
+	// currencies are never added together.
+	Values []*DepartmentValue `json:"values"`
+}
+
+type DepartmentValue struct {
+	Currency    string  `json:"currency"`
+	DirectValue float64 `json:"direct_value"`
+	Value       float64 `json:"value"`
+}
+
+// BookValue is the value of one device at AsOf after depreciation.
+type BookValue struct {
+	DeviceID     string    `json:"device_id"`
+	Name         string    `json:"name"`
+	TypeID       string    `json:"type_id"`
+	DepartmentID *string   `json:"department_id"`
+	PurchaseDate time.Time `json:"purchase_date"`
+	Currency     string    `json:"currency"`
+	Method       string    `json:"depreciation_method"`
+	Cost         float64   `json:"cost"`
+	Accumulated  float64   `json:"accumulated_depreciation"`
+	BookValue    float64   `json:"book_value"`
+}
+
+// FinancialTotal sums the book values of one type or department in one
+// currency. GroupID is nil for devices that belong to no department.
+type FinancialTotal struct {
+	GroupID     *string `json:"group_id"`
+	Name        string  `json:"name"`
+	Currency    string  `json:"currency"`
+	Devices     int     `json:"devices"`
+	Cost        float64 `json:"cost"`
+	Accumulated float64 `json:"accumulated_depreciation"`
+	BookValue   float64 `json:"book_value"`
 }
 
 type Type struct {
 	ID          string  `json:"id"`
 	Name        string  `json:"name"`
 	Description *string `json:"description"`
+	// DepreciationMethod is one of the Depreciation constants. Devices are
+	// written down over UsefulLifeMonths to SalvagePercent of their cost.
+	DepreciationMethod string  `json:"depreciation_method"`
+	UsefulLifeMonths   *int    `json:"useful_life_months"`
+	SalvagePercent     float64 `json:"salvage_percent"`
+	// DecliningFactor multiplies the straight-line rate for the declining
+	// balance method; nil means double declining (2).
+	DecliningFactor *float64 `json:"declining_factor"`
+	// AssetTagPrefix starts the asset tags of the type's devices, e.g. LAP
+	// for LAP-000123. When nil the prefix is taken from the type's name.
+	AssetTagPrefix *string `json:"asset_tag_prefix"`
+}
+
+// Location is a node in the campus, building, floor and room hierarchy.
+// Locations can nest to any depth; ParentID is nil for top-level locations.
+type Location struct {
+	ID          string  `json:"id"`
+	ParentID    *string `json:"parent_id"`
+	Name        string  `json:"name"`
+	Kind        string  `json:"kind"`
+	Description *string `json:"description"`
+}
+
+type Vendor struct {
+	ID      string  `json:"id"`
+	Name    string  `json:"name"`
+	Email   *string `json:"email"`
+	Phone   *string `json:"phone"`
+	Website *string `json:"website"`
+	Notes   *string `json:"notes"`
+}
+
+// PurchaseOrder is an order placed with a vendor. Its lines are filled in
+// when it is loaded on its own.
+type PurchaseOrder struct {
+	ID        string               `json:"id"`
+	PONumber  string               `json:"po_number"`
+	VendorID  string               `json:"vendor_id"`
+	Status    string               `json:"status"`
+	Currency  string               `json:"currency"`
+	Note      string               `json:"note"`
+	CreatedAt time.Time            `json:"created_at"`
+	CreatedBy string               `json:"created_by"`
+	OrderedAt *time.Time           `json:"ordered_at"`
+	Lines     []*PurchaseOrderLine `json:"lines,omitempty"`
+}
+
+// PurchaseOrderLine orders Quantity devices of one type. Received counts
+// the devices received against it so far.
+type PurchaseOrderLine struct {
+	ID              string  `json:"id"`
+	PurchaseOrderID string  `json:"purchase_order_id"`
+	TypeID          string  `json:"type_id"`
+	Description     string  `json:"description"`
+	Quantity        int     `json:"quantity"`
+	UnitCost        float64 `json:"unit_cost"`
+	Received        int     `json:"received"`
+}
+
+// PurchaseOrderReceipt records one delivery against a line and the devices
+// it created.
+type PurchaseOrderReceipt struct {
+	ID              string    `json:"id"`
+	PurchaseOrderID string    `json:"purchase_order_id"`
+	LineID          string    `json:"line_id"`
+	Quantity        int       `json:"quantity"`
+	DeviceIDs       []string  `json:"device_ids"`
+	ReceivedAt      time.Time `json:"received_at"`
+	ReceivedBy      string    `json:"received_by"`
+	Note            string    `json:"note"`
+}
+
+// Consumable is a stocked item counted by quantity rather than tracked by
+// serial number, such as cables or toner.
+type Consumable struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	SKU         *string `json:"sku"`
+	Unit        string  `json:"unit"`
+	Description *string `json:"description"`
+	// ReorderThreshold flags the item as low on stock once the quantity on
+	// hand across all locations falls to it; 0 disables the check.
+	ReorderThreshold int `json:"reorder_threshold"`
+	ReorderQuantity  int `json:"reorder_quantity"`
+	// OnHand is the quantity across all locations. It is computed from the
+	// stock levels and ignored on writes.
+	OnHand int `json:"on_hand"`
+}
+
+type ConsumableStock struct {
+	ConsumableID string `json:"consumable_id"`
+	LocationID   string `json:"location_id"`
+	Quantity     int    `json:"quantity"`
+}
+
+// ConsumableMovement is an entry in the append-only stock ledger. Quantity is
+// always positive and leaves FromLocationID, arrives at ToLocationID, or both
+// for a transfer.
+type ConsumableMovement struct {
+	ID             string    `json:"id"`
+	ConsumableID   string    `json:"consumable_id"`
+	Kind           string    `json:"kind"`
+	Quantity       int       `json:"quantity"`
+	FromLocationID *string   `json:"from_location_id"`
+	ToLocationID   *string   `json:"to_location_id"`
+	OwnerID        *string   `json:"owner_id"`
+	Note           string    `json:"note"`
+	CreatedAt      time.Time `json:"created_at"`
+	CreatedBy      string    `json:"created_by"`
 }
 
 type TypeProperty struct {
@@ -25,13 +276,26 @@ type TypeProperty struct {
 }
 
 type Device struct {
-	ID           string    `json:"id"`
-	SerialNumber string    `json:"serial_number"`
-	Name         string    `json:"name"`
-	TypeID       string    `json:"type_id"`
-	OwnerID      string    `json:"owner_id"`
+	ID           string  `json:"id"`
+	SerialNumber string  `json:"serial_number"`
+	Name         string  `json:"name"`
+	TypeID       string  `json:"type_id"`
+	OwnerID      string  `json:"owner_id"`
+	LocationID   *string `json:"location_id"`
+	// CostCenter charges the device to a department's cost center instead of
+	// its owner's department.
+	CostCenter   *string   `json:"cost_center"`
 	PurchaseDate time.Time `json:"purchase_date"`
-	Status       string    `json:"status"`
+	PurchaseCost *float64  `json:"purchase_cost"`
+	// Currency is the ISO 4217 code of PurchaseCost; nil means the
+	// configured default currency.
+	Currency   *string `json:"currency"`
+	Vendor     *string `json:"vendor"`
+	PONumber   *string `json:"po_number"`
+	InvoiceRef *string `json:"invoice_ref"`
+	Status     string  `json:"status"`
+	// AssetTag is assigned when the device is created and never changes.
+	AssetTag *string `json:"asset_tag"`
 }
 
 type DeviceProperty struct {
@@ -41,8 +305,16 @@ type DeviceProperty struct {
 	Value          string `json:"value"`
 }
 
+// LabeledDeviceProperty is a device property together with the name and data
+// type of the type property it fills in.
+type LabeledDeviceProperty struct {
+	DeviceProperty
+	Name     string `json:"name"`
+	DataType string `json:"data_type"`
+}
+
 type DevicePhoto struct {
-	ID        string `json:"id"`
+	ID        string    `json:"id"`
 	DeviceID  string    `json:"device_id"`
 	Photo     string    `json:"photo"`
 	CreatedAt time.Time `json:"created_at"`
@@ -56,3 +328,183 @@ type DeviceLog struct {
 	CreatedAt time.Time `json:"created_at"`
 	CreatedBy string    `json:"created_by"`
 }
+
+// DeviceComponent attaches a child device, such as a monitor or dock, to a
+// parent device. Detached relations are kept as history.
+type DeviceComponent struct {
+	ID       string `json:"id"`
+	ParentID string `json:"parent_id"`
+	ChildID  string `json:"child_id"`
+	// CascadeStatus copies status changes of the parent to the child while
+	// it is attached.
+	CascadeStatus bool       `json:"cascade_status"`
+	Note          string     `json:"note"`
+	AttachedAt    time.Time  `json:"attached_at"`
+	AttachedBy    string     `json:"attached_by"`
+	DetachedAt    *time.Time `json:"detached_at"`
+	DetachedBy    *string    `json:"detached_by"`
+}
+
+// DeviceTree is a device with its currently attached components, nested to
+// any depth. Relation is the attachment to the parent and is nil at the root.
+type DeviceTree struct {
+	Device
+	Relation   *DeviceComponent `json:"relation,omitempty"`
+	Components []*DeviceTree    `json:"components"`
+}
+
+// Loaner is a device in the loaner pool, which owners can reserve.
+type Loaner struct {
+	DeviceID string    `json:"device_id"`
+	Note     string    `json:"note"`
+	AddedAt  time.Time `json:"added_at"`
+	AddedBy  string    `json:"added_by"`
+}
+
+// Reservation books a loaner for a time window. A reservation made for a type
+// is given a free device of that type when it is booked.
+type Reservation struct {
+	ID        string    `json:"id"`
+	DeviceID  string    `json:"device_id"`
+	TypeID    string    `json:"type_id"`
+	OwnerID   string    `json:"owner_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Status    string    `json:"status"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+}
+
+// DeviceAssignment is a device handed to an owner, such as a checked out
+// loaner. It is open until ReturnedAt is set.
+type DeviceAssignment struct {
+	ID            string     `json:"id"`
+	DeviceID      string     `json:"device_id"`
+	OwnerID       string     `json:"owner_id"`
+	ReservationID *string    `json:"reservation_id"`
+	AssignedAt    time.Time  `json:"assigned_at"`
+	AssignedBy    string     `json:"assigned_by"`
+	DueAt         *time.Time `json:"due_at"`
+	ReturnedAt    *time.Time `json:"returned_at"`
+	ReturnedBy    *string    `json:"returned_by"`
+}
+
+// OverdueLoan is an open assignment past its due time, with the details
+// needed to chase it up.
+type OverdueLoan struct {
+	DeviceAssignment
+	DeviceName string `json:"device_name"`
+	OwnerName  string `json:"owner_name"`
+	OwnerEmail string `json:"owner_email"`
+}
+
+// RepairTicket tracks a device through repair. PriorStatus is the device
+// status before the ticket was opened and is restored when it is resolved.
+type RepairTicket struct {
+	ID          string     `json:"id"`
+	DeviceID    string     `json:"device_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Assignee    *string    `json:"assignee"`
+	Cost        *float64   `json:"cost"`
+	Currency    string     `json:"currency"`
+	RMANumber   *string    `json:"rma_number"`
+	PriorStatus string     `json:"prior_status"`
+	OpenedAt    time.Time  `json:"opened_at"`
+	OpenedBy    string     `json:"opened_by"`
+	ResolvedAt  *time.Time `json:"resolved_at"`
+	ResolvedBy  *string    `json:"resolved_by"`
+	Resolution  *string    `json:"resolution"`
+	// Comments is only filled in when a single ticket is fetched.
+	Comments []*RepairComment `json:"comments,omitempty"`
+}
+
+type RepairComment struct {
+	ID        string    `json:"id"`
+	TicketID  string    `json:"ticket_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+}
+
+// RecoveryTask tracks getting one device back from a departing owner. It is
+// resolved either by reassigning the device or by marking it lost.
+type RecoveryTask struct {
+	ID         string     `json:"id"`
+	OwnerID    string     `json:"owner_id"`
+	DeviceID   string     `json:"device_id"`
+	Status     string     `json:"status"`
+	Note       string     `json:"note"`
+	CreatedAt  time.Time  `json:"created_at"`
+	CreatedBy  string     `json:"created_by"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+	ResolvedBy *string    `json:"resolved_by"`
+}
+
+// Audit is a stocktake of the devices in a location or a department, each
+// including their sub-locations or sub-departments. The devices in scope are
+// fixed when the audit is created.
+type Audit struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	LocationID   *string    `json:"location_id"`
+	DepartmentID *string    `json:"department_id"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CreatedBy    string     `json:"created_by"`
+	ClosedAt     *time.Time `json:"closed_at"`
+	ClosedBy     *string    `json:"closed_by"`
+}
+
+// AuditScan is one code read during an audit. DeviceID is nil when the code
+// matched no device, and LocationID is where the device was found, if given.
+// Result is worked out when the scan is recorded and is not stored.
+type AuditScan struct {
+	ID         string    `json:"id"`
+	AuditID    string    `json:"audit_id"`
+	Code       string    `json:"code"`
+	DeviceID   *string   `json:"device_id"`
+	LocationID *string   `json:"location_id"`
+	ScannedAt  time.Time `json:"scanned_at"`
+	ScannedBy  string    `json:"scanned_by"`
+	Result     string    `json:"result,omitempty"`
+}
+
+// AuditEntry is a device in an audit report. LocationID is where the
+// inventory says the device is and ScannedLocationID where its latest scan
+// found it.
+type AuditEntry struct {
+	DeviceID          string     `json:"device_id"`
+	Name              string     `json:"name"`
+	SerialNumber      *string    `json:"serial_number"`
+	Status            string     `json:"status"`
+	LocationID        *string    `json:"location_id"`
+	ScannedLocationID *string    `json:"scanned_location_id"`
+	ScannedAt         *time.Time `json:"scanned_at"`
+	ScannedBy         *string    `json:"scanned_by"`
+}
+
+// AuditReport reconciles the expected devices of an audit with its scans.
+// Unknown holds the scanned codes that matched no device.
+type AuditReport struct {
+	Audit         *Audit        `json:"audit"`
+	Found         []*AuditEntry `json:"found"`
+	WrongLocation []*AuditEntry `json:"wrong_location"`
+	Missing       []*AuditEntry `json:"missing"`
+	Unexpected    []*AuditEntry `json:"unexpected"`
+	Unknown       []string      `json:"unknown"`
+}
+
+// DeviceDetail is a device with the relations requested through ?expand=.
+// Relations that were not requested are left empty and omitted from JSON.
+type DeviceDetail struct {
+	Device
+	Owner      *Owner                   `json:"owner,omitempty"`
+	Type       *Type                    `json:"type,omitempty"`
+	Location   *Location                `json:"location,omitempty"`
+	Properties []*LabeledDeviceProperty `json:"properties,omitempty"`
+	Logs       []*DeviceLog             `json:"logs,omitempty"`
+	Photos     []*DevicePhoto           `json:"photos,omitempty"`
+}
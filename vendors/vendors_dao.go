@@ -0,0 +1,124 @@
+package vendors
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Dao struct{}
+
+func NewDao() *Dao {
+	return &Dao{}
+}
+
+func (d *Dao) GetVendor(ctx context.Context, tx pgx.Tx, id string) (*utils.Vendor, error) {
+	utils.Log(ctx).Printf("Fetching vendor with ID: %s", id)
+	query := `SELECT id, name, email, phone, website, notes FROM vendors WHERE id = $1`
+	var vendor utils.Vendor
+	err := tx.QueryRow(ctx, query, id).Scan(&vendor.ID, &vendor.Name, &vendor.Email, &vendor.Phone, &vendor.Website, &vendor.Notes)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching vendor with ID %s: %v", id, err)
+		return nil, err
+	}
+	return &vendor, nil
+}
+
+func (d *Dao) GetVendorByName(ctx context.Context, tx pgx.Tx, name string) (*utils.Vendor, error) {
+	utils.Log(ctx).Printf("Fetching vendor with name: %s", name)
+	query := `SELECT id, name, email, phone, website, notes FROM vendors WHERE name = $1`
+	var vendor utils.Vendor
+	err := tx.QueryRow(ctx, query, name).Scan(&vendor.ID, &vendor.Name, &vendor.Email, &vendor.Phone, &vendor.Website, &vendor.Notes)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching vendor with name %s: %v", name, err)
+		return nil, err
+	}
+	return &vendor, nil
+}
+
+func (d *Dao) GetVendors(ctx context.Context, tx pgx.Tx) ([]*utils.Vendor, error) {
+	utils.Log(ctx).Println("Fetching all vendors")
+	query := `SELECT id, name, email, phone, website, notes FROM vendors ORDER BY name`
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error fetching vendors: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var vendors []*utils.Vendor
+	for rows.Next() {
+		var vendor utils.Vendor
+		if err := rows.Scan(&vendor.ID, &vendor.Name, &vendor.Email, &vendor.Phone, &vendor.Website, &vendor.Notes); err != nil {
+			utils.Log(ctx).Errorf("Error scanning vendor row: %v", err)
+			return nil, err
+		}
+		vendors = append(vendors, &vendor)
+	}
+	if err := rows.Err(); err != nil {
+		utils.Log(ctx).Errorf("Error iterating over vendor rows: %v", err)
+		return nil, err
+	}
+	return vendors, nil
+}
+
+func (d *Dao) CreateVendor(ctx context.Context, tx pgx.Tx, vendor *utils.Vendor) error {
+	utils.Log(ctx).Printf("Creating vendor: %+v", vendor)
+	if vendor.ID == "" {
+		id, err := gonanoid.New()
+		if err != nil {
+			utils.Log(ctx).Errorf("Error generating ID for new vendor: %v", err)
+			return err
+		}
+		vendor.ID = id
+	}
+	query := `INSERT INTO vendors (id, name, email, phone, website, notes) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := tx.Exec(ctx, query, vendor.ID, vendor.Name, vendor.Email, vendor.Phone, vendor.Website, vendor.Notes)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error creating vendor: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (d *Dao) UpdateVendor(ctx context.Context, tx pgx.Tx, vendor *utils.Vendor) error {
+	utils.Log(ctx).Printf("Updating vendor: %+v", vendor)
+	query := `UPDATE vendors SET name = $2, email = $3, phone = $4, website = $5, notes = $6 WHERE id = $1`
+	tag, err := tx.Exec(ctx, query, vendor.ID, vendor.Name, vendor.Email, vendor.Phone, vendor.Website, vendor.Notes)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error updating vendor with ID %s: %v", vendor.ID, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (d *Dao) DeleteVendor(ctx context.Context, tx pgx.Tx, id string) error {
+	utils.Log(ctx).Printf("Deleting vendor with ID: %s", id)
+	query := `DELETE FROM vendors WHERE id = $1`
+	tag, err := tx.Exec(ctx, query, id)
+	if err != nil {
+		utils.Log(ctx).Errorf("Error deleting vendor with ID %s: %v", id, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// CountPurchaseOrders counts the purchase orders placed with a vendor.
+func (d *Dao) CountPurchaseOrders(ctx context.Context, tx pgx.Tx, id string) (int, error) {
+	utils.Log(ctx).Printf("Counting purchase orders for vendor with ID: %s", id)
+	query := `SELECT count(*) FROM purchase_orders WHERE vendor_id = $1`
+	var count int
+	if err := tx.QueryRow(ctx, query, id).Scan(&count); err != nil {
+		utils.Log(ctx).Errorf("Error counting purchase orders for vendor %s: %v", id, err)
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,99 @@
+package vendors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+type Handler struct {
+	svc *Service
+	// atz *authz.Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{
+		svc: svc,
+		// atz: atz,
+	}
+}
+
+func (h *Handler) GetVendor(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	vendor, err := h.svc.GetVendor(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(vendor)
+}
+
+func (h *Handler) GetVendors(w http.ResponseWriter, r *http.Request) {
+	vendors, err := h.svc.GetVendors(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(vendors)
+}
+
+func (h *Handler) CreateVendor(w http.ResponseWriter, r *http.Request) {
+	var vendor utils.Vendor
+	if err := json.NewDecoder(r.Body).Decode(&vendor); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	if err := h.svc.CreateVendor(r.Context(), &vendor); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(vendor)
+}
+
+func (h *Handler) UpdateVendor(w http.ResponseWriter, r *http.Request) {
+	var vendor utils.Vendor
+	if err := json.NewDecoder(r.Body).Decode(&vendor); err != nil {
+		http.Error(w, err.Error(), utils.DecodeStatus(err))
+		return
+	}
+	vendor.ID = mux.Vars(r)["id"]
+	if err := h.svc.UpdateVendor(r.Context(), &vendor); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(vendor)
+}
+
+func (h *Handler) DeleteVendor(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if err := h.svc.DeleteVendor(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrInvalidRequest):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrNameTaken), errors.Is(err, ErrVendorInUse):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,146 @@
+package vendors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rickCrz7/Inventory-API/tracing"
+	"github.com/rickCrz7/Inventory-API/utils"
+)
+
+var (
+	ErrInvalidRequest = errors.New("invalid vendor request")
+	// ErrNameTaken is returned when another vendor already has the name.
+	ErrNameTaken = errors.New("vendor name is already used")
+	// ErrVendorInUse is returned when deleting a vendor that has purchase
+	// orders.
+	ErrVendorInUse = errors.New("vendor has purchase orders")
+)
+
+type Service struct {
+	dao *Dao
+	pdb *utils.DB
+}
+
+func NewService(dao *Dao, pdb *utils.DB) *Service {
+	return &Service{
+		dao: dao,
+		pdb: pdb,
+	}
+}
+
+func (s *Service) GetVendor(ctx context.Context, id string) (*utils.Vendor, error) {
+	ctx, span := tracing.Start(ctx, "vendors.GetVendor")
+	defer span.End()
+
+	var vendor *utils.Vendor
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		vendor, err = s.dao.GetVendor(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get vendor: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vendor, nil
+}
+
+func (s *Service) GetVendors(ctx context.Context) ([]*utils.Vendor, error) {
+	ctx, span := tracing.Start(ctx, "vendors.GetVendors")
+	defer span.End()
+
+	var vendors []*utils.Vendor
+	err := s.pdb.ReadTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		vendors, err = s.dao.GetVendors(ctx, tx)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to get vendors: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vendors, nil
+}
+
+func (s *Service) CreateVendor(ctx context.Context, vendor *utils.Vendor) error {
+	ctx, span := tracing.Start(ctx, "vendors.CreateVendor")
+	defer span.End()
+
+	if strings.TrimSpace(vendor.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRequest)
+	}
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.checkName(ctx, tx, vendor); err != nil {
+			return err
+		}
+		if err := s.dao.CreateVendor(ctx, tx, vendor); err != nil {
+			utils.Log(ctx).Errorf("Failed to create vendor: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+func (s *Service) UpdateVendor(ctx context.Context, vendor *utils.Vendor) error {
+	ctx, span := tracing.Start(ctx, "vendors.UpdateVendor")
+	defer span.End()
+
+	if strings.TrimSpace(vendor.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRequest)
+	}
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		if err := s.checkName(ctx, tx, vendor); err != nil {
+			return err
+		}
+		if err := s.dao.UpdateVendor(ctx, tx, vendor); err != nil {
+			utils.Log(ctx).Errorf("Failed to update vendor: %v", err)
+			return err
+		}
+		return nil
+	})
+}
+
+func (s *Service) checkName(ctx context.Context, tx pgx.Tx, vendor *utils.Vendor) error {
+	existing, err := s.dao.GetVendorByName(ctx, tx, vendor.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != vendor.ID {
+		return fmt.Errorf("%w: %s", ErrNameTaken, vendor.Name)
+	}
+	return nil
+}
+
+func (s *Service) DeleteVendor(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "vendors.DeleteVendor")
+	defer span.End()
+
+	return s.pdb.WriteTx(ctx, func(tx pgx.Tx) error {
+		count, err := s.dao.CountPurchaseOrders(ctx, tx, id)
+		if err != nil {
+			utils.Log(ctx).Errorf("Failed to count vendor purchase orders: %v", err)
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: %d purchase orders", ErrVendorInUse, count)
+		}
+		if err := s.dao.DeleteVendor(ctx, tx, id); err != nil {
+			utils.Log(ctx).Errorf("Failed to delete vendor: %v", err)
+			return err
+		}
+		return nil
+	})
+}